@@ -11,8 +11,8 @@ import (
 	"strings"
 	"time"
 
-	"tech-letter/cmd/api/trace"
 	"tech-letter/cmd/internal/logger"
+	"tech-letter/cmd/internal/trace"
 )
 
 // Config는 HTTP 클라이언트 공통 설정을 캡슐화한다.
@@ -8,8 +8,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 
-	"tech-letter/cmd/api/trace"
 	"tech-letter/cmd/internal/logger"
+	"tech-letter/cmd/internal/trace"
 )
 
 const (
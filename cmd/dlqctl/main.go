@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"tech-letter/cmd/internal/eventbus"
+	"tech-letter/cmd/internal/logger"
+)
+
+const usage = `dlqctl: eventbus DLQ 조회/재처리/정리 도구
+
+사용법:
+  dlqctl list    [-topic <base>] [-id <event_id>] [-error <substr>] [-since <RFC3339>] [-until <RFC3339>] [-limit N]
+  dlqctl show    [-topic <base>] -id <event_id>
+  dlqctl replay  -topic <base> [-id ...] [-error ...] [-since ...] [-until ...] [-limit N] [-dry-run] [-force]
+  dlqctl purge   -topic <base> [-dry-run]
+
+-topic 을 생략하면 list/show 는 eventbus.AllTopics 의 모든 DLQ 를 조회한다.
+재처리 기록은 -ledger (기본값: DLQCTL_LEDGER 환경변수 또는 dlqctl-replays.jsonl) 파일에 남으며,
+purge 는 파티션 앞쪽에서부터 연속으로 재처리된 레코드만 삭제한다.
+EVENTBUS_BLOB_STORE 가 설정되어 있으면 replay/purge 는 더 이상 참조되지 않는 DLQ 레코드의 페이로드 블롭도 삭제한다.
+`
+
+func main() {
+	logger.InitFromEnv("LOG_LEVEL")
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "list":
+		err = runList(ctx, args)
+	case "show":
+		err = runShow(ctx, args)
+	case "replay":
+		err = runReplay(ctx, args)
+	case "purge":
+		err = runPurge(ctx, args)
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령: %s\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dlqctl %s 실패: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+// commonFlags는 조회 계열 명령이 공유하는 플래그입니다.
+type commonFlags struct {
+	topic  string
+	id     string
+	errStr string
+	since  string
+	until  string
+	limit  int
+	ledger string
+}
+
+func newFlagSet(name string, cf *commonFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.StringVar(&cf.topic, "topic", "", "기본 토픽 이름 (예: tech-letter.post.summary)")
+	fs.StringVar(&cf.id, "id", "", "이벤트 ID")
+	fs.StringVar(&cf.errStr, "error", "", "오류 메시지에 포함된 문자열")
+	fs.StringVar(&cf.since, "since", "", "DLQ 적재 시각 하한 (RFC3339)")
+	fs.StringVar(&cf.until, "until", "", "DLQ 적재 시각 상한 (RFC3339)")
+	fs.IntVar(&cf.limit, "limit", 0, "최대 레코드 수 (0 = 제한 없음)")
+	defaultLedger := os.Getenv("DLQCTL_LEDGER")
+	if defaultLedger == "" {
+		defaultLedger = "dlqctl-replays.jsonl"
+	}
+	fs.StringVar(&cf.ledger, "ledger", defaultLedger, "재처리 기록 파일 경로")
+	return fs
+}
+
+func (cf commonFlags) filter() (eventbus.DLQFilter, error) {
+	f := eventbus.DLQFilter{EventID: cf.id, ErrorContains: cf.errStr, Limit: cf.limit}
+	var err error
+	if cf.since != "" {
+		if f.Since, err = time.Parse(time.RFC3339, cf.since); err != nil {
+			return f, fmt.Errorf("-since 파싱 실패: %w", err)
+		}
+	}
+	if cf.until != "" {
+		if f.Until, err = time.Parse(time.RFC3339, cf.until); err != nil {
+			return f, fmt.Errorf("-until 파싱 실패: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// topics는 -topic 값에 해당하는 토픽 목록을 반환합니다. required가 false이고 비어 있으면 전체 토픽입니다.
+func (cf commonFlags) topics(required bool) ([]eventbus.Topic, error) {
+	if cf.topic == "" {
+		if required {
+			return nil, errors.New("-topic 이 필요합니다")
+		}
+		return eventbus.AllTopics, nil
+	}
+	for _, t := range eventbus.AllTopics {
+		if t.Base() == cf.topic {
+			return []eventbus.Topic{t}, nil
+		}
+	}
+	return []eventbus.Topic{eventbus.NewTopic(cf.topic)}, nil
+}
+
+func runList(ctx context.Context, args []string) error {
+	var cf commonFlags
+	if err := newFlagSet("list", &cf).Parse(args); err != nil {
+		return err
+	}
+	filter, err := cf.filter()
+	if err != nil {
+		return err
+	}
+	topics, err := cf.topics(false)
+	if err != nil {
+		return err
+	}
+	ledger, err := eventbus.NewFileReplayLedger(cf.ledger)
+	if err != nil {
+		return err
+	}
+
+	brokers := eventbus.GetBrokers()
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "DLQ\tPARTITION\tOFFSET\tTIMESTAMP\tEVENT_ID\tRETRY\tREASON\tREPLAYED\tLAST_ERROR")
+	for _, t := range topics {
+		records, err := eventbus.ReadDLQ(ctx, brokers, t, filter)
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			reason, lastError := "", r.Event.LastError
+			if r.Event.DLQ != nil {
+				reason = r.Event.DLQ.Reason
+			}
+			if r.DecodeError != "" {
+				reason, lastError = "undecodable", r.DecodeError
+			}
+			replayed := ""
+			if entry, ok, _ := ledger.Lookup(r.Key()); ok {
+				replayed = entry.ReplayedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%d\t%s\t%s\t%s\n",
+				r.Topic, r.Partition, r.Offset, r.Timestamp.Format(time.RFC3339),
+				r.Event.ID, r.Event.Retry, reason, replayed, truncate(lastError, 80))
+		}
+	}
+	return w.Flush()
+}
+
+func runShow(ctx context.Context, args []string) error {
+	var cf commonFlags
+	if err := newFlagSet("show", &cf).Parse(args); err != nil {
+		return err
+	}
+	if cf.id == "" {
+		return errors.New("-id 가 필요합니다")
+	}
+	filter, err := cf.filter()
+	if err != nil {
+		return err
+	}
+	topics, err := cf.topics(false)
+	if err != nil {
+		return err
+	}
+
+	claimCheck, err := claimCheckFromEnv()
+	if err != nil {
+		return err
+	}
+
+	brokers := eventbus.GetBrokers()
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	found := false
+	for _, t := range topics {
+		records, err := eventbus.ReadDLQ(ctx, brokers, t, filter)
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			found = true
+			// 블롭으로 분리된 페이로드는 저장소에서 읽어 함께 보여 준다.
+			if claimCheck != nil && r.DecodeError == "" {
+				if resolved, err := claimCheck.Resolve(ctx, r.Event); err != nil {
+					fmt.Fprintf(os.Stderr, "%s 페이로드 블롭 읽기 실패: %v\n", r.Key(), err)
+				} else {
+					r.Event = resolved
+				}
+			}
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("이벤트 %s 를 DLQ 에서 찾지 못했습니다", cf.id)
+	}
+	return nil
+}
+
+func runReplay(ctx context.Context, args []string) error {
+	var cf commonFlags
+	fs := newFlagSet("replay", &cf)
+	dryRun := fs.Bool("dry-run", false, "발행하지 않고 대상만 출력")
+	force := fs.Bool("force", false, "이미 재처리된 레코드도 다시 재처리")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	filter, err := cf.filter()
+	if err != nil {
+		return err
+	}
+	topics, err := cf.topics(true)
+	if err != nil {
+		return err
+	}
+	topic := topics[0]
+	ledger, err := eventbus.NewFileReplayLedger(cf.ledger)
+	if err != nil {
+		return err
+	}
+
+	brokers := eventbus.GetBrokers()
+	records, err := eventbus.ReadDLQ(ctx, brokers, topic, filter)
+	if err != nil {
+		return err
+	}
+	if *dryRun {
+		for _, r := range records {
+			_, replayed, _ := ledger.Lookup(r.Key())
+			fmt.Printf("%s\t%s\treplayed=%v\n", r.Key(), r.Event.ID, replayed)
+		}
+		fmt.Printf("대상 %d건 (dry-run)\n", len(records))
+		return nil
+	}
+
+	claimCheck, err := claimCheckFromEnv()
+	if err != nil {
+		return err
+	}
+	bus, err := eventbus.NewKafkaEventBus(brokers)
+	if err != nil {
+		return err
+	}
+	defer bus.Close()
+	if claimCheck != nil {
+		// 재처리 이벤트가 사본 블롭을 만들 저장소를 원본 블롭을 지울 저장소와 맞춘다.
+		bus.ClaimCheck = claimCheck
+	}
+
+	var done []eventbus.DLQRecord
+	skipped := 0
+	for _, r := range records {
+		err := eventbus.ReplayDLQRecord(ctx, bus, topic, r, ledger, *force)
+		if errors.Is(err, eventbus.ErrAlreadyReplayed) || errors.Is(err, eventbus.ErrUndecodableDLQRecord) {
+			skipped++
+			fmt.Printf("건너뜀: %v\n", err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		done = append(done, r)
+		fmt.Printf("재처리: %s -> %s (event %s)\n", r.Key(), topic.Base(), r.Event.ID)
+	}
+	fmt.Printf("재처리 %d건, 건너뜀 %d건\n", len(done), skipped)
+	if claimCheck == nil || len(done) == 0 {
+		return nil
+	}
+
+	// 재처리한 이벤트는 사본 블롭으로 발행했으므로, 아직 재처리하지 않은 레코드가 참조하지 않는 원본 블롭을 지운다.
+	all, err := eventbus.ReadDLQ(ctx, brokers, topic, eventbus.DLQFilter{})
+	if err != nil {
+		return fmt.Errorf("원본 블롭 정리를 위한 DLQ 조회 실패: %w", err)
+	}
+	var pending []eventbus.DLQRecord
+	for _, r := range all {
+		if _, replayed, err := ledger.Lookup(r.Key()); err != nil {
+			return err
+		} else if !replayed {
+			pending = append(pending, r)
+		}
+	}
+	deleted, err := eventbus.DeleteDLQBlobs(ctx, claimCheck, done, pending)
+	fmt.Printf("원본 블롭 %d개 삭제\n", deleted)
+	return err
+}
+
+func runPurge(ctx context.Context, args []string) error {
+	var cf commonFlags
+	fs := newFlagSet("purge", &cf)
+	dryRun := fs.Bool("dry-run", false, "삭제하지 않고 파티션별 삭제 범위만 출력")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	topics, err := cf.topics(true)
+	if err != nil {
+		return err
+	}
+	topic := topics[0]
+	ledger, err := eventbus.NewFileReplayLedger(cf.ledger)
+	if err != nil {
+		return err
+	}
+
+	brokers := eventbus.GetBrokers()
+	// 삭제 범위는 DLQ 전체를 기준으로 계산해야 하므로 필터 없이 읽는다.
+	records, err := eventbus.ReadDLQ(ctx, brokers, topic, eventbus.DLQFilter{})
+	if err != nil {
+		return err
+	}
+	before, err := eventbus.ReplayedPrefix(records, ledger)
+	if err != nil {
+		return err
+	}
+	if len(before) == 0 {
+		fmt.Println("삭제할 재처리 완료 레코드가 없습니다.")
+		return nil
+	}
+	for partition, offset := range before {
+		fmt.Printf("%s[%d]: offset %d 이전 삭제\n", topic.DLQ(), partition, offset)
+	}
+	if *dryRun {
+		return nil
+	}
+	claimCheck, err := claimCheckFromEnv()
+	if err != nil {
+		return err
+	}
+	if err := eventbus.PurgeDLQ(ctx, brokers, topic, before); err != nil {
+		return err
+	}
+	if claimCheck == nil {
+		return nil
+	}
+
+	// 잘라낸 레코드의 블롭은 더 읽을 일이 없으므로, 남은 레코드가 참조하지 않으면 지운다.
+	var purged, kept []eventbus.DLQRecord
+	for _, r := range records {
+		if r.Offset < before[r.Partition] {
+			purged = append(purged, r)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+	deleted, err := eventbus.DeleteDLQBlobs(ctx, claimCheck, purged, kept)
+	fmt.Printf("블롭 %d개 삭제\n", deleted)
+	return err
+}
+
+// claimCheckFromEnv 는 EVENTBUS_BLOB_STORE 환경변수로 DLQ 레코드의 블롭 참조를 풀 클레임 체크를 만든다.
+// 비어 있으면 nil 을 반환한다.
+func claimCheckFromEnv() (*eventbus.ClaimCheck, error) {
+	raw := strings.TrimSpace(os.Getenv("EVENTBUS_BLOB_STORE"))
+	if raw == "" {
+		return nil, nil
+	}
+	store, err := eventbus.NewBlobStoreFromURL(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &eventbus.ClaimCheck{Store: store}, nil
+}
+
+func truncate(s string, n int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
@@ -3,62 +3,238 @@ package eventbus
 import (
 	"context"
 	"fmt"
-	"time"
+	"sort"
+	"strconv"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 )
 
-// EnsureTopics는 기본 토픽, 모든 지연 토픽, DLQ 토픽을 생성합니다.
-// 이미 존재하는 토픽에 대해서는 성공으로 간주합니다.
-func EnsureTopics(brokers string, topic Topic, basePartitions int) error {
+// TopicChangeKind는 EnsureTopics가 찾은 카탈로그와 클러스터의 차이 종류입니다.
+type TopicChangeKind string
+
+const (
+	TopicChangeCreate        TopicChangeKind = "create"
+	TopicChangeAddPartitions TopicChangeKind = "add-partitions"
+	TopicChangeAlterConfig   TopicChangeKind = "alter-config"
+	// TopicChangeManual은 자동으로 맞출 수 없는 차이(파티션 축소, 복제 계수 변경)입니다. 적용하지 않고 보고만 합니다.
+	TopicChangeManual TopicChangeKind = "manual"
+)
+
+// TopicChange는 토픽 하나의 변경 사항입니다. alter-config는 설정 키마다 하나씩 만들어집니다.
+type TopicChange struct {
+	Topic string
+	Kind  TopicChangeKind
+	// Key는 alter-config/manual 대상 항목 이름입니다 (예: retention.ms, partitions).
+	Key  string
+	From string
+	To   string
+}
+
+func (c TopicChange) String() string {
+	switch c.Kind {
+	case TopicChangeCreate:
+		return fmt.Sprintf("+ %s (%s)", c.Topic, c.To)
+	case TopicChangeManual:
+		return fmt.Sprintf("! %s %s: %s -> %s (수동 조치 필요)", c.Topic, c.Key, c.From, c.To)
+	default:
+		return fmt.Sprintf("~ %s %s: %s -> %s", c.Topic, c.Key, c.From, c.To)
+	}
+}
+
+// topicState는 클러스터에 있는 토픽의 현재 상태입니다.
+type topicState struct {
+	Partitions        int
+	ReplicationFactor int
+	Configs           map[string]string
+}
+
+// EnsureTopics는 카탈로그의 모든 기본/재시도/DLQ 토픽을 클러스터와 맞춥니다.
+// 없는 토픽은 만들고, 파티션이 모자라면 늘리고, 다른 설정 값은 바꿉니다. 카탈로그에 없는 설정은 건드리지 않습니다.
+// 파티션 축소와 복제 계수 변경은 자동으로 하지 않고 TopicChangeManual로만 보고합니다.
+//
+// dryRun이면 아무것도 바꾸지 않고 차이만 반환합니다.
+func EnsureTopics(ctx context.Context, brokers string, catalog *TopicCatalog, dryRun bool) ([]TopicChange, error) {
 	admin, err := kafka.NewAdminClient(&kafka.ConfigMap{
 		"bootstrap.servers": brokers,
 	})
 	if err != nil {
-		return fmt.Errorf("AdminClient 생성 실패: %w", err)
+		return nil, fmt.Errorf("AdminClient 생성 실패: %w", err)
 	}
 	defer admin.Close()
 
-	// 생성할 토픽 사양 구성
-	specs := make([]kafka.TopicSpecification, 0, 2+len(RetryDelays))
+	defs := catalog.Definitions()
+	current, err := describeTopics(ctx, admin, defs)
+	if err != nil {
+		return nil, err
+	}
+	changes := planTopicChanges(defs, current)
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+	if err := applyTopicChanges(ctx, admin, defs, changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
 
-	// 기본 토픽
-	specs = append(specs, kafka.TopicSpecification{
-		Topic:             topic.Base(),
-		NumPartitions:     basePartitions,
-		ReplicationFactor: 1,
-	})
+// describeTopics는 defs 중 클러스터에 있는 토픽의 파티션 수, 복제 계수, 설정 값을 조회합니다.
+func describeTopics(ctx context.Context, admin *kafka.AdminClient, defs []TopicDefinition) (map[string]topicState, error) {
+	md, err := admin.GetMetadata(nil, true, 10000)
+	if err != nil {
+		return nil, fmt.Errorf("토픽 메타데이터 조회 실패: %w", err)
+	}
 
-	// DLQ 토픽 (1 파티션 권장)
-	specs = append(specs, kafka.TopicSpecification{
-		Topic:             topic.DLQ(),
-		NumPartitions:     1,
-		ReplicationFactor: 1,
-	})
+	current := make(map[string]topicState, len(defs))
+	var resources []kafka.ConfigResource
+	for _, def := range defs {
+		tm, ok := md.Topics[def.Name]
+		if !ok || tm.Error.Code() == kafka.ErrUnknownTopicOrPart || len(tm.Partitions) == 0 {
+			continue
+		}
+		current[def.Name] = topicState{
+			Partitions:        len(tm.Partitions),
+			ReplicationFactor: len(tm.Partitions[0].Replicas),
+		}
+		if len(def.Configs) > 0 {
+			resources = append(resources, kafka.ConfigResource{Type: kafka.ResourceTopic, Name: def.Name})
+		}
+	}
+	if len(resources) == 0 {
+		return current, nil
+	}
 
-	// 재시도 토픽들 (기본 토픽과 동일한 파티션 수 권장)
-	for _, retryTopic := range topic.GetRetryTopics() {
-		specs = append(specs, kafka.TopicSpecification{
-			Topic:             retryTopic,
-			NumPartitions:     basePartitions,
-			ReplicationFactor: 1,
-		})
+	results, err := admin.DescribeConfigs(ctx, resources)
+	if err != nil {
+		return nil, fmt.Errorf("토픽 설정 조회 실패: %w", err)
 	}
+	for _, r := range results {
+		if r.Error.Code() != kafka.ErrNoError {
+			return nil, fmt.Errorf("토픽 %s 설정 조회 실패: %v", r.Name, r.Error)
+		}
+		state := current[r.Name]
+		state.Configs = make(map[string]string, len(r.Config))
+		for name, entry := range r.Config {
+			state.Configs[name] = entry.Value
+		}
+		current[r.Name] = state
+	}
+	return current, nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// planTopicChanges는 목표 상태와 현재 상태를 비교해 변경 사항을 defs 순서대로 반환합니다.
+func planTopicChanges(defs []TopicDefinition, current map[string]topicState) []TopicChange {
+	var changes []TopicChange
+	for _, def := range defs {
+		state, ok := current[def.Name]
+		if !ok {
+			changes = append(changes, TopicChange{
+				Topic: def.Name,
+				Kind:  TopicChangeCreate,
+				To:    fmt.Sprintf("partitions=%d, replication_factor=%d", def.Partitions, def.ReplicationFactor),
+			})
+			continue
+		}
 
-	results, err := admin.CreateTopics(ctx, specs)
-	if err != nil {
-		return fmt.Errorf("토픽 생성 요청 실패: %w", err)
+		switch {
+		case state.Partitions < def.Partitions:
+			changes = append(changes, TopicChange{Topic: def.Name, Kind: TopicChangeAddPartitions, Key: "partitions",
+				From: strconv.Itoa(state.Partitions), To: strconv.Itoa(def.Partitions)})
+		case state.Partitions > def.Partitions:
+			changes = append(changes, TopicChange{Topic: def.Name, Kind: TopicChangeManual, Key: "partitions",
+				From: strconv.Itoa(state.Partitions), To: strconv.Itoa(def.Partitions)})
+		}
+		if state.ReplicationFactor != def.ReplicationFactor {
+			changes = append(changes, TopicChange{Topic: def.Name, Kind: TopicChangeManual, Key: "replication_factor",
+				From: strconv.Itoa(state.ReplicationFactor), To: strconv.Itoa(def.ReplicationFactor)})
+		}
+
+		keys := make([]string, 0, len(def.Configs))
+		for k := range def.Configs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if from, want := state.Configs[k], def.Configs[k]; from != want {
+				changes = append(changes, TopicChange{Topic: def.Name, Kind: TopicChangeAlterConfig, Key: k, From: from, To: want})
+			}
+		}
 	}
+	return changes
+}
 
-	for _, r := range results {
-		code := r.Error.Code()
-		if code != kafka.ErrNoError && code != kafka.ErrTopicAlreadyExists {
-			return fmt.Errorf("토픽 %s 생성 실패: %v", r.Topic, r.Error)
+// applyTopicChanges는 토픽 생성, 파티션 추가, 설정 변경 순서로 changes를 적용합니다.
+func applyTopicChanges(ctx context.Context, admin *kafka.AdminClient, defs []TopicDefinition, changes []TopicChange) error {
+	byName := make(map[string]TopicDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.Name] = def
+	}
+
+	var (
+		creates    []kafka.TopicSpecification
+		partitions []kafka.PartitionsSpecification
+		alters     []kafka.ConfigResource
+		alterIndex = map[string]int{}
+	)
+	for _, c := range changes {
+		def := byName[c.Topic]
+		switch c.Kind {
+		case TopicChangeCreate:
+			creates = append(creates, kafka.TopicSpecification{
+				Topic:             def.Name,
+				NumPartitions:     def.Partitions,
+				ReplicationFactor: def.ReplicationFactor,
+				Config:            def.Configs,
+			})
+		case TopicChangeAddPartitions:
+			partitions = append(partitions, kafka.PartitionsSpecification{Topic: def.Name, IncreaseTo: def.Partitions})
+		case TopicChangeAlterConfig:
+			i, ok := alterIndex[def.Name]
+			if !ok {
+				i = len(alters)
+				alterIndex[def.Name] = i
+				alters = append(alters, kafka.ConfigResource{Type: kafka.ResourceTopic, Name: def.Name})
+			}
+			alters[i].Config = append(alters[i].Config, kafka.ConfigEntry{
+				Name:                 c.Key,
+				Value:                c.To,
+				IncrementalOperation: kafka.AlterConfigOpTypeSet,
+			})
 		}
 	}
 
+	if len(creates) > 0 {
+		results, err := admin.CreateTopics(ctx, creates)
+		if err != nil {
+			return fmt.Errorf("토픽 생성 요청 실패: %w", err)
+		}
+		for _, r := range results {
+			// 여러 retryworker가 동시에 떠서 다른 쪽이 먼저 만든 경우는 성공으로 간주합니다.
+			if code := r.Error.Code(); code != kafka.ErrNoError && code != kafka.ErrTopicAlreadyExists {
+				return fmt.Errorf("토픽 %s 생성 실패: %v", r.Topic, r.Error)
+			}
+		}
+	}
+	if len(partitions) > 0 {
+		results, err := admin.CreatePartitions(ctx, partitions)
+		if err != nil {
+			return fmt.Errorf("파티션 추가 요청 실패: %w", err)
+		}
+		for _, r := range results {
+			if r.Error.Code() != kafka.ErrNoError {
+				return fmt.Errorf("토픽 %s 파티션 추가 실패: %v", r.Topic, r.Error)
+			}
+		}
+	}
+	if len(alters) > 0 {
+		results, err := admin.IncrementalAlterConfigs(ctx, alters)
+		if err != nil {
+			return fmt.Errorf("토픽 설정 변경 요청 실패: %w", err)
+		}
+		for _, r := range results {
+			if r.Error.Code() != kafka.ErrNoError {
+				return fmt.Errorf("토픽 %s 설정 변경 실패: %v", r.Name, r.Error)
+			}
+		}
+	}
 	return nil
 }
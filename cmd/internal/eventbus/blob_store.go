@@ -0,0 +1,258 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// ErrBlobNotFound는 요청한 키의 블롭이 저장소에 없을 때 반환되는 오류입니다.
+var ErrBlobNotFound = errors.New("블롭을 찾을 수 없음")
+
+// BlobStore는 클레임 체크(claim-check)로 분리한 페이로드를 보관하는 저장소입니다.
+// key는 "/"로 구분된 상대 경로 형식입니다(예: tech-letter.post.summary/<event id>-<hash>.json).
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get은 블롭이 없으면 ErrBlobNotFound를 반환합니다.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete는 블롭이 이미 없어도 오류로 보지 않습니다.
+	Delete(ctx context.Context, key string) error
+}
+
+// validateBlobKey는 저장소 밖을 가리키거나 비어 있는 키를 거부합니다.
+func validateBlobKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("잘못된 블롭 키: %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("잘못된 블롭 키: %q", key)
+		}
+	}
+	return nil
+}
+
+// FileBlobStore는 로컬 파일 시스템 디렉터리에 블롭을 저장합니다.
+// 여러 프로세스가 함께 쓰려면 같은 볼륨을 공유해야 합니다.
+type FileBlobStore struct {
+	dir string
+}
+
+// NewFileBlobStore는 dir을 루트로 하는 FileBlobStore를 생성합니다. 디렉터리가 없으면 만듭니다.
+func NewFileBlobStore(dir string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("블롭 디렉터리 생성 실패: %w", err)
+	}
+	return &FileBlobStore{dir: dir}, nil
+}
+
+func (s *FileBlobStore) path(key string) (string, error) {
+	if err := validateBlobKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put은 임시 파일에 쓴 뒤 이름을 바꿔, 읽는 쪽이 쓰다 만 블롭을 보지 않게 합니다.
+func (s *FileBlobStore) Put(_ context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("블롭 디렉터리 생성 실패: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		return fmt.Errorf("블롭 임시 파일 생성 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("블롭 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("블롭 쓰기 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("블롭 저장 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *FileBlobStore) Get(_ context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("블롭 읽기 실패: %w", err)
+	}
+	return data, nil
+}
+
+func (s *FileBlobStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("블롭 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// S3Config는 S3 호환 저장소(AWS S3, MinIO 등) 접속 정보입니다.
+type S3Config struct {
+	// Endpoint는 저장소 주소입니다 (예: http://minio:9000, https://s3.ap-northeast-2.amazonaws.com).
+	Endpoint string
+	Bucket   string
+	// Prefix는 모든 키 앞에 붙는 경로입니다. 비어 있으면 버킷 루트를 사용합니다.
+	Prefix    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3BlobStore는 S3 호환 저장소에 블롭을 저장합니다.
+// MinIO와 호환되도록 경로 방식(path-style) URL을 사용하며, 요청은 SigV4로 서명합니다.
+type S3BlobStore struct {
+	cfg    S3Config
+	base   *url.URL
+	signer *v4.Signer
+	client *http.Client
+}
+
+// NewS3BlobStore는 S3BlobStore를 생성합니다. Region이 비어 있으면 us-east-1을 사용합니다.
+func NewS3BlobStore(cfg S3Config) (*S3BlobStore, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 엔드포인트와 버킷은 필수입니다")
+	}
+	base, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("잘못된 S3 엔드포인트: %q", cfg.Endpoint)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+	return &S3BlobStore{
+		cfg:  cfg,
+		base: base,
+		signer: v4.NewSigner(func(o *v4.SignerOptions) {
+			// S3는 경로를 한 번만 인코딩해 서명한다.
+			o.DisableURIPathEscaping = true
+		}),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (s *S3BlobStore) objectURL(key string) (*url.URL, error) {
+	if err := validateBlobKey(key); err != nil {
+		return nil, err
+	}
+	if s.cfg.Prefix != "" {
+		key = s.cfg.Prefix + "/" + key
+	}
+	segments := append([]string{s.cfg.Bucket}, strings.Split(key, "/")...)
+	escaped := make([]string, len(segments))
+	for i, seg := range segments {
+		escaped[i] = url.PathEscape(seg)
+	}
+	u := *s.base
+	u.Path = s.base.Path + "/" + strings.Join(segments, "/")
+	u.RawPath = s.base.Path + "/" + strings.Join(escaped, "/")
+	return &u, nil
+}
+
+func (s *S3BlobStore) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if method == http.MethodPut {
+		req.Header.Set("Content-Type", contentTypeJSON)
+	}
+	creds := aws.Credentials{AccessKeyID: s.cfg.AccessKey, SecretAccessKey: s.cfg.SecretKey}
+	if err := s.signer.SignHTTP(ctx, creds, req, payloadHash, "s3", s.cfg.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("S3 요청 서명 실패: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 %s %s 요청 실패: %w", method, key, err)
+	}
+	return resp, nil
+}
+
+// s3Error는 실패 응답 본문 일부를 오류 메시지에 담습니다.
+func s3Error(method, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("S3 %s %s 실패 (%s): %s", method, key, resp.Status, strings.TrimSpace(string(body)))
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return s3Error(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, key)
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, s3Error(http.MethodGet, key, resp)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("S3 GET %s 본문 읽기 실패: %w", key, err)
+	}
+	return data, nil
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return s3Error(http.MethodDelete, key, resp)
+	}
+	return nil
+}
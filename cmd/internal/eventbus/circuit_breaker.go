@@ -0,0 +1,343 @@
+package eventbus
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// defaultCircuitCoolDown은 차단기가 열린 뒤 시험 처리(half-open)를 하기까지의 기본 대기 시간입니다.
+const defaultCircuitCoolDown = 30 * time.Second
+
+// CircuitState는 구독별 차단기 상태입니다.
+type CircuitState string
+
+const (
+	// CircuitClosed는 정상 처리 중인 상태입니다.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen은 연속 실패로 파티션 소비를 멈춘 상태입니다. 실패한 메시지는 재시도 토픽으로 보내지 않고 보류합니다.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen은 대기 시간이 지나 메시지 하나로 복구 여부를 시험하는 상태입니다.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// metricValue는 circuit_breaker_state 지표 값(closed=0, half-open=1, open=2)입니다.
+func (s CircuitState) metricValue() float64 {
+	switch s {
+	case CircuitHalfOpen:
+		return 1
+	case CircuitOpen:
+		return 2
+	}
+	return 0
+}
+
+// CircuitBreakerConfig는 구독별 차단기 설정입니다.
+type CircuitBreakerConfig struct {
+	// FailureThreshold는 차단기를 여는 연속 실패 횟수입니다. 0 이하이면 차단기를 쓰지 않습니다.
+	FailureThreshold int
+	// CoolDown은 차단기가 열린 뒤 시험 처리까지 기다리는 시간입니다. 0 이하이면 defaultCircuitCoolDown을 사용합니다.
+	CoolDown time.Duration
+	// IsFailure는 연속 실패로 셀 오류인지 판단합니다. nil이면 영구 오류(Permanent)를 뺀 모든 오류를 셉니다.
+	// 영구 오류는 메시지 자체의 문제이므로 하위 시스템 장애로 보지 않고 평소처럼 DLQ로 보냅니다.
+	IsFailure func(error) bool
+}
+
+// WithCircuitBreaker는 구독에 차단기를 붙입니다. 핸들러가 FailureThreshold번 연속 실패하면
+// 재시도 토픽으로 보내는 대신 할당된 파티션 소비를 멈추고, CoolDown 뒤 메시지 하나로 복구를 시험합니다.
+// 시험이 성공하면 소비를 재개하고, 실패하면 다시 CoolDown 동안 멈춥니다.
+//
+// 차단기가 열린 뒤 실패한 메시지는 커밋하지 않고 보류했다가 재개할 때 그 오프셋부터 다시 읽으므로,
+// 같은 파티션에서 보류한 메시지 뒤에 이미 처리한 메시지가 한 번 더 처리될 수 있습니다.
+// 옵션을 주지 않으면 KAFKA_CIRCUIT_BREAKER_THRESHOLD, KAFKA_CIRCUIT_BREAKER_COOLDOWN_MS 환경변수를 사용합니다.
+// MemoryEventBus에서는 무시됩니다.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.breaker = &cfg
+	}
+}
+
+// circuitBreaker는 연속 실패 횟수로 상태를 바꾸는 차단기입니다. 워커 고루틴들이 Record를 동시에 호출합니다.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	cfg      CircuitBreakerConfig
+	clock    Clock
+	metrics  *Metrics
+	groupID  string
+	topic    string
+	state    CircuitState
+	failures int
+	since    time.Time
+}
+
+// newCircuitBreaker는 cfg로 차단기를 만듭니다. cfg가 nil이거나 FailureThreshold가 0 이하이면 nil을 반환하며,
+// nil 차단기는 항상 닫힌 상태로 동작합니다.
+func newCircuitBreaker(cfg *CircuitBreakerConfig, clock Clock, metrics *Metrics, groupID, topic string) *circuitBreaker {
+	if cfg == nil || cfg.FailureThreshold <= 0 {
+		return nil
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+	c := *cfg
+	if c.CoolDown <= 0 {
+		c.CoolDown = defaultCircuitCoolDown
+	}
+	if c.IsFailure == nil {
+		c.IsFailure = func(err error) bool { return !IsPermanent(err) }
+	}
+	b := &circuitBreaker{cfg: c, clock: clock, metrics: metrics, groupID: groupID, topic: topic, state: CircuitClosed, since: clock.Now()}
+	metrics.observeCircuitState(topic, groupID, CircuitClosed)
+	return b
+}
+
+// Record는 핸들러 결과를 반영합니다. 실패한 메시지를 재시도 토픽으로 보내지 말고 보류해야 하면 true를 반환합니다.
+func (b *circuitBreaker) Record(err error) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		switch b.state {
+		case CircuitHalfOpen:
+			b.transition(CircuitClosed, "시험 처리 성공")
+		case CircuitClosed:
+			b.failures = 0
+		}
+		return false
+	}
+	if !b.cfg.IsFailure(err) {
+		// 영구 오류처럼 실패로 세지 않는 결과도 시험 처리로는 끝난 것이므로 차단기를 닫는다.
+		if b.state == CircuitHalfOpen {
+			b.transition(CircuitClosed, "시험 처리 완료(실패로 세지 않는 오류): "+err.Error())
+		}
+		return false
+	}
+
+	b.failures++
+	switch b.state {
+	case CircuitClosed:
+		if b.failures < b.cfg.FailureThreshold {
+			return false
+		}
+		b.transition(CircuitOpen, "연속 실패 "+strconv.Itoa(b.failures)+"회: "+err.Error())
+	case CircuitHalfOpen:
+		b.transition(CircuitOpen, "시험 처리 실패: "+err.Error())
+	}
+	return true
+}
+
+// Skipped는 핸들러를 부르지 않고 커밋한 메시지(디코드 실패 등)를 반영합니다.
+// 연속 실패 횟수는 바꾸지 않지만, half-open 시험 메시지였다면 더 시험할 수 없으므로 차단기를 닫습니다.
+func (b *circuitBreaker) Skipped() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitHalfOpen {
+		b.transition(CircuitClosed, "시험 메시지를 처리하지 않고 커밋")
+	}
+}
+
+// State는 현재 상태를 반환합니다.
+func (b *circuitBreaker) State() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// TryHalfOpen은 열린 지 CoolDown이 지났으면 half-open으로 바꾸고 true를 반환합니다.
+func (b *circuitBreaker) TryHalfOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != CircuitOpen || b.clock.Now().Before(b.since.Add(b.cfg.CoolDown)) {
+		return false
+	}
+	b.transition(CircuitHalfOpen, "대기 시간 경과")
+	return true
+}
+
+// transition은 상태를 바꾸고 로그와 지표를 남깁니다. 호출자는 b.mu를 잡고 있어야 합니다.
+func (b *circuitBreaker) transition(to CircuitState, reason string) {
+	from := b.state
+	b.state = to
+	b.since = b.clock.Now()
+	if to == CircuitClosed {
+		b.failures = 0
+	}
+	fields := logger.Fields{"topic": b.topic, "group": b.groupID, "from": string(from), "to": string(to), "reason": reason}
+	if to == CircuitOpen {
+		fields["cool_down"] = b.cfg.CoolDown.String()
+		logger.ErrorWithFields("차단기 열림. 파티션 소비를 멈춥니다.", fields)
+	} else {
+		logger.InfoWithFields("차단기 상태 변경", fields)
+	}
+	b.metrics.observeCircuitState(b.topic, b.groupID, to)
+}
+
+// circuitGate는 Subscribe 메인 루프에서 차단기 상태에 맞춰 할당된 파티션을 멈추고 재개합니다.
+// 메인 루프 고루틴(리밸런스 콜백 포함)에서만 다룹니다.
+type circuitGate struct {
+	breaker *circuitBreaker
+	paused  bool
+	// probing은 half-open에서 시험 메시지를 이미 보냈는지 여부입니다.
+	probing bool
+	// inFlight는 워커에 보냈지만 결과를 아직 받지 않은 메시지 수입니다.
+	inFlight int
+	// held는 파티션별로 보류한 가장 앞선 오프셋입니다. 재개할 때 이 위치로 Seek 합니다.
+	held map[partitionKey]int64
+}
+
+func newCircuitGate(b *circuitBreaker) *circuitGate {
+	return &circuitGate{breaker: b, held: make(map[partitionKey]int64)}
+}
+
+// Accepting은 읽은 메시지를 워커에 보내도 되면 true를 반환합니다. false이면 Hold로 보류해야 합니다.
+func (g *circuitGate) Accepting() bool {
+	return !g.paused
+}
+
+// Dispatched는 메시지를 워커에 보냈음을 기록합니다.
+func (g *circuitGate) Dispatched() {
+	g.inFlight++
+	if g.breaker.State() == CircuitHalfOpen {
+		g.probing = true
+	}
+}
+
+// Finished는 워커의 처리 결과를 받았음을 기록합니다.
+// 시험 메시지가 차단기 상태를 바꾸지 못하고 끝났으면(보류, 종료 중 건너뜀 등) 다음 메시지로 다시 시험하도록 probing을 풉니다.
+func (g *circuitGate) Finished() {
+	g.inFlight--
+	if g.probing && g.inFlight == 0 && g.breaker.State() == CircuitHalfOpen {
+		g.probing = false
+	}
+}
+
+// Hold는 msg를 커밋하지 않고 보류해, 재개할 때 다시 읽도록 합니다.
+// 보류한 메시지는 결과가 오지 않으므로 tracker에서 건너뛰어, 파티션 회수가 이 메시지를 기다리지 않게 합니다.
+func (g *circuitGate) Hold(msg *kafka.Message, tracker *offsetTracker) {
+	key := messagePartitionKey(msg)
+	offset := int64(msg.TopicPartition.Offset)
+	tracker.Skip(key.topic, key.partition, offset)
+	if cur, ok := g.held[key]; !ok || offset < cur {
+		g.held[key] = offset
+	}
+}
+
+// Assigned는 멈춘 상태에서 새로 할당받은 파티션도 멈춰 둡니다.
+func (g *circuitGate) Assigned(c *kafka.Consumer, partitions []kafka.TopicPartition) error {
+	if !g.paused {
+		return nil
+	}
+	if err := c.Assign(partitions); err != nil {
+		return err
+	}
+	if err := c.Pause(partitions); err != nil {
+		logger.Log.Errorf("파티션 %v 일시 정지 실패: %v", partitions, err)
+	}
+	return nil
+}
+
+// Revoked는 회수된 파티션의 보류 기록을 버립니다. 커밋하지 않았으므로 새 소유자가 다시 읽습니다.
+func (g *circuitGate) Revoked(partitions []kafka.TopicPartition) {
+	for _, tp := range partitions {
+		delete(g.held, partitionKey{topic: *tp.Topic, partition: tp.Partition})
+	}
+}
+
+// Sync는 차단기 상태에 맞춰 파티션을 멈추거나 재개합니다. 메인 루프가 매 반복마다 호출합니다.
+func (g *circuitGate) Sync(c *kafka.Consumer, tracker *offsetTracker) {
+	switch g.breaker.State() {
+	case CircuitClosed:
+		if g.paused {
+			g.resume(c, tracker)
+		}
+	case CircuitOpen:
+		if !g.paused {
+			g.pause(c)
+		}
+		// 처리 중인 메시지가 모두 끝난 뒤에만 시험을 시작해, 보류 위치로 되감을 때 추적 상태가 섞이지 않게 한다.
+		if g.inFlight == 0 && g.breaker.TryHalfOpen() {
+			g.probing = false
+			g.resume(c, tracker)
+		}
+	case CircuitHalfOpen:
+		if g.probing && !g.paused {
+			g.pause(c)
+		} else if !g.probing && g.paused {
+			g.resume(c, tracker)
+		}
+	}
+}
+
+func (g *circuitGate) pause(c *kafka.Consumer) {
+	assigned, err := c.Assignment()
+	if err != nil {
+		logger.Log.Errorf("컨슈머 할당 조회 실패: %v", err)
+	}
+	if err := c.Pause(assigned); err != nil {
+		logger.Log.Errorf("파티션 %v 일시 정지 실패: %v", assigned, err)
+	}
+	g.paused = true
+}
+
+// resume은 보류한 메시지 위치로 되감은 뒤 모든 파티션을 재개합니다. 처리 중인 메시지가 있으면 다음 반복으로 미룹니다.
+func (g *circuitGate) resume(c *kafka.Consumer, tracker *offsetTracker) {
+	if len(g.held) > 0 && g.inFlight > 0 {
+		return
+	}
+	for key, offset := range g.held {
+		topic := key.topic
+		if err := c.Seek(kafka.TopicPartition{Topic: &topic, Partition: key.partition, Offset: kafka.Offset(offset)}, 0); err != nil {
+			logger.Log.Errorf("보류 메시지 위치 %s[%d]@%d seek 오류: %v", key.topic, key.partition, offset, err)
+		}
+		tracker.Reset(key.topic, key.partition)
+	}
+	clear(g.held)
+
+	assigned, err := c.Assignment()
+	if err != nil {
+		logger.Log.Errorf("컨슈머 할당 조회 실패: %v", err)
+	}
+	if err := c.Resume(assigned); err != nil {
+		logger.Log.Errorf("파티션 %v 재개 실패: %v", assigned, err)
+	}
+	g.paused = false
+}
+
+// getCircuitBreakerFromEnv 는 KAFKA_CIRCUIT_BREAKER_THRESHOLD, KAFKA_CIRCUIT_BREAKER_COOLDOWN_MS 환경변수에서
+// 차단기 설정을 읽어온다. 임계값이 없거나 1 미만이면 nil 을 반환해 차단기를 쓰지 않는다.
+func getCircuitBreakerFromEnv() *CircuitBreakerConfig {
+	raw := strings.TrimSpace(os.Getenv("KAFKA_CIRCUIT_BREAKER_THRESHOLD"))
+	if raw == "" {
+		return nil
+	}
+	threshold, err := strconv.Atoi(raw)
+	if err != nil || threshold < 1 {
+		logger.Log.Warnf("KAFKA_CIRCUIT_BREAKER_THRESHOLD 환경변수 파싱 실패: %q. 차단기를 사용하지 않습니다.", raw)
+		return nil
+	}
+	cfg := &CircuitBreakerConfig{FailureThreshold: threshold}
+	if raw := strings.TrimSpace(os.Getenv("KAFKA_CIRCUIT_BREAKER_COOLDOWN_MS")); raw != "" {
+		ms, err := strconv.Atoi(raw)
+		if err != nil || ms < 1 {
+			logger.Log.Warnf("KAFKA_CIRCUIT_BREAKER_COOLDOWN_MS 환경변수 파싱 실패: %q. 기본값 사용.", raw)
+		} else {
+			cfg.CoolDown = time.Duration(ms) * time.Millisecond
+		}
+	}
+	return cfg
+}
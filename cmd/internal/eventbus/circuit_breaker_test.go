@@ -0,0 +1,152 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	b := newCircuitBreaker(&CircuitBreakerConfig{FailureThreshold: 3, CoolDown: time.Minute}, clock, NewMetrics(), "group", "topic")
+	down := errors.New("llm provider unavailable")
+
+	if b.Record(down) || b.Record(down) {
+		t.Fatalf("failures below threshold should be routed to retry")
+	}
+	b.Record(nil)
+	b.Record(down)
+	b.Record(down)
+	if b.Record(Permanent(down)) || b.State() != CircuitClosed {
+		t.Fatalf("permanent errors should not count as consecutive failures")
+	}
+	if !b.Record(down) || b.State() != CircuitOpen {
+		t.Fatalf("expected breaker to open and hold the failing message, got %s", b.State())
+	}
+	if !b.Record(down) {
+		t.Fatalf("failures while open should be held")
+	}
+
+	if b.TryHalfOpen() {
+		t.Fatalf("breaker should stay open during cool-down")
+	}
+	clock.Advance(time.Minute)
+	if !b.TryHalfOpen() || b.State() != CircuitHalfOpen {
+		t.Fatalf("expected half-open after cool-down, got %s", b.State())
+	}
+	if !b.Record(down) || b.State() != CircuitOpen {
+		t.Fatalf("failed probe should reopen the breaker, got %s", b.State())
+	}
+
+	clock.Advance(time.Minute)
+	b.TryHalfOpen()
+	if b.Record(nil) || b.State() != CircuitClosed {
+		t.Fatalf("successful probe should close the breaker, got %s", b.State())
+	}
+	if b.Record(down) {
+		t.Fatalf("failure count should restart after closing")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeWithoutCountedFailure(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	down := errors.New("llm provider unavailable")
+	halfOpen := func() *circuitBreaker {
+		b := newCircuitBreaker(&CircuitBreakerConfig{FailureThreshold: 1, CoolDown: time.Minute}, clock, NewMetrics(), "group", "topic")
+		b.Record(down)
+		clock.Advance(time.Minute)
+		if !b.TryHalfOpen() {
+			t.Fatalf("expected half-open after cool-down, got %s", b.State())
+		}
+		return b
+	}
+
+	b := halfOpen()
+	if b.Record(Permanent(down)) || b.State() != CircuitClosed {
+		t.Fatalf("probe ending in a permanent error should close the breaker, got %s", b.State())
+	}
+
+	b = halfOpen()
+	topic := "test.circuit.decode"
+	msg := &kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0, Offset: 3}, Value: []byte("not an envelope")}
+	called := false
+	handler := func(context.Context, Event) error {
+		called = true
+		return nil
+	}
+	r := (&KafkaEventBus{Metrics: NewMetrics(), Clock: clock}).processMessage(context.Background(), "group", NewTopic(topic), handler, b, msg)
+	if called || !r.commit {
+		t.Fatalf("undecodable probe should be committed without calling the handler, got %+v", r)
+	}
+	if b.State() != CircuitClosed {
+		t.Fatalf("undecodable probe should close the breaker, got %s", b.State())
+	}
+}
+
+func TestCircuitGateRetriesProbeThatEndedWithoutVerdict(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	b := newCircuitBreaker(&CircuitBreakerConfig{FailureThreshold: 1, CoolDown: time.Minute}, clock, NewMetrics(), "group", "topic")
+	b.Record(errors.New("down"))
+	clock.Advance(time.Minute)
+	b.TryHalfOpen()
+
+	gate := newCircuitGate(b)
+	gate.Dispatched()
+	if !gate.probing {
+		t.Fatalf("message dispatched while half-open should be the probe")
+	}
+	// 시험 메시지가 종료 중 건너뛰어졌거나 보류되어 차단기 상태가 그대로인 경우
+	gate.Finished()
+	if gate.probing || b.State() != CircuitHalfOpen {
+		t.Fatalf("gate should send another probe, probing=%v state=%s", gate.probing, b.State())
+	}
+}
+
+func TestCircuitGateHoldStopsWaitingForHeldOffsets(t *testing.T) {
+	topic := "test.circuit.hold"
+	tracker := newOffsetTracker()
+	for offset := int64(5); offset <= 7; offset++ {
+		tracker.Track(topic, 0, offset)
+	}
+	gate := newCircuitGate(nil)
+
+	gate.Hold(&kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0, Offset: 6}}, tracker)
+	if got := tracker.Pending(topic, 0); got != 2 {
+		t.Fatalf("held offset should not be pending, got %d", got)
+	}
+	if next, advanced := tracker.Done(topic, 0, 5); !advanced || next != 6 {
+		t.Fatalf("expected commit up to the held offset, got %d, %v", next, advanced)
+	}
+	if _, advanced := tracker.Done(topic, 0, 7); advanced {
+		t.Fatalf("commit should not pass the held offset")
+	}
+	if got := tracker.Pending(topic, 0); got != 0 {
+		t.Fatalf("revoke should not wait for held messages, got %d pending", got)
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	var b *circuitBreaker = newCircuitBreaker(&CircuitBreakerConfig{}, nil, nil, "group", "topic")
+	if b != nil {
+		t.Fatalf("expected nil breaker without threshold")
+	}
+	if b.Record(errors.New("boom")) || b.State() != CircuitClosed {
+		t.Fatalf("nil breaker should never hold messages")
+	}
+}
+
+func TestGetCircuitBreakerFromEnv(t *testing.T) {
+	t.Setenv("KAFKA_CIRCUIT_BREAKER_THRESHOLD", "5")
+	t.Setenv("KAFKA_CIRCUIT_BREAKER_COOLDOWN_MS", "1500")
+	cfg := getCircuitBreakerFromEnv()
+	if cfg == nil || cfg.FailureThreshold != 5 || cfg.CoolDown != 1500*time.Millisecond {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	t.Setenv("KAFKA_CIRCUIT_BREAKER_THRESHOLD", "")
+	if getCircuitBreakerFromEnv() != nil {
+		t.Fatalf("expected breaker to be disabled without threshold")
+	}
+}
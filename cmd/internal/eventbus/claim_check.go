@@ -0,0 +1,270 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// DefaultClaimCheckThreshold는 클레임 체크를 적용할 페이로드 크기의 기본값입니다 (512KiB).
+const DefaultClaimCheckThreshold = 512 * 1024
+
+// ErrBlobIntegrity는 블롭 내용이 이벤트에 기록된 크기/해시와 다를 때 반환되는 오류입니다.
+var ErrBlobIntegrity = errors.New("블롭 무결성 검사 실패")
+
+// BlobRef는 클레임 체크로 분리된 페이로드의 위치입니다. 이벤트에는 페이로드 대신 이 참조만 실립니다.
+type BlobRef struct {
+	Key    string `json:"key"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ClaimCheck는 큰 페이로드를 BlobStore에 저장하고 이벤트에는 참조만 싣는 클레임 체크 설정입니다.
+//
+// 발행 시 페이로드가 Threshold보다 크면 블롭으로 분리하고, 구독 시에는 핸들러 실행 전에 블롭을 읽어
+// Payload를 채우므로 핸들러와 DecodeJSON/SubscribeJSON은 차이를 알 필요가 없습니다.
+// 재시도/DLQ 토픽으로는 참조만 전달되며, GCGroups에 지정한 그룹이 처리에 성공하고 오프셋을 커밋한 뒤 블롭을 삭제합니다.
+// DLQ로 보낸 이벤트의 블롭은 dlqctl show/replay를 위해 남겨 둡니다. 재처리(ReplayDLQRecord)는 사본 블롭을 만들어 발행하고,
+// dlqctl replay/purge가 재처리했거나 잘라낸 DLQ 레코드의 원본 블롭을 DeleteDLQBlobs로 삭제합니다.
+type ClaimCheck struct {
+	Store BlobStore
+	// Threshold는 블롭으로 분리할 페이로드 크기(바이트)입니다. 0 이하면 DefaultClaimCheckThreshold를 사용합니다.
+	Threshold int
+	// GCGroups는 처리 후 블롭을 삭제할 컨슈머 그룹입니다. 비어 있으면 어느 그룹도 삭제하지 않으므로
+	// 저장소의 수명 주기 규칙(TTL)으로 정리해야 합니다. 한 토픽을 여러 그룹이 구독하면
+	// (예: tech-letter.post.summary의 content_service와 summary_worker) 마지막 소비자 그룹만 지정해야 합니다.
+	GCGroups []string
+}
+
+func (c *ClaimCheck) threshold() int {
+	if c.Threshold <= 0 {
+		return DefaultClaimCheckThreshold
+	}
+	return c.Threshold
+}
+
+// offload는 페이로드가 임계값을 넘으면 블롭으로 저장하고 참조만 남긴 이벤트를 반환합니다.
+func (c *ClaimCheck) offload(ctx context.Context, topic string, evt Event) (Event, error) {
+	if c == nil || c.Store == nil || evt.PayloadRef != nil || len(evt.Payload) <= c.threshold() {
+		return evt, nil
+	}
+	sum := sha256.Sum256(evt.Payload)
+	hash := hex.EncodeToString(sum[:])
+	ref := &BlobRef{
+		Key:    blobKey(topic, evt.ID, hash, ""),
+		Size:   len(evt.Payload),
+		SHA256: hash,
+	}
+	if err := c.Store.Put(ctx, ref.Key, evt.Payload); err != nil {
+		return evt, fmt.Errorf("이벤트 %s 페이로드 블롭 저장 실패: %w", evt.ID, err)
+	}
+	logger.Log.Debugf("이벤트 %s 페이로드(%d바이트)를 블롭 %s로 분리", evt.ID, ref.Size, ref.Key)
+	evt.Payload = nil
+	evt.PayloadRef = ref
+	return evt, nil
+}
+
+// blobKey는 블롭 키 "<기본 토픽>/<이벤트 ID>-<해시 앞 16자>[-<suffix>].json"을 만듭니다.
+// 같은 이벤트를 다시 발행하면 같은 키에 덮어쓰므로 발행 재시도가 블롭을 늘리지 않습니다.
+func blobKey(topic, id, hash, suffix string) string {
+	key := fmt.Sprintf("%s/%s-%s", baseTopicName(topic), url.PathEscape(id), hash[:16])
+	if suffix != "" {
+		key += "-" + suffix
+	}
+	return key + ".json"
+}
+
+// copyBlob은 evt가 참조하는 블롭을 검사한 뒤 key로 복사하고 새 참조를 반환합니다.
+func (c *ClaimCheck) copyBlob(ctx context.Context, evt Event, key string) (*BlobRef, error) {
+	evt.Payload = nil
+	resolved, err := c.Resolve(ctx, evt)
+	if err != nil {
+		return nil, err
+	}
+	ref := &BlobRef{Key: key, Size: evt.PayloadRef.Size, SHA256: evt.PayloadRef.SHA256}
+	if err := c.Store.Put(ctx, key, resolved.Payload); err != nil {
+		return nil, fmt.Errorf("이벤트 %s 블롭 %s 복사 실패: %w", evt.ID, key, err)
+	}
+	return ref, nil
+}
+
+// offloaded는 이벤트가 페이로드 없이 블롭 참조만 가지고 있는지 확인합니다.
+// JSON 봉투는 비어 있는 페이로드를 null로 기록하므로 null도 빈 페이로드로 취급합니다.
+func (e Event) offloaded() bool {
+	return e.PayloadRef != nil && (len(e.Payload) == 0 || string(e.Payload) == "null")
+}
+
+// Resolve는 이벤트가 블롭 참조만 가지고 있으면 블롭을 읽어 Payload를 채운 이벤트를 반환합니다.
+// PayloadRef는 처리 후 삭제(GC)를 위해 그대로 둡니다. c가 nil이면 SetDefaultClaimCheck로 지정한 설정을 사용합니다.
+func (c *ClaimCheck) Resolve(ctx context.Context, evt Event) (Event, error) {
+	if !evt.offloaded() {
+		return evt, nil
+	}
+	if c == nil || c.Store == nil {
+		c = defaultClaimCheck.Load()
+	}
+	if c == nil || c.Store == nil {
+		return evt, fmt.Errorf("이벤트 %s는 블롭 %s를 참조하지만 블롭 저장소가 설정되지 않았습니다", evt.ID, evt.PayloadRef.Key)
+	}
+	data, err := c.Store.Get(ctx, evt.PayloadRef.Key)
+	if err != nil {
+		return evt, fmt.Errorf("이벤트 %s 페이로드 블롭 읽기 실패: %w", evt.ID, err)
+	}
+	sum := sha256.Sum256(data)
+	if len(data) != evt.PayloadRef.Size || hex.EncodeToString(sum[:]) != evt.PayloadRef.SHA256 {
+		return evt, fmt.Errorf("%w: 이벤트 %s 블롭 %s", ErrBlobIntegrity, evt.ID, evt.PayloadRef.Key)
+	}
+	evt.Payload = bytes.Clone(data)
+	return evt, nil
+}
+
+// release는 처리가 끝난 이벤트의 블롭을 삭제합니다. 삭제 실패는 처리 결과에 영향을 주지 않도록 로그만 남깁니다.
+func (c *ClaimCheck) release(ctx context.Context, groupID string, evt Event) {
+	if c == nil || c.Store == nil || evt.PayloadRef == nil {
+		return
+	}
+	if !slices.Contains(c.GCGroups, groupID) {
+		return
+	}
+	if err := c.Store.Delete(context.WithoutCancel(ctx), evt.PayloadRef.Key); err != nil {
+		logger.Log.Warnf("이벤트 %s 블롭 %s 삭제 실패: %v", evt.ID, evt.PayloadRef.Key, err)
+	}
+}
+
+// blobReleases는 파티션별로 오프셋 커밋을 기다리는 블롭 삭제 목록입니다. 커밋 전에 블롭을 지우면
+// 재시작이나 파티션 회수로 다시 받은 메시지가 블롭을 찾지 못하고 DLQ로 가므로, 커밋된 오프셋의 블롭만 지웁니다.
+// Subscribe 메인 루프 고루틴(리밸런스 콜백 포함)에서만 다룹니다.
+type blobReleases map[partitionKey][]pendingRelease
+
+type pendingRelease struct {
+	offset int64
+	evt    Event
+}
+
+// Add는 offset 메시지가 커밋되면 evt의 블롭을 지우도록 기록합니다.
+func (b blobReleases) Add(key partitionKey, offset int64, evt Event) {
+	b[key] = append(b[key], pendingRelease{offset: offset, evt: evt})
+}
+
+// Committed는 next(다음에 읽을 오프셋)까지 커밋되어 블롭을 지워도 되는 이벤트를 꺼내 반환합니다.
+func (b blobReleases) Committed(key partitionKey, next int64) []Event {
+	pending := b[key]
+	var out []Event
+	kept := pending[:0]
+	for _, r := range pending {
+		if r.offset < next {
+			out = append(out, r.evt)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
+		delete(b, key)
+	} else {
+		b[key] = kept
+	}
+	return out
+}
+
+// Forget은 회수된 파티션의 삭제 목록을 버립니다. 새 소유자가 메시지를 다시 처리하고 커밋한 뒤 지웁니다.
+func (b blobReleases) Forget(key partitionKey) {
+	delete(b, key)
+}
+
+// claimCheckHandler는 핸들러 실행 전에 블롭 참조를 실제 페이로드로 바꿉니다.
+// 블롭이 없거나 내용이 손상되었으면 재시도해도 소용없으므로 영구 오류로 반환합니다.
+func claimCheckHandler(c *ClaimCheck, handler EventHandler) EventHandler {
+	return func(ctx context.Context, evt Event) error {
+		resolved, err := c.Resolve(ctx, evt)
+		if err != nil {
+			if errors.Is(err, ErrBlobNotFound) || errors.Is(err, ErrBlobIntegrity) {
+				return Permanent(err)
+			}
+			return err
+		}
+		return handler(ctx, resolved)
+	}
+}
+
+var defaultClaimCheck atomic.Pointer[ClaimCheck]
+
+// SetDefaultClaimCheck는 버스 밖에서 디코딩하는 이벤트(DecodeJSON, DLQ 조회 등)의 블롭 참조를 풀 때 사용할 설정을 지정합니다.
+// NewKafkaEventBus는 환경변수로 클레임 체크가 설정되면 자동으로 호출합니다.
+func SetDefaultClaimCheck(c *ClaimCheck) {
+	defaultClaimCheck.Store(c)
+}
+
+// NewBlobStoreFromURL은 URL 형식의 설정으로 BlobStore를 생성합니다.
+//   - file:///var/lib/tech-letter/blobs 또는 /var/lib/tech-letter/blobs: 로컬 파일 시스템
+//   - s3://bucket/prefix: S3 호환 저장소. 엔드포인트와 인증 정보는 EVENTBUS_S3_* 환경변수에서 읽습니다.
+func NewBlobStoreFromURL(raw string) (BlobStore, error) {
+	if strings.HasPrefix(raw, "/") {
+		return NewFileBlobStore(raw)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("블롭 저장소 URL 파싱 실패: %w", err)
+	}
+	switch u.Scheme {
+	case "file":
+		return NewFileBlobStore(u.Path)
+	case "s3":
+		return NewS3BlobStore(S3Config{
+			Endpoint:  os.Getenv("EVENTBUS_S3_ENDPOINT"),
+			Bucket:    u.Host,
+			Prefix:    u.Path,
+			Region:    os.Getenv("EVENTBUS_S3_REGION"),
+			AccessKey: os.Getenv("EVENTBUS_S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("EVENTBUS_S3_SECRET_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("지원하지 않는 블롭 저장소: %s", raw)
+	}
+}
+
+// getClaimCheckFromEnv 는 EVENTBUS_BLOB_STORE 환경변수로 클레임 체크 설정을 만든다.
+// 비어 있으면 nil(사용 안 함)을 반환한다. 임계값은 EVENTBUS_CLAIM_CHECK_THRESHOLD_BYTES,
+// 블롭을 삭제할 컨슈머 그룹은 EVENTBUS_CLAIM_CHECK_GC_GROUPS(쉼표 구분)로 지정하며, 비어 있으면 삭제하지 않는다.
+func getClaimCheckFromEnv() *ClaimCheck {
+	raw := strings.TrimSpace(os.Getenv("EVENTBUS_BLOB_STORE"))
+	if raw == "" {
+		return nil
+	}
+	store, err := NewBlobStoreFromURL(raw)
+	if err != nil {
+		logger.Log.Warnf("EVENTBUS_BLOB_STORE 설정 오류: %v. 클레임 체크를 사용하지 않습니다.", err)
+		return nil
+	}
+
+	threshold := DefaultClaimCheckThreshold
+	if v := strings.TrimSpace(os.Getenv("EVENTBUS_CLAIM_CHECK_THRESHOLD_BYTES")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			logger.Log.Warnf("EVENTBUS_CLAIM_CHECK_THRESHOLD_BYTES 환경변수 파싱 실패: %q. 기본값 %d 사용.", v, DefaultClaimCheckThreshold)
+		} else {
+			threshold = n
+		}
+	}
+
+	var groups []string
+	for _, g := range strings.Split(os.Getenv("EVENTBUS_CLAIM_CHECK_GC_GROUPS"), ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 {
+		logger.Log.Warn("EVENTBUS_CLAIM_CHECK_GC_GROUPS가 비어 있어 처리 후 블롭을 삭제하지 않습니다. 저장소 수명 주기 규칙(TTL)이 없으면 블롭이 계속 쌓입니다.")
+	}
+	return &ClaimCheck{Store: store, Threshold: threshold, GCGroups: groups}
+}
@@ -0,0 +1,294 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type largePayload struct {
+	Type string `json:"type"`
+	HTML string `json:"html"`
+}
+
+func newTestClaimCheck(t *testing.T, groups ...string) *ClaimCheck {
+	t.Helper()
+	store, err := NewFileBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected store error: %v", err)
+	}
+	return &ClaimCheck{Store: store, Threshold: 64, GCGroups: groups}
+}
+
+func TestFileBlobStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected store error: %v", err)
+	}
+	if err := store.Put(ctx, "topic/evt-1.json", []byte(`{"a":1}`)); err != nil {
+		t.Fatalf("unexpected put error: %v", err)
+	}
+	got, err := store.Get(ctx, "topic/evt-1.json")
+	if err != nil || string(got) != `{"a":1}` {
+		t.Fatalf("unexpected get result: %s, %v", got, err)
+	}
+	if err := store.Delete(ctx, "topic/evt-1.json"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if _, err := store.Get(ctx, "topic/evt-1.json"); !errors.Is(err, ErrBlobNotFound) {
+		t.Fatalf("expected ErrBlobNotFound, got %v", err)
+	}
+	if err := store.Delete(ctx, "topic/evt-1.json"); err != nil {
+		t.Fatalf("deleting a missing blob should succeed, got %v", err)
+	}
+	if err := store.Put(ctx, "../escape", nil); err == nil {
+		t.Fatalf("expected error for key escaping the store directory")
+	}
+}
+
+func TestS3BlobStoreSignsRequests(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+			http.Error(w, "unsigned", http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				http.Error(w, "NoSuchKey", http.StatusNotFound)
+				return
+			}
+			w.Write(body)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewS3BlobStore(S3Config{
+		Endpoint:  server.URL,
+		Bucket:    "events",
+		Prefix:    "/claims/",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("unexpected store error: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := store.Put(ctx, "topic/evt-1.json", []byte("hello")); err != nil {
+		t.Fatalf("unexpected put error: %v", err)
+	}
+	if _, ok := objects["/events/claims/topic/evt-1.json"]; !ok {
+		t.Fatalf("expected path-style object key, got %v", objects)
+	}
+	got, err := store.Get(ctx, "topic/evt-1.json")
+	if err != nil || string(got) != "hello" {
+		t.Fatalf("unexpected get result: %q, %v", got, err)
+	}
+	if err := store.Delete(ctx, "topic/evt-1.json"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if _, err := store.Get(ctx, "topic/evt-1.json"); !errors.Is(err, ErrBlobNotFound) {
+		t.Fatalf("expected ErrBlobNotFound, got %v", err)
+	}
+}
+
+func TestMemoryEventBusClaimCheckRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewMemoryEventBus(NewManualClock(time.Unix(0, 0)))
+	defer bus.Close()
+	bus.ClaimCheck = newTestClaimCheck(t, "group")
+	topic := NewTopic("test.memory.claimcheck")
+
+	want := largePayload{Type: "post.rendered", HTML: strings.Repeat("<p>본문</p>", 100)}
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", want, 0)
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+
+	stored := bus.Events(topic.Base())[0]
+	if !stored.offloaded() {
+		t.Fatalf("expected payload to be offloaded, got %+v", stored)
+	}
+	if !strings.HasPrefix(stored.PayloadRef.Key, topic.Base()+"/evt-1-") || stored.PayloadRef.Size != len(evt.Payload) {
+		t.Fatalf("unexpected blob ref: %+v", stored.PayloadRef)
+	}
+
+	received := make(chan largePayload, 1)
+	go SubscribeJSON(ctx, bus, "group", topic, func(ctx context.Context, payload largePayload, meta Event) error {
+		received <- payload
+		return nil
+	})
+	select {
+	case got := <-received:
+		if got != want {
+			t.Fatalf("unexpected payload: %+v", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("handler was not called")
+	}
+
+	waitFor(t, func() bool {
+		_, err := bus.ClaimCheck.Store.Get(ctx, stored.PayloadRef.Key)
+		return errors.Is(err, ErrBlobNotFound)
+	})
+}
+
+func TestClaimCheckReleaseRespectsGCGroups(t *testing.T) {
+	ctx := context.Background()
+	cc := newTestClaimCheck(t, "last")
+	evt, err := cc.offload(ctx, "test.gc", Event{ID: "evt-1", Payload: []byte(`"` + strings.Repeat("x", 128) + `"`)})
+	if err != nil || evt.PayloadRef == nil {
+		t.Fatalf("expected offloaded event, got %+v, %v", evt, err)
+	}
+
+	cc.release(ctx, "first", evt)
+	if _, err := cc.Store.Get(ctx, evt.PayloadRef.Key); err != nil {
+		t.Fatalf("blob should be kept for groups outside GCGroups, got %v", err)
+	}
+	// GCGroups가 비어 있으면 어느 그룹도 삭제하지 않는다.
+	(&ClaimCheck{Store: cc.Store}).release(ctx, "last", evt)
+	if _, err := cc.Store.Get(ctx, evt.PayloadRef.Key); err != nil {
+		t.Fatalf("blob should be kept when GCGroups is empty, got %v", err)
+	}
+	cc.release(ctx, "last", evt)
+	if _, err := cc.Store.Get(ctx, evt.PayloadRef.Key); !errors.Is(err, ErrBlobNotFound) {
+		t.Fatalf("expected blob to be deleted, got %v", err)
+	}
+}
+
+func TestBlobReleasesWaitForCommit(t *testing.T) {
+	key := partitionKey{topic: "test.release", partition: 0}
+	releases := make(blobReleases)
+	releases.Add(key, 3, Event{ID: "evt-3"})
+	releases.Add(key, 5, Event{ID: "evt-5"})
+
+	if got := releases.Committed(key, 3); len(got) != 0 {
+		t.Fatalf("offset 3 is not committed yet, got %+v", got)
+	}
+	if got := releases.Committed(key, 4); len(got) != 1 || got[0].ID != "evt-3" {
+		t.Fatalf("expected evt-3 after commit at 4, got %+v", got)
+	}
+	releases.Forget(key)
+	if got := releases.Committed(key, 10); len(got) != 0 {
+		t.Fatalf("revoked partition should not release blobs, got %+v", got)
+	}
+}
+
+func TestMemoryEventBusClaimCheckMissingBlobGoesToDLQ(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewMemoryEventBus(NewManualClock(time.Unix(0, 0)))
+	defer bus.Close()
+	bus.ClaimCheck = newTestClaimCheck(t, "group")
+	topic := NewTopic("test.memory.claimcheck.dlq")
+
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", largePayload{HTML: strings.Repeat("x", 128)}, 0)
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+	ref := bus.Events(topic.Base())[0].PayloadRef
+	if err := bus.ClaimCheck.Store.Put(ctx, ref.Key, []byte(`{"tampered":true}`)); err != nil {
+		t.Fatalf("unexpected put error: %v", err)
+	}
+
+	var called atomic.Bool
+	go bus.Subscribe(ctx, "group", topic, func(ctx context.Context, evt Event) error {
+		called.Store(true)
+		return nil
+	})
+
+	waitFor(t, func() bool { return len(bus.Events(topic.DLQ())) == 1 })
+	dlq := bus.Events(topic.DLQ())[0]
+	if called.Load() {
+		t.Fatalf("handler should not be called for a corrupted blob")
+	}
+	if dlq.DLQ == nil || dlq.DLQ.Reason != DLQReasonPermanentError || !dlq.offloaded() {
+		t.Fatalf("unexpected DLQ event: %+v", dlq)
+	}
+	if !strings.Contains(dlq.LastError, ErrBlobIntegrity.Error()) {
+		t.Fatalf("expected integrity error, got %q", dlq.LastError)
+	}
+	// DLQ 레코드를 조회하거나 재처리할 수 있도록 블롭은 남아 있어야 한다.
+	if _, err := bus.ClaimCheck.Store.Get(ctx, ref.Key); err != nil {
+		t.Fatalf("blob of a DLQ'd event should be kept, got %v", err)
+	}
+}
+
+func TestReplayDLQRecordCopiesBlobSoOriginalCanBeDeleted(t *testing.T) {
+	ctx := context.Background()
+	bus := NewMemoryEventBus(NewManualClock(time.Unix(0, 0)))
+	defer bus.Close()
+	bus.ClaimCheck = newTestClaimCheck(t)
+	topic := NewTopic("test.memory.claimcheck.replay")
+
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", largePayload{HTML: strings.Repeat("x", 128)}, 0)
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+	original := bus.Events(topic.Base())[0]
+	record := DLQRecord{Topic: topic.DLQ(), Partition: 0, Offset: 7, Event: original}
+
+	if err := ReplayDLQRecord(ctx, bus, topic, record, nil, false); err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	replayed := bus.Events(topic.Base())[1]
+	if replayed.PayloadRef == nil || replayed.PayloadRef.Key == original.PayloadRef.Key {
+		t.Fatalf("replayed event should reference its own blob, got %+v", replayed.PayloadRef)
+	}
+
+	// 같은 블롭을 참조하는 레코드가 DLQ에 남아 있으면 지우지 않는다.
+	if n, err := DeleteDLQBlobs(ctx, bus.ClaimCheck, []DLQRecord{record}, []DLQRecord{{Offset: 8, Event: original}}); err != nil || n != 0 {
+		t.Fatalf("blob still referenced by a kept record should not be deleted, got %d, %v", n, err)
+	}
+	if n, err := DeleteDLQBlobs(ctx, bus.ClaimCheck, []DLQRecord{record}, nil); err != nil || n != 1 {
+		t.Fatalf("expected the original blob to be deleted, got %d, %v", n, err)
+	}
+	if _, err := bus.ClaimCheck.Store.Get(ctx, original.PayloadRef.Key); !errors.Is(err, ErrBlobNotFound) {
+		t.Fatalf("expected original blob to be gone, got %v", err)
+	}
+	if resolved, err := bus.ClaimCheck.Resolve(ctx, replayed); err != nil || string(resolved.Payload) != string(evt.Payload) {
+		t.Fatalf("replayed event should still resolve its payload, got %v", err)
+	}
+}
+
+func TestBinaryCodecsKeepPayloadRef(t *testing.T) {
+	ref := &BlobRef{Key: "t/evt-1-0123456789abcdef.json", Size: 1234, SHA256: strings.Repeat("ab", 32)}
+	for _, codec := range []EnvelopeCodec{MsgpackEnvelope, ProtobufEnvelope, NewCloudEventsBinaryCodec("/test")} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			value, headers, err := codec.Encode(Event{ID: "evt-1", PayloadRef: ref})
+			if err != nil {
+				t.Fatalf("unexpected encode error: %v", err)
+			}
+			got, err := DecodeEnvelope(value, headers)
+			if err != nil {
+				t.Fatalf("unexpected decode error: %v", err)
+			}
+			if got.PayloadRef == nil || *got.PayloadRef != *ref {
+				t.Fatalf("expected payload ref %+v, got %+v", ref, got.PayloadRef)
+			}
+		})
+	}
+}
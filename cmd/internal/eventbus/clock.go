@@ -0,0 +1,138 @@
+package eventbus
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock은 현재 시각과 타이머를 제공하는 추상화입니다.
+// 재시도 지연 계산을 테스트에서 결정적으로 제어할 수 있도록 주입받아 사용합니다.
+type Clock interface {
+	Now() time.Time
+	// NewTimer는 지정한 시각(deadline)에 만료되는 타이머를 생성합니다.
+	// deadline이 이미 지났다면 즉시 만료됩니다.
+	NewTimer(deadline time.Time) Timer
+}
+
+// Timer는 Clock이 생성하는 단발성 타이머입니다.
+type Timer interface {
+	C() <-chan time.Time
+	// Stop은 타이머를 중지합니다. 이미 만료되었거나 중지된 경우 false를 반환합니다.
+	Stop() bool
+}
+
+// SystemClock은 실제 시스템 시각을 사용하는 Clock 구현체입니다.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(deadline time.Time) Timer {
+	return &systemTimer{t: time.NewTimer(time.Until(deadline))}
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (s *systemTimer) C() <-chan time.Time {
+	return s.t.C
+}
+
+func (s *systemTimer) Stop() bool {
+	return s.t.Stop()
+}
+
+// ManualClock은 Advance/Set 호출로만 시간이 흐르는 테스트용 Clock 구현체입니다.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+// NewManualClock은 start 시각에서 시작하는 ManualClock을 생성합니다.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) NewTimer(deadline time.Time) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &manualTimer{clock: c, deadline: deadline, ch: make(chan time.Time, 1)}
+	if !deadline.After(c.now) {
+		t.ch <- c.now
+		t.fired = true
+		return t
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance는 시계를 d만큼 앞으로 이동시키고, 만료된 타이머를 모두 발화합니다.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(c.now.Add(d))
+}
+
+// Set은 시계를 지정한 시각으로 이동시킵니다. 과거 시각으로는 이동하지 않습니다.
+func (c *ManualClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Before(c.now) {
+		return
+	}
+	c.setLocked(now)
+}
+
+func (c *ManualClock) setLocked(now time.Time) {
+	c.now = now
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.fired = true
+		t.ch <- now
+	}
+	c.timers = pending
+}
+
+type manualTimer struct {
+	clock    *ManualClock
+	deadline time.Time
+	ch       chan time.Time
+	fired    bool
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *manualTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.fired {
+		return false
+	}
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			break
+		}
+	}
+	t.fired = true
+	return true
+}
@@ -0,0 +1,157 @@
+package eventbus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// headerEventCodec은 바이너리 봉투 코덱 이름을 기록하는 메시지 헤더입니다.
+// 헤더가 없으면 기존 JSON 봉투로 간주하므로, 코덱을 바꾸는 동안 이전 메시지와 섞여 있어도 읽을 수 있습니다.
+const headerEventCodec = "X-Event-Codec"
+
+// 바이너리 코덱 이름
+const (
+	EnvelopeMsgpack  = "msgpack"
+	EnvelopeProtobuf = "protobuf"
+)
+
+// MsgpackEnvelope은 Event를 MessagePack으로 직렬화하는 코덱입니다.
+// 페이로드도 JSON 문자열이 아닌 MessagePack 맵으로 변환해 담고, 디코딩 시 다시 JSON으로 복원하므로
+// 핸들러와 DecodeJSON은 코덱과 관계없이 같은 페이로드를 받습니다(키 순서는 달라질 수 있습니다).
+var MsgpackEnvelope EnvelopeCodec = msgpackEnvelope{}
+
+type msgpackEnvelope struct{}
+
+// msgpackEvent는 페이로드만 임의 값으로 바꾼 Event의 MessagePack 표현입니다. 나머지 필드 이름은 json 태그를 따릅니다.
+type msgpackEvent struct {
+	ID            string          `json:"id"`
+	Payload       any             `json:"payload"`
+	PayloadRef    *BlobRef        `json:"payload_ref,omitempty"`
+	Retry         int             `json:"retry"`
+	MaxRetry      int             `json:"max_retry"`
+	LastError     string          `json:"last_error,omitempty"`
+	SchemaVersion int             `json:"schema_version,omitempty"`
+	Failures      []FailureRecord `json:"failures,omitempty"`
+	DLQ           *DLQEnvelope    `json:"dlq,omitempty"`
+}
+
+func (msgpackEnvelope) Name() string { return EnvelopeMsgpack }
+
+func (msgpackEnvelope) Encode(evt Event) ([]byte, []kafka.Header, error) {
+	payload, err := jsonToNative(evt.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("페이로드 변환 실패: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	if err := enc.Encode(msgpackEvent{
+		ID:            evt.ID,
+		Payload:       payload,
+		PayloadRef:    evt.PayloadRef,
+		Retry:         evt.Retry,
+		MaxRetry:      evt.MaxRetry,
+		LastError:     evt.LastError,
+		SchemaVersion: evt.SchemaVersion,
+		Failures:      evt.Failures,
+		DLQ:           evt.DLQ,
+	}); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), codecHeaders(EnvelopeMsgpack), nil
+}
+
+func (msgpackEnvelope) Decode(value []byte, _ []kafka.Header) (Event, error) {
+	dec := msgpack.NewDecoder(bytes.NewReader(value))
+	dec.SetCustomStructTag("json")
+	var m msgpackEvent
+	if err := dec.Decode(&m); err != nil {
+		return Event{}, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	evt := Event{
+		ID:            m.ID,
+		PayloadRef:    m.PayloadRef,
+		Retry:         m.Retry,
+		MaxRetry:      m.MaxRetry,
+		LastError:     m.LastError,
+		SchemaVersion: m.SchemaVersion,
+		Failures:      m.Failures,
+		DLQ:           m.DLQ,
+	}
+	if m.Payload != nil {
+		payload, err := json.Marshal(m.Payload)
+		if err != nil {
+			return Event{}, fmt.Errorf("%w: 페이로드 JSON 복원 실패: %v", ErrInvalidEnvelope, err)
+		}
+		evt.Payload = payload
+	}
+	return evt, nil
+}
+
+// jsonToNative는 JSON 페이로드를 MessagePack으로 옮길 수 있는 Go 값으로 변환합니다.
+// 정수는 정수 그대로 유지해야 디코딩 후에도 1이 1.0으로 바뀌지 않습니다.
+func jsonToNative(raw json.RawMessage) (any, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return convertJSONNumbers(v), nil
+}
+
+func convertJSONNumbers(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			return n
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]any:
+		for k, item := range t {
+			t[k] = convertJSONNumbers(item)
+		}
+		return t
+	case []any:
+		for i, item := range t {
+			t[i] = convertJSONNumbers(item)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+func codecHeaders(name string) []kafka.Header {
+	return []kafka.Header{{Key: headerEventCodec, Value: []byte(name)}}
+}
+
+// decodeWithCodecHeader는 X-Event-Codec 헤더에 기록된 코덱으로 메시지를 해석합니다.
+// 헤더가 없으면 ok=false를 반환합니다.
+func decodeWithCodecHeader(value []byte, headers []kafka.Header) (evt Event, ok bool, err error) {
+	name, found := headerValue(headers, headerEventCodec)
+	if !found {
+		return Event{}, false, nil
+	}
+	switch name {
+	case EnvelopeJSON:
+		evt, err = JSONEnvelope.Decode(value, headers)
+	case EnvelopeMsgpack:
+		evt, err = MsgpackEnvelope.Decode(value, headers)
+	case EnvelopeProtobuf:
+		evt, err = ProtobufEnvelope.Decode(value, headers)
+	default:
+		err = fmt.Errorf("%w: 알 수 없는 코덱 %q", ErrInvalidEnvelope, name)
+	}
+	return evt, true, err
+}
@@ -0,0 +1,278 @@
+package eventbus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ProtobufEnvelope은 Event를 proto/event.proto 형식으로 직렬화하는 코덱입니다.
+// 생성 코드 없이 protowire로 직접 인코딩하며, 모르는 필드는 건너뛰어 이후 필드 추가와 호환됩니다.
+var ProtobufEnvelope EnvelopeCodec = protobufEnvelope{}
+
+type protobufEnvelope struct{}
+
+func (protobufEnvelope) Name() string { return EnvelopeProtobuf }
+
+func (protobufEnvelope) Encode(evt Event) ([]byte, []kafka.Header, error) {
+	var b []byte
+	b = appendString(b, 1, evt.ID)
+	if len(evt.Payload) > 0 {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, evt.Payload)
+	}
+	b = appendInt(b, 3, int64(evt.Retry))
+	b = appendInt(b, 4, int64(evt.MaxRetry))
+	b = appendString(b, 5, evt.LastError)
+	b = appendInt(b, 6, int64(evt.SchemaVersion))
+	for _, f := range evt.Failures {
+		b = appendMessage(b, 7, encodeFailureRecord(f))
+	}
+	if evt.DLQ != nil {
+		b = appendMessage(b, 8, encodeDLQEnvelope(*evt.DLQ))
+	}
+	if evt.PayloadRef != nil {
+		b = appendMessage(b, 9, encodeBlobRef(*evt.PayloadRef))
+	}
+	return b, codecHeaders(EnvelopeProtobuf), nil
+}
+
+func (protobufEnvelope) Decode(value []byte, _ []kafka.Header) (Event, error) {
+	var evt Event
+	err := consumeFields(value, func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error {
+		switch num {
+		case 1:
+			evt.ID = string(data)
+		case 2:
+			evt.Payload = append([]byte(nil), data...)
+		case 3:
+			evt.Retry = int(int64(v))
+		case 4:
+			evt.MaxRetry = int(int64(v))
+		case 5:
+			evt.LastError = string(data)
+		case 6:
+			evt.SchemaVersion = int(int64(v))
+		case 7:
+			f, err := decodeFailureRecord(data)
+			if err != nil {
+				return err
+			}
+			evt.Failures = append(evt.Failures, f)
+		case 8:
+			dlq, err := decodeDLQEnvelope(data)
+			if err != nil {
+				return err
+			}
+			evt.DLQ = &dlq
+		case 9:
+			ref, err := decodeBlobRef(data)
+			if err != nil {
+				return err
+			}
+			evt.PayloadRef = &ref
+		}
+		return nil
+	})
+	if err != nil {
+		return Event{}, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	return evt, nil
+}
+
+func encodeFailureRecord(f FailureRecord) []byte {
+	var b []byte
+	b = appendInt(b, 1, int64(f.Attempt))
+	b = appendString(b, 2, f.Error)
+	if f.Permanent {
+		b = appendInt(b, 3, 1)
+	}
+	b = appendString(b, 4, f.Topic)
+	b = appendInt(b, 5, int64(f.Partition))
+	b = appendInt(b, 6, f.Offset)
+	b = appendTimestamp(b, 7, f.FailedAt)
+	return b
+}
+
+func decodeFailureRecord(data []byte) (FailureRecord, error) {
+	var f FailureRecord
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error {
+		var err error
+		switch num {
+		case 1:
+			f.Attempt = int(int64(v))
+		case 2:
+			f.Error = string(data)
+		case 3:
+			f.Permanent = v != 0
+		case 4:
+			f.Topic = string(data)
+		case 5:
+			f.Partition = int32(int64(v))
+		case 6:
+			f.Offset = int64(v)
+		case 7:
+			f.FailedAt, err = decodeTimestamp(data)
+		}
+		return err
+	})
+	return f, err
+}
+
+func encodeDLQEnvelope(d DLQEnvelope) []byte {
+	var b []byte
+	b = appendString(b, 1, d.Reason)
+	b = appendString(b, 2, d.OriginalTopic)
+	b = appendInt(b, 3, int64(d.Partition))
+	b = appendInt(b, 4, d.Offset)
+	b = appendString(b, 5, d.ConsumerGroup)
+	b = appendTimestamp(b, 6, d.FirstFailedAt)
+	b = appendTimestamp(b, 7, d.LastFailedAt)
+	for _, f := range d.Errors {
+		b = appendMessage(b, 8, encodeFailureRecord(f))
+	}
+	return b
+}
+
+func decodeDLQEnvelope(data []byte) (DLQEnvelope, error) {
+	var d DLQEnvelope
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error {
+		var err error
+		switch num {
+		case 1:
+			d.Reason = string(data)
+		case 2:
+			d.OriginalTopic = string(data)
+		case 3:
+			d.Partition = int32(int64(v))
+		case 4:
+			d.Offset = int64(v)
+		case 5:
+			d.ConsumerGroup = string(data)
+		case 6:
+			d.FirstFailedAt, err = decodeTimestamp(data)
+		case 7:
+			d.LastFailedAt, err = decodeTimestamp(data)
+		case 8:
+			var f FailureRecord
+			if f, err = decodeFailureRecord(data); err == nil {
+				d.Errors = append(d.Errors, f)
+			}
+		}
+		return err
+	})
+	return d, err
+}
+
+func encodeBlobRef(r BlobRef) []byte {
+	var b []byte
+	b = appendString(b, 1, r.Key)
+	b = appendInt(b, 2, int64(r.Size))
+	b = appendString(b, 3, r.SHA256)
+	return b
+}
+
+func decodeBlobRef(data []byte) (BlobRef, error) {
+	var r BlobRef
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error {
+		switch num {
+		case 1:
+			r.Key = string(data)
+		case 2:
+			r.Size = int(int64(v))
+		case 3:
+			r.SHA256 = string(data)
+		}
+		return nil
+	})
+	return r, err
+}
+
+// proto3 기본값(0, 빈 문자열)은 기록하지 않는다.
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendInt(b []byte, num protowire.Number, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// appendTimestamp는 google.protobuf.Timestamp(seconds=1, nanos=2)와 같은 형식으로 시각을 기록합니다.
+func appendTimestamp(b []byte, num protowire.Number, t time.Time) []byte {
+	if t.IsZero() {
+		return b
+	}
+	var ts []byte
+	ts = appendInt(ts, 1, t.Unix())
+	ts = appendInt(ts, 2, int64(t.Nanosecond()))
+	return appendMessage(b, num, ts)
+}
+
+func decodeTimestamp(data []byte) (time.Time, error) {
+	var sec, nsec int64
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error {
+		switch num {
+		case 1:
+			sec = int64(v)
+		case 2:
+			nsec = int64(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// consumeFields는 메시지의 필드를 차례로 읽어 fn에 전달합니다.
+// varint 필드는 v로, length-delimited 필드는 data로 전달하며 그 밖의 타입은 건너뜁니다.
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var v uint64
+		var data []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			data, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if err := fn(num, typ, v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
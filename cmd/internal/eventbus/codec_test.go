@@ -0,0 +1,158 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func newCodecTestEvent() Event {
+	failedAt := time.Date(2025, 3, 4, 5, 6, 7, 890, time.UTC)
+	failures := []FailureRecord{{Attempt: 0, Error: "boom", Permanent: true, Topic: "t", Partition: 2, Offset: -1, FailedAt: failedAt}}
+	return Event{
+		ID:            "evt-codec",
+		Payload:       json.RawMessage(`{"type":"post.summary_requested","count":3,"ratio":0.5,"tags":["a","b"],"nested":{"big":9007199254740993}}`),
+		Retry:         1,
+		MaxRetry:      3,
+		LastError:     "boom",
+		SchemaVersion: 2,
+		Failures:      failures,
+		DLQ: &DLQEnvelope{
+			Reason:        DLQReasonPermanentError,
+			OriginalTopic: "t",
+			Partition:     2,
+			Offset:        -1,
+			ConsumerGroup: "g",
+			FirstFailedAt: failedAt,
+			LastFailedAt:  failedAt,
+			Errors:        failures,
+		},
+	}
+}
+
+// assertSameEvent는 페이로드를 JSON 의미 기준으로 비교합니다(바이너리 코덱은 키 순서를 보존하지 않습니다).
+func assertSameEvent(t *testing.T, want, got Event) {
+	t.Helper()
+	var wantPayload, gotPayload any
+	if err := json.Unmarshal(want.Payload, &wantPayload); err != nil {
+		t.Fatalf("invalid want payload: %v", err)
+	}
+	if err := json.Unmarshal(got.Payload, &gotPayload); err != nil {
+		t.Fatalf("decoded payload is not JSON: %v (%s)", err, got.Payload)
+	}
+	wantJSON, _ := json.Marshal(wantPayload)
+	gotJSON, _ := json.Marshal(gotPayload)
+	if string(wantJSON) != string(gotJSON) {
+		t.Fatalf("payload mismatch:\nwant %s\ngot  %s", wantJSON, gotJSON)
+	}
+
+	want.Payload, got.Payload = nil, nil
+	wantMeta, _ := json.Marshal(want)
+	gotMeta, _ := json.Marshal(got)
+	if string(wantMeta) != string(gotMeta) {
+		t.Fatalf("event mismatch:\nwant %s\ngot  %s", wantMeta, gotMeta)
+	}
+}
+
+func TestBinaryCodecsRoundTrip(t *testing.T) {
+	for _, codec := range []EnvelopeCodec{MsgpackEnvelope, ProtobufEnvelope} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			evt := newCodecTestEvent()
+			value, headers, err := codec.Encode(evt)
+			if err != nil {
+				t.Fatalf("unexpected encode error: %v", err)
+			}
+			if got := findHeader(headers, headerEventCodec); got != codec.Name() {
+				t.Fatalf("expected %s header %q, got %q", headerEventCodec, codec.Name(), got)
+			}
+			got, err := DecodeEnvelope(value, headers)
+			if err != nil {
+				t.Fatalf("unexpected decode error: %v", err)
+			}
+			assertSameEvent(t, evt, got)
+		})
+	}
+}
+
+func TestMsgpackKeepsIntegers(t *testing.T) {
+	evt := Event{ID: "evt-1", Payload: json.RawMessage(`{"n":1,"f":1.5}`)}
+	value, headers, err := MsgpackEnvelope.Encode(evt)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	got, err := DecodeEnvelope(value, headers)
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if string(got.Payload) != `{"f":1.5,"n":1}` {
+		t.Fatalf("unexpected payload: %s", got.Payload)
+	}
+}
+
+func TestDecodeEnvelopeRejectsUnknownCodec(t *testing.T) {
+	headers := []kafka.Header{{Key: headerEventCodec, Value: []byte("avro")}}
+	if _, err := DecodeEnvelope([]byte(`{}`), headers); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Fatalf("expected ErrInvalidEnvelope, got %v", err)
+	}
+}
+
+func TestMemoryEventBusDecodesMixedCodecs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewMemoryEventBus(NewManualClock(time.Unix(0, 0)))
+	defer bus.Close()
+	topic := NewTopic("test.memory.codecs")
+
+	// 코덱 전환 중: 기존 JSON 메시지 뒤에 msgpack, protobuf 메시지가 이어진다.
+	for i, codec := range []EnvelopeCodec{nil, MsgpackEnvelope, ProtobufEnvelope} {
+		bus.Envelope = codec
+		evt, _ := NewTopicJSONEvent(topic, "", testPayload{PostID: string(rune('a' + i))}, 0)
+		if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+			t.Fatalf("unexpected publish error: %v", err)
+		}
+	}
+
+	received := make(chan string, 3)
+	go SubscribeJSON(ctx, bus, "group", topic, func(ctx context.Context, payload testPayload, meta Event) error {
+		received <- payload.PostID
+		return nil
+	})
+	for _, want := range []string{"a", "b", "c"} {
+		select {
+		case got := <-received:
+			if got != want {
+				t.Fatalf("expected %q, got %q", want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("handler was not called for %q", want)
+		}
+	}
+}
+
+func TestParseTopicCompression(t *testing.T) {
+	got := parseTopicCompression("TEST", "a.summary=ZSTD, b.embedding=lz4 ,broken,c=brotli,d.retry.2=gzip")
+	want := map[string]string{"a.summary": "zstd", "b.embedding": "lz4", "d": "gzip"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("expected %s=%s, got %v", k, v, got)
+		}
+	}
+
+	for name, want := range map[string]string{
+		"a.summary":         "a.summary",
+		"a.summary.retry.3": "a.summary",
+		"a.summary.dlq":     "a.summary",
+	} {
+		if got := baseTopicName(name); got != want {
+			t.Fatalf("baseTopicName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
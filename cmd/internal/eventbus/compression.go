@@ -0,0 +1,127 @@
+package eventbus
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// 지원하는 Producer 압축 방식 (librdkafka compression.type)
+const (
+	CompressionNone   = "none"
+	CompressionGzip   = "gzip"
+	CompressionSnappy = "snappy"
+	CompressionLZ4    = "lz4"
+	CompressionZstd   = "zstd"
+)
+
+func validCompression(c string) bool {
+	switch c {
+	case CompressionNone, CompressionGzip, CompressionSnappy, CompressionLZ4, CompressionZstd:
+		return true
+	}
+	return false
+}
+
+// SetTopicCompression은 topic(기본 토픽 이름)과 그 재시도/DLQ 토픽에 사용할 압축 방식을 지정합니다.
+// 재시도/DLQ 토픽은 기본 토픽과 같은 압축 설정을 따릅니다.
+// librdkafka는 Producer 단위로만 압축을 설정할 수 있으므로, 기본 Producer와 다른 압축이 필요하면
+// 해당 압축용 Producer를 처음 발행할 때 만들어 공유합니다.
+func (k *KafkaEventBus) SetTopicCompression(topic, compression string) error {
+	compression = strings.ToLower(strings.TrimSpace(compression))
+	if !validCompression(compression) {
+		return fmt.Errorf("지원하지 않는 압축 방식: %s", compression)
+	}
+	k.producersMu.Lock()
+	defer k.producersMu.Unlock()
+	if k.topicCompression == nil {
+		k.topicCompression = make(map[string]string)
+	}
+	k.topicCompression[baseTopicName(topic)] = compression
+	return nil
+}
+
+// producerFor는 topic의 압축 설정에 맞는 Producer를 반환합니다.
+func (k *KafkaEventBus) producerFor(topic string) (*kafka.Producer, error) {
+	k.producersMu.Lock()
+	defer k.producersMu.Unlock()
+
+	compression, ok := k.topicCompression[baseTopicName(topic)]
+	if !ok || compression == k.compression {
+		return k.Producer, nil
+	}
+	if p, ok := k.producers[compression]; ok {
+		return p, nil
+	}
+
+	cfg := kafka.ConfigMap{}
+	for key, v := range k.producerCfg {
+		cfg[key] = v
+	}
+	cfg["compression.type"] = compression
+	p, err := kafka.NewProducer(&cfg)
+	if err != nil {
+		return nil, fmt.Errorf("kafka Producer(%s 압축) 생성 실패: %w", compression, err)
+	}
+	if k.producers == nil {
+		k.producers = make(map[string]*kafka.Producer)
+	}
+	k.producers[compression] = p
+	go k.handleProducerEvents(p)
+	logger.Log.Infof("%s 압축 Producer 생성", compression)
+	return p, nil
+}
+
+// getKafkaCompressionTypeFromEnv 는 KAFKA_COMPRESSION_TYPE 환경변수에서 기본 Producer의 압축 방식을 읽어온다.
+// 비어 있으면 빈 문자열을 반환하여 라이브러리 기본값(none)을 사용하게 하고, 잘못된 값은 경고 후 무시한다.
+func getKafkaCompressionTypeFromEnv() string {
+	raw := strings.ToLower(strings.TrimSpace(os.Getenv("KAFKA_COMPRESSION_TYPE")))
+	if raw == "" {
+		return ""
+	}
+	if !validCompression(raw) {
+		logger.Log.Warnf("KAFKA_COMPRESSION_TYPE 환경변수 값 %q을 지원하지 않습니다. 기본값 사용.", raw)
+		return ""
+	}
+	return raw
+}
+
+// getKafkaTopicCompressionFromEnv 는 KAFKA_TOPIC_COMPRESSION 환경변수에서 토픽별 압축 방식을 읽어온다.
+// 형식: "tech-letter.post.summary=zstd,tech-letter.post.embedding=lz4". 잘못된 항목은 경고 후 건너뛴다.
+func getKafkaTopicCompressionFromEnv() map[string]string {
+	return parseTopicCompression("KAFKA_TOPIC_COMPRESSION", os.Getenv("KAFKA_TOPIC_COMPRESSION"))
+}
+
+func parseTopicCompression(key, raw string) map[string]string {
+	out := make(map[string]string)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		topic, compression, ok := strings.Cut(item, "=")
+		topic = strings.TrimSpace(topic)
+		compression = strings.ToLower(strings.TrimSpace(compression))
+		if !ok || topic == "" || !validCompression(compression) {
+			logger.Log.Warnf("%s 환경변수 항목 %q 파싱 실패. 건너뜁니다.", key, item)
+			continue
+		}
+		out[baseTopicName(topic)] = compression
+	}
+	return out
+}
+
+// describeCompression은 로그용으로 토픽별 압축 설정을 정렬된 문자열로 만듭니다.
+func describeCompression(m map[string]string) string {
+	items := make([]string, 0, len(m))
+	for topic, c := range m {
+		items = append(items, topic+"="+c)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
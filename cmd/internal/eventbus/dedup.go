@@ -0,0 +1,250 @@
+package eventbus
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// DedupStore는 처리 완료된 이벤트 키를 기록해 중복 처리를 막는 저장소입니다.
+// Kafka는 at-least-once 전달이므로, 부수 효과가 있는 핸들러(크레딧 지급 등)는
+// WithDedup 옵션으로 이 저장소를 연결해 같은 이벤트가 두 번 실행되지 않도록 합니다.
+type DedupStore interface {
+	// Seen은 key가 이미 처리 완료로 기록되어 있는지 확인합니다.
+	Seen(ctx context.Context, key string) (bool, error)
+	// MarkProcessed는 key를 처리 완료로 기록합니다.
+	MarkProcessed(ctx context.Context, key string) error
+}
+
+// WithDedup은 이미 처리한 Event.ID를 건너뛰는 중복 제거 레이어를 Subscribe에 추가합니다.
+// 키는 컨슈머 그룹과 이벤트 ID의 조합이므로, 하나의 저장소를 여러 구독이 공유해도 됩니다.
+func WithDedup(store DedupStore) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.dedup = store
+	}
+}
+
+// dedupKey는 컨슈머 그룹 단위로 이벤트를 구분하는 중복 제거 키를 만듭니다.
+func dedupKey(groupID string, evt Event) string {
+	return groupID + "/" + evt.ID
+}
+
+// dedupHandler는 이미 처리한 이벤트를 건너뛰고, 핸들러가 성공한 이벤트만 처리 완료로 기록합니다.
+// 저장소 조회에 실패하면 중복 실행을 피하기 위해 오류를 반환해 재시도 경로로 넘깁니다.
+func dedupHandler(store DedupStore, groupID string, handler EventHandler) EventHandler {
+	return func(ctx context.Context, evt Event) error {
+		if evt.ID == "" {
+			return handler(ctx, evt)
+		}
+		key := dedupKey(groupID, evt)
+		seen, err := store.Seen(ctx, key)
+		if err != nil {
+			return fmt.Errorf("중복 처리 여부 확인 실패: %w", err)
+		}
+		if seen {
+			logger.Log.Infof("이벤트 %s는 그룹 %s에서 이미 처리되었습니다. 건너뜁니다.", evt.ID, groupID)
+			return nil
+		}
+
+		if err := handler(ctx, evt); err != nil {
+			return err
+		}
+
+		if err := store.MarkProcessed(ctx, key); err != nil {
+			logger.Log.Warnf("이벤트 %s 처리 완료 기록 실패: %v", evt.ID, err)
+		}
+		return nil
+	}
+}
+
+// MemoryDedupStore는 최근 처리한 키를 최대 capacity개까지 보관하는 LRU 기반 DedupStore입니다.
+// 프로세스가 재시작되면 기록이 사라지므로, 재시작 간 중복 방지가 필요하면 FileDedupStore를 사용합니다.
+type MemoryDedupStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // 앞쪽이 가장 최근
+	items    map[string]*list.Element
+}
+
+// NewMemoryDedupStore는 capacity개까지 키를 보관하는 MemoryDedupStore를 생성합니다.
+// capacity가 0 이하이면 기본값 10000을 사용합니다.
+func NewMemoryDedupStore(capacity int) *MemoryDedupStore {
+	if capacity <= 0 {
+		capacity = defaultDedupCapacity
+	}
+	return &MemoryDedupStore{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+const defaultDedupCapacity = 10000
+
+func (s *MemoryDedupStore) Seen(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.items[key]
+	if ok {
+		s.order.MoveToFront(el)
+	}
+	return ok, nil
+}
+
+func (s *MemoryDedupStore) MarkProcessed(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addLocked(key)
+	return nil
+}
+
+// addLocked는 key를 가장 최근 항목으로 추가하고, 용량을 넘으면 가장 오래된 키를 제거합니다.
+// 제거된 키가 있으면 함께 반환합니다.
+func (s *MemoryDedupStore) addLocked(key string) (string, bool) {
+	if el, ok := s.items[key]; ok {
+		s.order.MoveToFront(el)
+		return "", false
+	}
+	s.items[key] = s.order.PushFront(key)
+	if s.order.Len() <= s.capacity {
+		return "", false
+	}
+	oldest := s.order.Back()
+	s.order.Remove(oldest)
+	evicted := oldest.Value.(string)
+	delete(s.items, evicted)
+	return evicted, true
+}
+
+// keys는 오래된 순서로 보관 중인 키 목록을 반환합니다.
+func (s *MemoryDedupStore) keys() []string {
+	out := make([]string, 0, s.order.Len())
+	for el := s.order.Back(); el != nil; el = el.Prev() {
+		out = append(out, el.Value.(string))
+	}
+	return out
+}
+
+// FileDedupStore는 처리 완료 키를 파일에 한 줄씩 추가 기록하는 DedupStore입니다.
+// 시작 시 파일을 읽어 최근 capacity개의 키를 메모리 LRU로 복원하며,
+// 파일이 보관 용량의 두 배를 넘으면 최근 키만 남기도록 다시 씁니다.
+type FileDedupStore struct {
+	mu    sync.Mutex
+	path  string
+	file  *os.File
+	lines int
+	mem   *MemoryDedupStore
+}
+
+// NewFileDedupStore는 path 파일을 열어(없으면 생성) FileDedupStore를 생성합니다.
+func NewFileDedupStore(path string, capacity int) (*FileDedupStore, error) {
+	mem := NewMemoryDedupStore(capacity)
+
+	lines := 0
+	if f, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			key := strings.TrimSpace(scanner.Text())
+			if key == "" {
+				continue
+			}
+			mem.addLocked(key)
+			lines++
+		}
+		scanErr := scanner.Err()
+		f.Close()
+		if scanErr != nil {
+			return nil, fmt.Errorf("중복 제거 파일 읽기 실패 %s: %w", path, scanErr)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("중복 제거 파일 열기 실패 %s: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("중복 제거 파일 열기 실패 %s: %w", path, err)
+	}
+
+	return &FileDedupStore{path: path, file: f, lines: lines, mem: mem}, nil
+}
+
+func (s *FileDedupStore) Seen(ctx context.Context, key string) (bool, error) {
+	return s.mem.Seen(ctx, key)
+}
+
+func (s *FileDedupStore) MarkProcessed(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if seen, _ := s.mem.Seen(ctx, key); seen {
+		return nil
+	}
+	if _, err := s.file.WriteString(key + "\n"); err != nil {
+		return fmt.Errorf("중복 제거 키 기록 실패: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("중복 제거 파일 동기화 실패: %w", err)
+	}
+	s.lines++
+	_ = s.mem.MarkProcessed(ctx, key)
+
+	if s.lines > 2*s.mem.capacity {
+		if err := s.compactLocked(); err != nil {
+			logger.Log.Warnf("중복 제거 파일 정리 실패 %s: %v", s.path, err)
+		}
+	}
+	return nil
+}
+
+// compactLocked는 메모리에 남아 있는 최근 키만으로 파일을 다시 씁니다.
+func (s *FileDedupStore) compactLocked() error {
+	s.mem.mu.Lock()
+	keys := s.mem.keys()
+	s.mem.mu.Unlock()
+
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	for _, key := range keys {
+		w.WriteString(key + "\n")
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	s.file.Close()
+	s.file = f
+	s.lines = len(keys)
+	return nil
+}
+
+// Close는 기록 파일을 닫습니다.
+func (s *FileDedupStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
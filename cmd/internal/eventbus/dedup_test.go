@@ -0,0 +1,109 @@
+package eventbus
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewEventIDIsUniqueAndOrdered(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := make(map[string]bool)
+	prev := ""
+	for i := 0; i < 10000; i++ {
+		id := NewEventID()
+		if !pattern.MatchString(id) {
+			t.Fatalf("expected UUIDv7 format, got %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate id %q", id)
+		}
+		if id <= prev {
+			t.Fatalf("expected monotonic ids, got %q after %q", id, prev)
+		}
+		seen[id] = true
+		prev = id
+	}
+}
+
+func TestMemoryDedupStoreEvictsOldestKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDedupStore(2)
+	store.MarkProcessed(ctx, "a")
+	store.MarkProcessed(ctx, "b")
+	store.Seen(ctx, "a") // a를 최근 항목으로 갱신
+	store.MarkProcessed(ctx, "c")
+
+	if seen, _ := store.Seen(ctx, "b"); seen {
+		t.Fatalf("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if seen, _ := store.Seen(ctx, key); !seen {
+			t.Fatalf("expected %s to be kept", key)
+		}
+	}
+}
+
+func TestFileDedupStoreSurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dedup.log")
+
+	store, err := NewFileDedupStore(path, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.MarkProcessed(ctx, "group/evt-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store.Close()
+
+	reopened, err := NewFileDedupStore(path, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	if seen, _ := reopened.Seen(ctx, "group/evt-1"); !seen {
+		t.Fatalf("expected key to be restored from file")
+	}
+}
+
+func TestFileDedupStoreCompactsFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dedup.log")
+
+	store, err := NewFileDedupStore(path, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer store.Close()
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		if err := store.MarkProcessed(ctx, key); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if store.lines != 2 {
+		t.Fatalf("expected file to be compacted to 2 lines, got %d", store.lines)
+	}
+}
+
+func TestDedupHandlerSkipsProcessedEvents(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	handler := dedupHandler(NewMemoryDedupStore(10), "credit-group", func(ctx context.Context, evt Event) error {
+		calls.Add(1)
+		return nil
+	})
+
+	evt := Event{ID: "evt-1"}
+	for i := 0; i < 3; i++ {
+		if err := handler(ctx, evt); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected handler to run once, got %d", got)
+	}
+}
@@ -0,0 +1,149 @@
+package eventbus
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// delayScheduler는 아직 재주입 시각(readyAt)이 되지 않은 재시도 메시지를 파티션별로 보관하는 타이머 힙입니다.
+// 재주입기는 대기 중인 메시지가 있는 파티션을 Pause 해 두고, readyAt이 가장 이른 파티션부터 꺼내
+// 재주입한 뒤 Resume 합니다. 파티션마다 독립적으로 기다리므로 한 파티션의 긴 지연이
+// 다른 파티션의 재주입을 막지 않으며, 대기 중에 Seek를 반복하지 않습니다.
+type delayScheduler struct {
+	clock       Clock
+	queue       delayQueue
+	byPartition map[partitionKey]*delayedPartition
+}
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+// delayedPartition은 Pause 된 파티션과 그 파티션에서 재주입을 기다리는 첫 메시지입니다.
+type delayedPartition struct {
+	key     partitionKey
+	msg     *kafka.Message
+	readyAt time.Time
+	// skipped는 Pause가 적용되기 전에 뒤쪽 메시지를 이미 받아 버렸는지 여부입니다.
+	// true이면 재개할 때 보관 중인 메시지 다음 오프셋으로 Seek 해야 합니다.
+	skipped bool
+	index   int
+}
+
+func newDelayScheduler(clock Clock) *delayScheduler {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &delayScheduler{clock: clock, byPartition: make(map[partitionKey]*delayedPartition)}
+}
+
+func messagePartitionKey(msg *kafka.Message) partitionKey {
+	return partitionKey{topic: *msg.TopicPartition.Topic, partition: msg.TopicPartition.Partition}
+}
+
+// Defer는 msg를 readyAt까지 보관합니다. 호출자는 해당 파티션을 Pause 해야 합니다.
+func (s *delayScheduler) Defer(msg *kafka.Message, readyAt time.Time) {
+	key := messagePartitionKey(msg)
+	if d, ok := s.byPartition[key]; ok {
+		d.msg = msg
+		d.readyAt = readyAt
+		heap.Fix(&s.queue, d.index)
+		return
+	}
+	s.requeue(&delayedPartition{key: key, msg: msg, readyAt: readyAt})
+}
+
+// requeue는 Due로 꺼낸 항목을 readyAt에 다시 꺼내도록 힙에 되돌립니다.
+func (s *delayScheduler) requeue(d *delayedPartition) {
+	s.byPartition[d.key] = d
+	heap.Push(&s.queue, d)
+}
+
+// Holds는 msg의 파티션이 대기 중이면 true를 반환합니다.
+// 대기 중인 파티션에서 받은 메시지는 처리하지 않고 버리며, 재개 시 Seek로 다시 읽습니다.
+func (s *delayScheduler) Holds(msg *kafka.Message) bool {
+	d, ok := s.byPartition[messagePartitionKey(msg)]
+	if !ok {
+		return false
+	}
+	if msg.TopicPartition.Offset != d.msg.TopicPartition.Offset {
+		d.skipped = true
+	}
+	return true
+}
+
+// Due는 현재 시각 기준으로 readyAt이 지난 항목을 readyAt 순서로 꺼냅니다.
+func (s *delayScheduler) Due() []*delayedPartition {
+	now := s.clock.Now()
+	var due []*delayedPartition
+	for s.queue.Len() > 0 && !s.queue[0].readyAt.After(now) {
+		d := heap.Pop(&s.queue).(*delayedPartition)
+		delete(s.byPartition, d.key)
+		due = append(due, d)
+	}
+	return due
+}
+
+// Wait는 다음 항목의 readyAt까지 남은 시간을 max 이하로 잘라 반환합니다.
+func (s *delayScheduler) Wait(max time.Duration) time.Duration {
+	if s.queue.Len() == 0 {
+		return max
+	}
+	wait := s.queue[0].readyAt.Sub(s.clock.Now())
+	if wait < 0 {
+		return 0
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
+
+// Remove는 리밸런스로 회수된 파티션의 대기 항목을 버립니다.
+// 커밋하지 않은 메시지이므로 새로 파티션을 할당받은 컨슈머가 다시 읽습니다.
+func (s *delayScheduler) Remove(topic string, partition int32) {
+	key := partitionKey{topic: topic, partition: partition}
+	d, ok := s.byPartition[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&s.queue, d.index)
+	delete(s.byPartition, key)
+}
+
+// Len은 대기 중인 파티션 수를 반환합니다.
+func (s *delayScheduler) Len() int {
+	return s.queue.Len()
+}
+
+// delayQueue는 readyAt 기준 최소 힙입니다.
+type delayQueue []*delayedPartition
+
+func (q delayQueue) Len() int { return len(q) }
+
+func (q delayQueue) Less(i, j int) bool { return q[i].readyAt.Before(q[j].readyAt) }
+
+func (q delayQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *delayQueue) Push(x any) {
+	d := x.(*delayedPartition)
+	d.index = len(*q)
+	*q = append(*q, d)
+}
+
+func (q *delayQueue) Pop() any {
+	old := *q
+	n := len(old)
+	d := old[n-1]
+	old[n-1] = nil
+	d.index = -1
+	*q = old[:n-1]
+	return d
+}
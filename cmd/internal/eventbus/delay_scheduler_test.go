@@ -0,0 +1,94 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func newTestRetryMessage(topic string, partition int32, offset int64) *kafka.Message {
+	msg := newTestKafkaMessage(topic, partition, "post-1")
+	msg.TopicPartition.Offset = kafka.Offset(offset)
+	return msg
+}
+
+func TestDelaySchedulerReleasesPartitionsIndependently(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	sched := newDelayScheduler(clock)
+
+	slow := newTestRetryMessage("t.retry.5", 0, 10)
+	fast := newTestRetryMessage("t.retry.1", 0, 3)
+	sched.Defer(slow, start.Add(time.Hour))
+	sched.Defer(fast, start.Add(time.Second))
+
+	if got := sched.Wait(100 * time.Millisecond); got != 100*time.Millisecond {
+		t.Fatalf("expected wait capped at 100ms, got %v", got)
+	}
+	if due := sched.Due(); len(due) != 0 {
+		t.Fatalf("expected nothing due yet, got %d", len(due))
+	}
+
+	clock.Advance(900 * time.Millisecond)
+	if got := sched.Wait(time.Minute); got != 100*time.Millisecond {
+		t.Fatalf("expected 100ms until fast partition, got %v", got)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	due := sched.Due()
+	if len(due) != 1 || due[0].msg != fast {
+		t.Fatalf("expected only fast partition due, got %+v", due)
+	}
+	if sched.Holds(newTestRetryMessage("t.retry.1", 0, 4)) {
+		t.Fatalf("released partition should not be held")
+	}
+	if !sched.Holds(newTestRetryMessage("t.retry.5", 0, 11)) {
+		t.Fatalf("slow partition should still be held")
+	}
+
+	clock.Advance(time.Hour)
+	due = sched.Due()
+	if len(due) != 1 || due[0].msg != slow {
+		t.Fatalf("expected slow partition due, got %+v", due)
+	}
+	if !due[0].skipped {
+		t.Fatalf("expected skipped flag after receiving a later offset while paused")
+	}
+	if sched.Len() != 0 {
+		t.Fatalf("expected empty scheduler, got %d", sched.Len())
+	}
+}
+
+func TestDelaySchedulerRemoveAndRequeue(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	sched := newDelayScheduler(clock)
+
+	a := newTestRetryMessage("t.retry.1", 0, 1)
+	b := newTestRetryMessage("t.retry.1", 1, 1)
+	sched.Defer(a, start.Add(time.Second))
+	sched.Defer(b, start.Add(2*time.Second))
+
+	sched.Remove("t.retry.1", 0)
+	if sched.Holds(a) {
+		t.Fatalf("removed partition should not be held")
+	}
+
+	clock.Advance(2 * time.Second)
+	due := sched.Due()
+	if len(due) != 1 || due[0].msg != b {
+		t.Fatalf("expected only partition 1 due, got %+v", due)
+	}
+
+	// 재주입 실패 시 같은 항목을 다시 예약한다.
+	due[0].readyAt = clock.Now().Add(time.Second)
+	sched.requeue(due[0])
+	if len(sched.Due()) != 0 {
+		t.Fatalf("requeued entry should wait for its new readyAt")
+	}
+	clock.Advance(time.Second)
+	if due := sched.Due(); len(due) != 1 || due[0].msg != b {
+		t.Fatalf("expected requeued entry due, got %+v", due)
+	}
+}
@@ -0,0 +1,453 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// DLQRecord는 DLQ 토픽에서 읽은 단일 이벤트와 그 위치입니다.
+type DLQRecord struct {
+	Topic     string         `json:"topic"`
+	Partition int32          `json:"partition"`
+	Offset    int64          `json:"offset"`
+	Timestamp time.Time      `json:"timestamp"`
+	Event     Event          `json:"event"`
+	Headers   []kafka.Header `json:"-"`
+	// DecodeError는 봉투를 해석하지 못한 레코드의 오류입니다. 이때 Event는 비어 있고 재처리할 수 없습니다.
+	DecodeError string `json:"decode_error,omitempty"`
+}
+
+// Key는 DLQ 내에서 레코드를 유일하게 식별하는 키(토픽/파티션/오프셋)입니다.
+// 같은 이벤트가 재처리 후 다시 DLQ에 들어오면 다른 키를 가지므로 재차 재처리할 수 있습니다.
+func (r DLQRecord) Key() string {
+	return fmt.Sprintf("%s/%d/%d", r.Topic, r.Partition, r.Offset)
+}
+
+// DLQFilter는 DLQ 레코드 조회 조건입니다. 비어 있는 필드는 조건에서 제외합니다.
+type DLQFilter struct {
+	EventID       string
+	ErrorContains string
+	Since         time.Time // DLQ 적재 시각(메시지 타임스탬프) 하한 (포함)
+	Until         time.Time // DLQ 적재 시각 상한 (제외)
+	Limit         int
+}
+
+// Match는 레코드가 필터 조건을 만족하는지 확인합니다.
+// ErrorContains는 LastError와 DLQ 봉투의 시도별 오류 이력 모두에서 대소문자 구분 없이 찾습니다.
+func (f DLQFilter) Match(r DLQRecord) bool {
+	if f.EventID != "" && r.Event.ID != f.EventID {
+		return false
+	}
+	if !f.Since.IsZero() && r.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !r.Timestamp.Before(f.Until) {
+		return false
+	}
+	if f.ErrorContains != "" {
+		needle := strings.ToLower(f.ErrorContains)
+		found := strings.Contains(strings.ToLower(r.Event.LastError), needle) ||
+			strings.Contains(strings.ToLower(r.DecodeError), needle)
+		if !found && r.Event.DLQ != nil {
+			for _, failure := range r.Event.DLQ.Errors {
+				if strings.Contains(strings.ToLower(failure.Error), needle) {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadDLQ는 토픽의 DLQ를 처음부터 호출 시점의 끝(high watermark)까지 읽어 필터에 맞는 레코드를 반환합니다.
+// 해석할 수 없는 레코드도 DecodeError를 채워 반환하므로, 조회 결과에서 빠진 레코드가 삭제되는 일은 없습니다.
+// 컨슈머 그룹 오프셋을 커밋하지 않으므로 다른 컨슈머에 영향을 주지 않습니다.
+func ReadDLQ(ctx context.Context, brokers string, topic Topic, filter DLQFilter) ([]DLQRecord, error) {
+	dlqTopic := topic.DLQ()
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  brokers,
+		"group.id":           "tech-letter-dlq-reader",
+		"enable.auto.commit": false,
+		// 마지막 오프셋이 비어 있는(압축, 트랜잭션 마커, 보존 기간 만료) 파티션도 끝을 알 수 있게 EOF 이벤트를 받는다.
+		"enable.partition.eof": true,
+		"auto.offset.reset":    "earliest",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kafka Consumer 생성 실패: %w", err)
+	}
+	defer c.Close()
+
+	md, err := c.GetMetadata(&dlqTopic, false, 10000)
+	if err != nil {
+		return nil, fmt.Errorf("DLQ %s 메타데이터 조회 실패: %w", dlqTopic, err)
+	}
+	tmd, ok := md.Topics[dlqTopic]
+	if !ok || tmd.Error.Code() == kafka.ErrUnknownTopicOrPart {
+		return nil, nil
+	}
+
+	// 파티션별로 [low, high) 구간만 읽는다.
+	remaining := make(map[int32]int64)
+	var assignments []kafka.TopicPartition
+	for _, p := range tmd.Partitions {
+		low, high, err := c.QueryWatermarkOffsets(dlqTopic, p.ID, 10000)
+		if err != nil {
+			return nil, fmt.Errorf("DLQ %s[%d] 워터마크 조회 실패: %w", dlqTopic, p.ID, err)
+		}
+		if high <= low {
+			continue
+		}
+		remaining[p.ID] = high
+		assignments = append(assignments, kafka.TopicPartition{Topic: &dlqTopic, Partition: p.ID, Offset: kafka.Offset(low)})
+	}
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+	if err := c.Assign(assignments); err != nil {
+		return nil, fmt.Errorf("DLQ %s 파티션 할당 실패: %w", dlqTopic, err)
+	}
+
+	records, err := pollDLQ(ctx, c, dlqTopic, remaining, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Timestamp.Equal(records[j].Timestamp) {
+			return records[i].Timestamp.Before(records[j].Timestamp)
+		}
+		if records[i].Partition != records[j].Partition {
+			return records[i].Partition < records[j].Partition
+		}
+		return records[i].Offset < records[j].Offset
+	})
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
+// dlqPoller는 pollDLQ가 사용하는 컨슈머 기능입니다. *kafka.Consumer가 구현합니다.
+type dlqPoller interface {
+	Poll(timeoutMs int) kafka.Event
+}
+
+// pollDLQ는 remaining의 파티션별 끝(high watermark)까지 읽어 필터에 맞는 레코드를 반환합니다.
+// 끝 직전 오프셋이 비어 있어 메시지가 오지 않아도 PartitionEOF를 받으면 그 파티션을 끝냅니다.
+func pollDLQ(ctx context.Context, c dlqPoller, dlqTopic string, remaining map[int32]int64, filter DLQFilter) ([]DLQRecord, error) {
+	var records []DLQRecord
+	for len(remaining) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var msg *kafka.Message
+		switch e := c.Poll(500).(type) {
+		case *kafka.Message:
+			msg = e
+		case kafka.PartitionEOF:
+			delete(remaining, e.Partition)
+			continue
+		case kafka.Error:
+			return nil, fmt.Errorf("DLQ %s 읽기 실패: %w", dlqTopic, e)
+		default:
+			continue
+		}
+		if msg.TopicPartition.Error != nil {
+			return nil, fmt.Errorf("DLQ %s 읽기 실패: %w", dlqTopic, msg.TopicPartition.Error)
+		}
+
+		tp := msg.TopicPartition
+		high, ok := remaining[tp.Partition]
+		if !ok || int64(tp.Offset) >= high {
+			// 이미 끝낸 파티션이거나 조회 이후 새로 들어온 레코드
+			continue
+		}
+		if int64(tp.Offset)+1 >= high {
+			delete(remaining, tp.Partition)
+		}
+
+		record := DLQRecord{
+			Topic:     dlqTopic,
+			Partition: tp.Partition,
+			Offset:    int64(tp.Offset),
+			Timestamp: msg.Timestamp,
+			Headers:   msg.Headers,
+		}
+		var err error
+		if record.Event, err = DecodeEnvelope(msg.Value, msg.Headers); err != nil {
+			record.DecodeError = err.Error()
+		}
+		if filter.Match(record) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// ReplayEntry는 DLQ 레코드를 기본 토픽으로 재처리한 기록입니다.
+type ReplayEntry struct {
+	Key        string    `json:"key"`
+	EventID    string    `json:"event_id"`
+	Topic      string    `json:"topic"`
+	ReplayedAt time.Time `json:"replayed_at"`
+}
+
+// ReplayLedger는 재처리 기록 저장소입니다. 같은 DLQ 레코드를 실수로 두 번 재처리하지 않도록 사용합니다.
+type ReplayLedger interface {
+	Lookup(key string) (ReplayEntry, bool, error)
+	Record(entry ReplayEntry) error
+}
+
+// ErrAlreadyReplayed는 이미 재처리된 DLQ 레코드를 다시 재처리하려 할 때 반환되는 오류입니다.
+var ErrAlreadyReplayed = errors.New("이미 재처리된 DLQ 레코드")
+
+// ErrUndecodableDLQRecord는 봉투를 해석하지 못한(DecodeError가 있는) DLQ 레코드를 재처리하려 할 때 반환되는 오류입니다.
+var ErrUndecodableDLQRecord = errors.New("해석할 수 없는 DLQ 레코드")
+
+// ReplayDLQRecord는 DLQ 레코드의 이벤트를 재시도 정보를 초기화하여 기본 토픽으로 다시 발행하고 기록합니다.
+// 원본 트레이싱 헤더를 이어받으며, force가 false이면 이미 재처리된 레코드는 ErrAlreadyReplayed를 반환합니다.
+// 페이로드가 블롭으로 분리된 이벤트는 레코드 위치를 붙인 사본 블롭을 만들어 발행하므로,
+// 재처리가 끝난 레코드의 원본 블롭은 DeleteDLQBlobs로 지울 수 있습니다.
+func ReplayDLQRecord(ctx context.Context, bus EventBus, topic Topic, record DLQRecord, ledger ReplayLedger, force bool) error {
+	if record.DecodeError != "" {
+		return fmt.Errorf("%w %s: %s", ErrUndecodableDLQRecord, record.Key(), record.DecodeError)
+	}
+	if ledger != nil && !force {
+		if entry, ok, err := ledger.Lookup(record.Key()); err != nil {
+			return fmt.Errorf("재처리 기록 조회 실패: %w", err)
+		} else if ok {
+			return fmt.Errorf("%w: %s (%s)", ErrAlreadyReplayed, record.Key(), entry.ReplayedAt.Format(time.RFC3339))
+		}
+	}
+
+	evt := record.Event
+	evt.Retry = 0
+	evt.LastError = ""
+	evt.Failures = nil
+	evt.DLQ = nil
+
+	claimCheck := busClaimCheck(bus)
+	if evt.PayloadRef != nil && claimCheck != nil {
+		suffix := fmt.Sprintf("replay-%d-%d", record.Partition, record.Offset)
+		ref, err := claimCheck.copyBlob(ctx, evt, blobKey(topic.Base(), evt.ID, evt.PayloadRef.SHA256, suffix))
+		if err != nil {
+			return fmt.Errorf("이벤트 %s 재처리 블롭 준비 실패: %w", evt.ID, err)
+		}
+		evt.PayloadRef = ref
+	}
+
+	publishCtx := contextWithTraceHeaders(ctx, record.Headers)
+	if err := bus.Publish(publishCtx, topic.Base(), evt); err != nil {
+		if evt.PayloadRef != nil && evt.PayloadRef != record.Event.PayloadRef {
+			if delErr := claimCheck.Store.Delete(context.WithoutCancel(ctx), evt.PayloadRef.Key); delErr != nil {
+				logger.Log.Warnf("재처리 사본 블롭 %s 삭제 실패: %v", evt.PayloadRef.Key, delErr)
+			}
+		}
+		return fmt.Errorf("이벤트 %s 재처리 발행 실패: %w", evt.ID, err)
+	}
+
+	if ledger != nil {
+		if err := ledger.Record(ReplayEntry{
+			Key:        record.Key(),
+			EventID:    evt.ID,
+			Topic:      topic.Base(),
+			ReplayedAt: time.Now(),
+		}); err != nil {
+			return fmt.Errorf("재처리 기록 저장 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// busClaimCheck는 bus가 블롭을 읽고 쓸 때 쓰는 클레임 체크 설정을 반환합니다. 없으면 기본 설정(SetDefaultClaimCheck)을 사용합니다.
+func busClaimCheck(bus EventBus) *ClaimCheck {
+	var c *ClaimCheck
+	switch b := bus.(type) {
+	case *KafkaEventBus:
+		c = b.ClaimCheck
+	case *MemoryEventBus:
+		c = b.ClaimCheck
+	}
+	if c == nil || c.Store == nil {
+		c = defaultClaimCheck.Load()
+	}
+	if c == nil || c.Store == nil {
+		return nil
+	}
+	return c
+}
+
+// DeleteDLQBlobs는 released 레코드가 참조하는 블롭 가운데 kept 레코드가 참조하지 않는 것을 삭제하고, 삭제한 수를 반환합니다.
+// 재처리를 마친 레코드나 PurgeDLQ로 잘라낸 레코드의 블롭을 정리할 때 사용하며, kept에는 DLQ에 남아 다시 읽힐 레코드를 넘깁니다.
+// 삭제에 실패한 블롭은 건너뛰고 오류를 모아 반환합니다.
+func DeleteDLQBlobs(ctx context.Context, c *ClaimCheck, released, kept []DLQRecord) (int, error) {
+	if c == nil || c.Store == nil {
+		return 0, nil
+	}
+	inUse := make(map[string]bool)
+	for _, r := range kept {
+		if r.Event.PayloadRef != nil {
+			inUse[r.Event.PayloadRef.Key] = true
+		}
+	}
+	deleted := 0
+	var errs []error
+	for _, r := range released {
+		ref := r.Event.PayloadRef
+		if ref == nil || inUse[ref.Key] {
+			continue
+		}
+		inUse[ref.Key] = true // 같은 블롭을 두 번 지우지 않는다.
+		if err := c.Store.Delete(ctx, ref.Key); err != nil {
+			errs = append(errs, fmt.Errorf("%s 블롭 %s 삭제 실패: %w", r.Key(), ref.Key, err))
+			continue
+		}
+		deleted++
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// PurgeDLQ는 DLQ 파티션별로 지정한 오프셋 이전(제외)의 레코드를 삭제합니다.
+// Kafka는 개별 메시지 삭제를 지원하지 않으므로 파티션 앞부분을 잘라내는 방식입니다.
+func PurgeDLQ(ctx context.Context, brokers string, topic Topic, before map[int32]int64) error {
+	if len(before) == 0 {
+		return nil
+	}
+	admin, err := kafka.NewAdminClient(&kafka.ConfigMap{
+		"bootstrap.servers": brokers,
+	})
+	if err != nil {
+		return fmt.Errorf("AdminClient 생성 실패: %w", err)
+	}
+	defer admin.Close()
+
+	dlqTopic := topic.DLQ()
+	partitions := make([]kafka.TopicPartition, 0, len(before))
+	for partition, offset := range before {
+		partitions = append(partitions, kafka.TopicPartition{Topic: &dlqTopic, Partition: partition, Offset: kafka.Offset(offset)})
+	}
+
+	results, err := admin.DeleteRecords(ctx, partitions)
+	if err != nil {
+		return fmt.Errorf("DLQ %s 레코드 삭제 요청 실패: %w", dlqTopic, err)
+	}
+	for _, r := range results.DeleteRecordsResults {
+		if r.TopicPartition.Error != nil {
+			return fmt.Errorf("DLQ %s[%d] 레코드 삭제 실패: %w", dlqTopic, r.TopicPartition.Partition, r.TopicPartition.Error)
+		}
+	}
+	return nil
+}
+
+// ReplayedPrefix는 파티션별로 앞에서부터 연속해서 재처리된 레코드 구간의 끝(다음 오프셋)을 계산합니다.
+// records는 DLQ 전체(필터 없이 읽은 결과)여야 하며, 결과는 PurgeDLQ에 그대로 전달할 수 있습니다.
+// 해석하지 못한 레코드나 오프셋이 비는 곳에서 멈추므로, 보여 주지 않은 레코드는 삭제 범위에 들어가지 않습니다.
+func ReplayedPrefix(records []DLQRecord, ledger ReplayLedger) (map[int32]int64, error) {
+	byPartition := make(map[int32][]DLQRecord)
+	for _, r := range records {
+		byPartition[r.Partition] = append(byPartition[r.Partition], r)
+	}
+
+	out := make(map[int32]int64)
+	for partition, recs := range byPartition {
+		sort.Slice(recs, func(i, j int) bool { return recs[i].Offset < recs[j].Offset })
+		for i, r := range recs {
+			if r.DecodeError != "" || (i > 0 && r.Offset != recs[i-1].Offset+1) {
+				break
+			}
+			_, ok, err := ledger.Lookup(r.Key())
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			out[partition] = r.Offset + 1
+		}
+	}
+	return out, nil
+}
+
+// FileReplayLedger는 재처리 기록을 JSON Lines 파일에 추가 기록하는 ReplayLedger입니다.
+type FileReplayLedger struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]ReplayEntry
+}
+
+// NewFileReplayLedger는 path 파일에서 기존 재처리 기록을 읽어 FileReplayLedger를 생성합니다.
+func NewFileReplayLedger(path string) (*FileReplayLedger, error) {
+	l := &FileReplayLedger{path: path, entries: make(map[string]ReplayEntry)}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("재처리 기록 파일 열기 실패 %s: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry ReplayEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("재처리 기록 파싱 실패 %s: %w", path, err)
+		}
+		l.entries[entry.Key] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("재처리 기록 파일 읽기 실패 %s: %w", path, err)
+	}
+	return l, nil
+}
+
+func (l *FileReplayLedger) Lookup(key string) (ReplayEntry, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry, ok := l.entries[key]
+	return entry, ok, nil
+}
+
+func (l *FileReplayLedger) Record(entry ReplayEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	l.entries[entry.Key] = entry
+	return nil
+}
@@ -0,0 +1,225 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestDLQFilterMatchesErrorHistoryAndTimeRange(t *testing.T) {
+	at := time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC)
+	record := DLQRecord{
+		Timestamp: at,
+		Event: Event{
+			ID:        "evt-1",
+			LastError: "context deadline exceeded",
+			DLQ: &DLQEnvelope{Errors: []FailureRecord{
+				{Error: "429 Too Many Requests"},
+				{Error: "context deadline exceeded"},
+			}},
+		},
+	}
+
+	cases := []struct {
+		name   string
+		filter DLQFilter
+		want   bool
+	}{
+		{name: "empty filter", filter: DLQFilter{}, want: true},
+		{name: "event id", filter: DLQFilter{EventID: "evt-2"}, want: false},
+		{name: "error in history", filter: DLQFilter{ErrorContains: "too many"}, want: true},
+		{name: "error missing", filter: DLQFilter{ErrorContains: "not found"}, want: false},
+		{name: "inside range", filter: DLQFilter{Since: at, Until: at.Add(time.Hour)}, want: true},
+		{name: "until is exclusive", filter: DLQFilter{Until: at}, want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.Match(record); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestReplayDLQRecordResetsRetryAndRecordsLedger(t *testing.T) {
+	ctx := context.Background()
+	bus := NewMemoryEventBus(NewManualClock(time.Unix(0, 0)))
+	defer bus.Close()
+
+	ledger, err := NewFileReplayLedger(filepath.Join(t.TempDir(), "replays.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	topic := NewTopic("test.dlq.replay")
+	record := DLQRecord{
+		Topic:  topic.DLQ(),
+		Offset: 3,
+		Event: Event{
+			ID:        "evt-1",
+			Retry:     5,
+			MaxRetry:  5,
+			LastError: "boom",
+			DLQ:       &DLQEnvelope{Reason: DLQReasonMaxRetryExceeded},
+		},
+	}
+
+	if err := ReplayDLQRecord(ctx, bus, topic, record, ledger, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	replayed := bus.Events(topic.Base())
+	if len(replayed) != 1 {
+		t.Fatalf("expected 1 replayed event, got %d", len(replayed))
+	}
+	if got := replayed[0]; got.Retry != 0 || got.LastError != "" || got.DLQ != nil || got.MaxRetry != 5 {
+		t.Fatalf("expected retry state to be reset, got %+v", got)
+	}
+
+	err = ReplayDLQRecord(ctx, bus, topic, record, ledger, false)
+	if !errors.Is(err, ErrAlreadyReplayed) {
+		t.Fatalf("expected ErrAlreadyReplayed, got %v", err)
+	}
+	if err := ReplayDLQRecord(ctx, bus, topic, record, ledger, true); err != nil {
+		t.Fatalf("expected forced replay to succeed, got %v", err)
+	}
+
+	// 파일에서 다시 읽어도 기록이 유지되어야 한다.
+	reopened, err := NewFileReplayLedger(ledger.path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok, _ := reopened.Lookup(record.Key()); !ok {
+		t.Fatalf("expected replay to be persisted")
+	}
+}
+
+func TestReplayedPrefixStopsAtFirstUnreplayedRecord(t *testing.T) {
+	ledger, err := NewFileReplayLedger(filepath.Join(t.TempDir(), "replays.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := []DLQRecord{
+		{Topic: "t.dlq", Partition: 0, Offset: 0},
+		{Topic: "t.dlq", Partition: 0, Offset: 1},
+		{Topic: "t.dlq", Partition: 0, Offset: 2},
+		{Topic: "t.dlq", Partition: 1, Offset: 0},
+	}
+	for _, r := range []DLQRecord{records[0], records[2], records[3]} {
+		if err := ledger.Record(ReplayEntry{Key: r.Key()}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	before, err := ReplayedPrefix(records, ledger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if before[0] != 1 {
+		t.Fatalf("expected partition 0 purge before offset 1, got %d", before[0])
+	}
+	if before[1] != 1 {
+		t.Fatalf("expected partition 1 purge before offset 1, got %d", before[1])
+	}
+}
+
+func TestReplayedPrefixStopsAtUndecodableRecordOrGap(t *testing.T) {
+	ledger, err := NewFileReplayLedger(filepath.Join(t.TempDir(), "replays.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := []DLQRecord{
+		{Topic: "t.dlq", Partition: 0, Offset: 0},
+		{Topic: "t.dlq", Partition: 0, Offset: 1, DecodeError: "unknown envelope"},
+		{Topic: "t.dlq", Partition: 0, Offset: 2},
+		{Topic: "t.dlq", Partition: 1, Offset: 0},
+		{Topic: "t.dlq", Partition: 1, Offset: 2},
+	}
+	for _, r := range records {
+		if err := ledger.Record(ReplayEntry{Key: r.Key()}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	before, err := ReplayedPrefix(records, ledger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if before[0] != 1 || before[1] != 1 {
+		t.Fatalf("expected purge to stop before undecodable record and gap, got %v", before)
+	}
+
+	err = ReplayDLQRecord(context.Background(), NewMemoryEventBus(nil), NewTopic("t"), records[1], nil, true)
+	if !errors.Is(err, ErrUndecodableDLQRecord) {
+		t.Fatalf("expected ErrUndecodableDLQRecord, got %v", err)
+	}
+}
+
+func TestReadDLQReturnsUndecodableRecords(t *testing.T) {
+	topic := NewTopic("tech-letter.test.dlqread")
+	bus := newMockKafkaBus(t, topic.DLQ())
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	dlqTopic := topic.DLQ()
+	delivery := make(chan kafka.Event, 1)
+	if err := bus.Producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &dlqTopic, Partition: kafka.PartitionAny},
+		Value:          []byte("not an envelope"),
+	}, delivery); err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	if m := (<-delivery).(*kafka.Message); m.TopicPartition.Error != nil {
+		t.Fatalf("produce: %v", m.TopicPartition.Error)
+	}
+	publishTestEvents(t, bus, topic.DLQ(), "evt-1")
+
+	records, err := ReadDLQ(ctx, bus.Brokers, topic, DLQFilter{})
+	if err != nil {
+		t.Fatalf("read dlq: %v", err)
+	}
+	if len(records) != 2 || records[0].DecodeError == "" || records[1].DecodeError != "" || records[1].Event.ID != "evt-1" {
+		t.Fatalf("expected undecodable record followed by evt-1, got %+v", records)
+	}
+}
+
+type fakeDLQPoller struct {
+	events []kafka.Event
+}
+
+func (p *fakeDLQPoller) Poll(int) kafka.Event {
+	if len(p.events) == 0 {
+		return nil
+	}
+	e := p.events[0]
+	p.events = p.events[1:]
+	return e
+}
+
+func TestPollDLQFinishesPartitionWhoseLastOffsetIsAGap(t *testing.T) {
+	dlqTopic := NewTopic("tech-letter.test.dlqgap").DLQ()
+	value, err := json.Marshal(Event{ID: "evt-1"})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	// high watermark는 3이지만 오프셋 2는 트랜잭션 커밋 마커라 메시지로 오지 않는다.
+	poller := &fakeDLQPoller{events: []kafka.Event{
+		&kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &dlqTopic, Partition: 0, Offset: 1}, Value: value},
+		kafka.PartitionEOF{Topic: &dlqTopic, Partition: 0, Offset: 3},
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	records, err := pollDLQ(ctx, poller, dlqTopic, map[int32]int64{0: 3}, DLQFilter{})
+	if err != nil {
+		t.Fatalf("expected the partition to finish at EOF, got %v", err)
+	}
+	if len(records) != 1 || records[0].Event.ID != "evt-1" || records[0].Offset != 1 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+}
@@ -0,0 +1,451 @@
+package eventbus
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// EnvelopeCodec은 Event를 Kafka 메시지 값과 헤더로 직렬화하는 방식(봉투 형식)입니다.
+// 발행 시에는 버스에 지정된 코덱으로 인코딩하고, 구독 시에는 DecodeEnvelope가 메시지 헤더를 보고
+// 형식을 자동으로 판별하므로 코덱을 바꾸는 동안 한 토픽에 여러 형식이 섞여 있어도 처리할 수 있습니다.
+type EnvelopeCodec interface {
+	// Name은 KAFKA_EVENT_ENVELOPE 환경변수에 쓰는 코덱 이름입니다.
+	Name() string
+	Encode(evt Event) (value []byte, headers []kafka.Header, err error)
+	Decode(value []byte, headers []kafka.Header) (Event, error)
+}
+
+// 코덱 이름
+const (
+	EnvelopeJSON                   = "json"
+	EnvelopeCloudEventsBinary      = "cloudevents-binary"
+	EnvelopeCloudEventsStructured  = "cloudevents-structured"
+	defaultCloudEventsSource       = "/tech-letter"
+	defaultCloudEventsType         = "techletter.event"
+	cloudEventsSpecVersion         = "1.0"
+	cloudEventsHeaderPrefix        = "ce_"
+	headerContentType              = "content-type"
+	contentTypeJSON                = "application/json"
+	contentTypeCloudEventsJSON     = "application/cloudevents+json"
+	contentTypeCloudEventsJSONUTF8 = contentTypeCloudEventsJSON + "; charset=UTF-8"
+)
+
+// CloudEvents 확장 속성 이름입니다. 재시도 메타데이터를 표준 속성과 겹치지 않게 담습니다.
+// 사양에 따라 소문자 영문/숫자만 사용합니다.
+const (
+	ceExtRetry         = "retry"
+	ceExtMaxRetry      = "maxretry"
+	ceExtLastError     = "lasterror"
+	ceExtSchemaVersion = "schemaversion"
+	ceExtFailures      = "failures"
+	ceExtDLQ           = "dlq"
+	ceExtPayloadRef    = "payloadref"
+)
+
+// ErrInvalidEnvelope은 메시지가 어떤 봉투 형식으로도 해석되지 않을 때 반환되는 오류입니다.
+var ErrInvalidEnvelope = errors.New("이벤트 봉투 형식 오류")
+
+// JSONEnvelope은 Event 구조체를 그대로 JSON으로 직렬화하는 기본 코덱입니다. Python eventbus와 호환됩니다.
+var JSONEnvelope EnvelopeCodec = jsonEnvelope{}
+
+type jsonEnvelope struct{}
+
+func (jsonEnvelope) Name() string { return EnvelopeJSON }
+
+func (jsonEnvelope) Encode(evt Event) ([]byte, []kafka.Header, error) {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, nil, nil
+}
+
+func (jsonEnvelope) Decode(value []byte, _ []kafka.Header) (Event, error) {
+	var evt Event
+	if err := json.Unmarshal(value, &evt); err != nil {
+		return Event{}, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	return evt, nil
+}
+
+// CloudEventsCodec은 CloudEvents 1.0 Kafka 프로토콜 바인딩을 따르는 코덱입니다.
+// 바이너리 모드는 속성을 ce_* 헤더에, 페이로드를 메시지 값에 그대로 싣고,
+// 구조화 모드는 속성과 data를 하나의 JSON 문서(application/cloudevents+json)로 싣습니다.
+// type은 페이로드의 type 필드를 사용하며, Retry/MaxRetry 등 재시도 메타데이터는 확장 속성으로 기록합니다.
+type CloudEventsCodec struct {
+	// Structured면 구조화 모드, 아니면 바이너리 모드로 인코딩합니다.
+	Structured bool
+	// Source는 CloudEvents source 속성입니다. 비어 있으면 "/tech-letter"를 사용합니다.
+	Source string
+	// Clock은 time 속성 기록에 사용합니다. nil이면 SystemClock을 사용합니다.
+	Clock Clock
+}
+
+// NewCloudEventsBinaryCodec은 바이너리 모드 CloudEventsCodec을 생성합니다.
+func NewCloudEventsBinaryCodec(source string) *CloudEventsCodec {
+	return &CloudEventsCodec{Source: source}
+}
+
+// NewCloudEventsStructuredCodec은 구조화 모드 CloudEventsCodec을 생성합니다.
+func NewCloudEventsStructuredCodec(source string) *CloudEventsCodec {
+	return &CloudEventsCodec{Structured: true, Source: source}
+}
+
+func (c *CloudEventsCodec) Name() string {
+	if c.Structured {
+		return EnvelopeCloudEventsStructured
+	}
+	return EnvelopeCloudEventsBinary
+}
+
+// cloudEvent는 코덱 내부에서 사용하는 CloudEvents 속성 모음입니다.
+type cloudEvent struct {
+	ID              string
+	Source          string
+	Type            string
+	Time            time.Time
+	DataContentType string
+	Extensions      map[string]string
+	Data            json.RawMessage
+}
+
+func (c *CloudEventsCodec) Encode(evt Event) ([]byte, []kafka.Header, error) {
+	ce, err := c.fromEvent(evt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.Structured {
+		return ce.marshalStructured()
+	}
+	return ce.marshalBinary()
+}
+
+// Decode는 헤더로 모드를 판별해 해석합니다. CloudEvents가 아닌 메시지는 오류를 반환합니다.
+func (c *CloudEventsCodec) Decode(value []byte, headers []kafka.Header) (Event, error) {
+	if isCloudEventsBinary(headers) {
+		return decodeCloudEventsBinary(value, headers)
+	}
+	if isCloudEventsStructured(headers) {
+		return decodeCloudEventsStructured(value)
+	}
+	return Event{}, fmt.Errorf("%w: CloudEvents 헤더가 없습니다", ErrInvalidEnvelope)
+}
+
+func (c *CloudEventsCodec) fromEvent(evt Event) (cloudEvent, error) {
+	if evt.ID == "" {
+		return cloudEvent{}, fmt.Errorf("%w: CloudEvents id가 비어 있습니다", ErrInvalidEnvelope)
+	}
+	clock := c.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	source := c.Source
+	if source == "" {
+		source = defaultCloudEventsSource
+	}
+	eventType := defaultCloudEventsType
+	var head struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(evt.Payload, &head) == nil && head.Type != "" {
+		eventType = head.Type
+	}
+
+	ext := map[string]string{
+		ceExtRetry:    strconv.Itoa(evt.Retry),
+		ceExtMaxRetry: strconv.Itoa(evt.MaxRetry),
+	}
+	if evt.LastError != "" {
+		ext[ceExtLastError] = evt.LastError
+	}
+	if evt.SchemaVersion != 0 {
+		ext[ceExtSchemaVersion] = strconv.Itoa(evt.SchemaVersion)
+	}
+	if len(evt.Failures) > 0 {
+		data, err := json.Marshal(evt.Failures)
+		if err != nil {
+			return cloudEvent{}, fmt.Errorf("실패 이력 마샬링 실패: %w", err)
+		}
+		ext[ceExtFailures] = string(data)
+	}
+	if evt.DLQ != nil {
+		data, err := json.Marshal(evt.DLQ)
+		if err != nil {
+			return cloudEvent{}, fmt.Errorf("DLQ 봉투 마샬링 실패: %w", err)
+		}
+		ext[ceExtDLQ] = string(data)
+	}
+	if evt.PayloadRef != nil {
+		data, err := json.Marshal(evt.PayloadRef)
+		if err != nil {
+			return cloudEvent{}, fmt.Errorf("블롭 참조 마샬링 실패: %w", err)
+		}
+		ext[ceExtPayloadRef] = string(data)
+	}
+
+	return cloudEvent{
+		ID:              evt.ID,
+		Source:          source,
+		Type:            eventType,
+		Time:            clock.Now().UTC(),
+		DataContentType: contentTypeJSON,
+		Extensions:      ext,
+		Data:            evt.Payload,
+	}, nil
+}
+
+func (ce cloudEvent) marshalBinary() ([]byte, []kafka.Header, error) {
+	headers := []kafka.Header{
+		{Key: cloudEventsHeaderPrefix + "specversion", Value: []byte(cloudEventsSpecVersion)},
+		{Key: cloudEventsHeaderPrefix + "id", Value: []byte(ce.ID)},
+		{Key: cloudEventsHeaderPrefix + "source", Value: []byte(ce.Source)},
+		{Key: cloudEventsHeaderPrefix + "type", Value: []byte(ce.Type)},
+		{Key: cloudEventsHeaderPrefix + "time", Value: []byte(ce.Time.Format(time.RFC3339Nano))},
+		{Key: headerContentType, Value: []byte(ce.DataContentType)},
+	}
+	names := make([]string, 0, len(ce.Extensions))
+	for name := range ce.Extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		headers = append(headers, kafka.Header{Key: cloudEventsHeaderPrefix + name, Value: []byte(ce.Extensions[name])})
+	}
+	return []byte(ce.Data), headers, nil
+}
+
+func (ce cloudEvent) marshalStructured() ([]byte, []kafka.Header, error) {
+	doc := map[string]any{
+		"specversion":     cloudEventsSpecVersion,
+		"id":              ce.ID,
+		"source":          ce.Source,
+		"type":            ce.Type,
+		"time":            ce.Time.Format(time.RFC3339Nano),
+		"datacontenttype": ce.DataContentType,
+	}
+	if len(ce.Data) > 0 {
+		doc["data"] = ce.Data
+	}
+	for name, v := range ce.Extensions {
+		// 정수 확장 속성은 구조화 모드에서 JSON 숫자로 기록한다.
+		switch name {
+		case ceExtRetry, ceExtMaxRetry, ceExtSchemaVersion:
+			n, _ := strconv.Atoi(v)
+			doc[name] = n
+		default:
+			doc[name] = v
+		}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, []kafka.Header{{Key: headerContentType, Value: []byte(contentTypeCloudEventsJSONUTF8)}}, nil
+}
+
+func isCloudEventsBinary(headers []kafka.Header) bool {
+	_, ok := headerValue(headers, cloudEventsHeaderPrefix+"specversion")
+	return ok
+}
+
+func isCloudEventsStructured(headers []kafka.Header) bool {
+	v, ok := headerValue(headers, headerContentType)
+	return ok && strings.HasPrefix(strings.ToLower(strings.TrimSpace(v)), contentTypeCloudEventsJSON)
+}
+
+func decodeCloudEventsBinary(value []byte, headers []kafka.Header) (Event, error) {
+	attrs := make(map[string]string)
+	for _, h := range headers {
+		if name, ok := strings.CutPrefix(h.Key, cloudEventsHeaderPrefix); ok {
+			attrs[name] = string(h.Value)
+		}
+	}
+	if ct, ok := headerValue(headers, headerContentType); ok {
+		attrs["datacontenttype"] = ct
+	}
+	return cloudEventToEvent(attrs, value)
+}
+
+func decodeCloudEventsStructured(value []byte) (Event, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(value, &doc); err != nil {
+		return Event{}, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	attrs := make(map[string]string, len(doc))
+	var data []byte
+	for name, raw := range doc {
+		switch name {
+		case "data":
+			data = raw
+		case "data_base64":
+			var encoded string
+			if err := json.Unmarshal(raw, &encoded); err != nil {
+				return Event{}, fmt.Errorf("%w: data_base64: %v", ErrInvalidEnvelope, err)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return Event{}, fmt.Errorf("%w: data_base64: %v", ErrInvalidEnvelope, err)
+			}
+			data = decoded
+		default:
+			var s string
+			if json.Unmarshal(raw, &s) == nil {
+				attrs[name] = s
+			} else {
+				attrs[name] = string(bytes.TrimSpace(raw))
+			}
+		}
+	}
+	return cloudEventToEvent(attrs, data)
+}
+
+// cloudEventToEvent는 CloudEvents 속성과 data로 Event를 복원합니다.
+func cloudEventToEvent(attrs map[string]string, data []byte) (Event, error) {
+	if v := attrs["specversion"]; !strings.HasPrefix(v, "1.") && v != "1" {
+		return Event{}, fmt.Errorf("%w: 지원하지 않는 CloudEvents specversion %q", ErrInvalidEnvelope, v)
+	}
+	for _, required := range []string{"id", "source", "type"} {
+		if attrs[required] == "" {
+			return Event{}, fmt.Errorf("%w: CloudEvents 필수 속성 %s 누락", ErrInvalidEnvelope, required)
+		}
+	}
+	if ct := attrs["datacontenttype"]; ct != "" && !strings.Contains(strings.ToLower(ct), "json") {
+		return Event{}, fmt.Errorf("%w: JSON이 아닌 datacontenttype %q", ErrInvalidEnvelope, ct)
+	}
+
+	evt := Event{
+		ID:        attrs["id"],
+		LastError: attrs[ceExtLastError],
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if !json.Valid(data) {
+			return Event{}, fmt.Errorf("%w: CloudEvents data가 JSON이 아닙니다", ErrInvalidEnvelope)
+		}
+		evt.Payload = json.RawMessage(data)
+	}
+	var err error
+	if evt.Retry, err = intAttr(attrs, ceExtRetry); err != nil {
+		return Event{}, err
+	}
+	if evt.MaxRetry, err = intAttr(attrs, ceExtMaxRetry); err != nil {
+		return Event{}, err
+	}
+	if evt.SchemaVersion, err = intAttr(attrs, ceExtSchemaVersion); err != nil {
+		return Event{}, err
+	}
+	if v := attrs[ceExtFailures]; v != "" {
+		if err := json.Unmarshal([]byte(v), &evt.Failures); err != nil {
+			return Event{}, fmt.Errorf("%w: %s 확장 속성: %v", ErrInvalidEnvelope, ceExtFailures, err)
+		}
+	}
+	if v := attrs[ceExtPayloadRef]; v != "" {
+		evt.PayloadRef = &BlobRef{}
+		if err := json.Unmarshal([]byte(v), evt.PayloadRef); err != nil {
+			return Event{}, fmt.Errorf("%w: %s 확장 속성: %v", ErrInvalidEnvelope, ceExtPayloadRef, err)
+		}
+	}
+	if v := attrs[ceExtDLQ]; v != "" {
+		evt.DLQ = &DLQEnvelope{}
+		if err := json.Unmarshal([]byte(v), evt.DLQ); err != nil {
+			return Event{}, fmt.Errorf("%w: %s 확장 속성: %v", ErrInvalidEnvelope, ceExtDLQ, err)
+		}
+	}
+	return evt, nil
+}
+
+func intAttr(attrs map[string]string, name string) (int, error) {
+	v := attrs[name]
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s 확장 속성이 정수가 아닙니다: %q", ErrInvalidEnvelope, name, v)
+	}
+	return n, nil
+}
+
+// DecodeEnvelope는 메시지 헤더로 봉투 형식을 판별해 Event를 복원합니다.
+// X-Event-Codec 헤더가 있으면 그 코덱(msgpack, protobuf 등)으로, ce_specversion 헤더가 있으면 CloudEvents 바이너리 모드,
+// content-type이 application/cloudevents+json이면 구조화 모드, 그 외에는 기본 JSON 봉투로 해석합니다.
+func DecodeEnvelope(value []byte, headers []kafka.Header) (Event, error) {
+	if evt, ok, err := decodeWithCodecHeader(value, headers); ok {
+		return evt, err
+	}
+	if isCloudEventsBinary(headers) {
+		return decodeCloudEventsBinary(value, headers)
+	}
+	if isCloudEventsStructured(headers) {
+		return decodeCloudEventsStructured(value)
+	}
+	return JSONEnvelope.Decode(value, headers)
+}
+
+// encodeEnvelope는 codec으로 이벤트를 인코딩하고 코덱 헤더를 headers 뒤에 붙입니다. codec이 nil이면 JSON 봉투를 사용합니다.
+// headers 슬라이스는 원본 메시지와 공유될 수 있으므로 새 슬라이스를 만들어 반환합니다.
+func encodeEnvelope(codec EnvelopeCodec, evt Event, headers []kafka.Header) ([]byte, []kafka.Header, error) {
+	if codec == nil {
+		codec = JSONEnvelope
+	}
+	value, codecHeaders, err := codec.Encode(evt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("이벤트 %s 인코딩 실패: %w", codec.Name(), err)
+	}
+	if len(codecHeaders) == 0 {
+		return value, headers, nil
+	}
+	out := make([]kafka.Header, 0, len(headers)+len(codecHeaders))
+	out = append(out, headers...)
+	out = append(out, codecHeaders...)
+	return value, out, nil
+}
+
+// NewEnvelopeCodec은 이름으로 코덱을 생성합니다. source는 CloudEvents 코덱의 source 속성입니다.
+func NewEnvelopeCodec(name, source string) (EnvelopeCodec, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", EnvelopeJSON:
+		return JSONEnvelope, nil
+	case EnvelopeCloudEventsBinary:
+		return NewCloudEventsBinaryCodec(source), nil
+	case EnvelopeCloudEventsStructured:
+		return NewCloudEventsStructuredCodec(source), nil
+	case EnvelopeMsgpack:
+		return MsgpackEnvelope, nil
+	case EnvelopeProtobuf:
+		return ProtobufEnvelope, nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 이벤트 봉투 형식: %s", name)
+	}
+}
+
+func headerValue(headers []kafka.Header, key string) (string, bool) {
+	for _, h := range headers {
+		if strings.EqualFold(h.Key, key) {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+// getKafkaEventEnvelopeFromEnv는 KAFKA_EVENT_ENVELOPE(json, msgpack, protobuf, cloudevents-binary, cloudevents-structured)와
+// CLOUDEVENTS_SOURCE로 발행 코덱을 결정합니다. 값이 잘못되면 경고 후 JSON 봉투를 사용합니다.
+func getKafkaEventEnvelopeFromEnv() EnvelopeCodec {
+	codec, err := NewEnvelopeCodec(os.Getenv("KAFKA_EVENT_ENVELOPE"), os.Getenv("CLOUDEVENTS_SOURCE"))
+	if err != nil {
+		logger.Log.Warnf("KAFKA_EVENT_ENVELOPE 환경변수 파싱 실패: %v. 기본값(json) 사용.", err)
+		return JSONEnvelope
+	}
+	return codec
+}
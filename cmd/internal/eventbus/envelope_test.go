@@ -0,0 +1,208 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func newEnvelopeTestEvent() Event {
+	return Event{
+		ID:            "evt-ce",
+		Payload:       json.RawMessage(`{"type":"post.summary_requested","post_id":"p1"}`),
+		Retry:         2,
+		MaxRetry:      5,
+		LastError:     "timeout",
+		SchemaVersion: 1,
+		Failures:      []FailureRecord{{Attempt: 1, Error: "timeout", Topic: "t.retry.1", Offset: 7}},
+	}
+}
+
+func findHeader(headers []kafka.Header, key string) string {
+	v, _ := headerValue(headers, key)
+	return v
+}
+
+func TestCloudEventsBinaryRoundTrip(t *testing.T) {
+	codec := NewCloudEventsBinaryCodec("/tech-letter/test")
+	codec.Clock = NewManualClock(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	evt := newEnvelopeTestEvent()
+
+	value, headers, err := codec.Encode(evt)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if string(value) != string(evt.Payload) {
+		t.Fatalf("binary mode value should be the raw payload, got %s", value)
+	}
+	for key, want := range map[string]string{
+		"ce_specversion": "1.0",
+		"ce_id":          "evt-ce",
+		"ce_source":      "/tech-letter/test",
+		"ce_type":        "post.summary_requested",
+		"ce_time":        "2025-01-02T03:04:05Z",
+		"ce_retry":       "2",
+		"ce_maxretry":    "5",
+		"ce_lasterror":   "timeout",
+		"content-type":   "application/json",
+	} {
+		if got := findHeader(headers, key); got != want {
+			t.Fatalf("header %s: expected %q, got %q", key, want, got)
+		}
+	}
+
+	got, err := DecodeEnvelope(value, headers)
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if got.ID != evt.ID || got.Retry != 2 || got.MaxRetry != 5 || got.LastError != "timeout" || got.SchemaVersion != 1 {
+		t.Fatalf("unexpected decoded event: %+v", got)
+	}
+	if len(got.Failures) != 1 || got.Failures[0].Offset != 7 {
+		t.Fatalf("expected failures to round trip, got %+v", got.Failures)
+	}
+	if string(got.Payload) != string(evt.Payload) {
+		t.Fatalf("unexpected payload: %s", got.Payload)
+	}
+}
+
+func TestCloudEventsStructuredRoundTrip(t *testing.T) {
+	codec := NewCloudEventsStructuredCodec("")
+	evt := newEnvelopeTestEvent()
+	evt.DLQ = &DLQEnvelope{Reason: DLQReasonPermanentError}
+
+	value, headers, err := codec.Encode(evt)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if ct := findHeader(headers, headerContentType); ct != contentTypeCloudEventsJSONUTF8 {
+		t.Fatalf("unexpected content-type: %q", ct)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(value, &doc); err != nil {
+		t.Fatalf("structured value should be JSON: %v", err)
+	}
+	if doc["specversion"] != "1.0" || doc["source"] != defaultCloudEventsSource || doc["retry"] != float64(2) {
+		t.Fatalf("unexpected structured document: %v", doc)
+	}
+	if data, ok := doc["data"].(map[string]any); !ok || data["post_id"] != "p1" {
+		t.Fatalf("expected payload in data, got %v", doc["data"])
+	}
+
+	got, err := DecodeEnvelope(value, headers)
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if got.ID != evt.ID || got.Retry != 2 || got.MaxRetry != 5 || got.DLQ == nil || got.DLQ.Reason != DLQReasonPermanentError {
+		t.Fatalf("unexpected decoded event: %+v", got)
+	}
+}
+
+func TestDecodeEnvelopeDefaultsToJSON(t *testing.T) {
+	evt := newEnvelopeTestEvent()
+	value, headers, err := JSONEnvelope.Encode(evt)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if len(headers) != 0 {
+		t.Fatalf("json envelope should not add headers, got %v", headers)
+	}
+	got, err := DecodeEnvelope(value, traceHeadersFromContext(context.Background()))
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if got.ID != evt.ID || got.Retry != evt.Retry {
+		t.Fatalf("unexpected decoded event: %+v", got)
+	}
+}
+
+func TestDecodeEnvelopeRejectsIncompleteCloudEvents(t *testing.T) {
+	headers := []kafka.Header{
+		{Key: "ce_specversion", Value: []byte("1.0")},
+		{Key: "ce_id", Value: []byte("evt-1")},
+	}
+	if _, err := DecodeEnvelope([]byte(`{}`), headers); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Fatalf("expected ErrInvalidEnvelope for missing source/type, got %v", err)
+	}
+
+	headers = append(headers,
+		kafka.Header{Key: "ce_source", Value: []byte("/x")},
+		kafka.Header{Key: "ce_type", Value: []byte("x")},
+		kafka.Header{Key: "ce_retry", Value: []byte("one")},
+	)
+	if _, err := DecodeEnvelope([]byte(`{}`), headers); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Fatalf("expected ErrInvalidEnvelope for non-integer retry, got %v", err)
+	}
+}
+
+func TestNewEnvelopeCodec(t *testing.T) {
+	for name, want := range map[string]string{
+		"":                       EnvelopeJSON,
+		"json":                   EnvelopeJSON,
+		"CloudEvents-Binary":     EnvelopeCloudEventsBinary,
+		"cloudevents-structured": EnvelopeCloudEventsStructured,
+	} {
+		codec, err := NewEnvelopeCodec(name, "")
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", name, err)
+		}
+		if codec.Name() != want {
+			t.Fatalf("%q: expected %s, got %s", name, want, codec.Name())
+		}
+	}
+	if _, err := NewEnvelopeCodec("avro", ""); err == nil {
+		t.Fatalf("expected error for unknown envelope")
+	}
+}
+
+func TestMemoryEventBusRetriesWithCloudEventsEnvelope(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewManualClock(time.Unix(0, 0))
+	bus := NewMemoryEventBus(clock)
+	bus.Envelope = NewCloudEventsBinaryCodec("/tech-letter/test")
+	defer bus.Close()
+
+	topic := NewTopic("test.memory.cloudevents")
+	var calls atomic.Int32
+	var retried atomic.Int32
+	go bus.Subscribe(ctx, "group", topic, func(ctx context.Context, evt Event) error {
+		if calls.Add(1) == 1 {
+			return errors.New("temporary failure")
+		}
+		retried.Store(int32(evt.Retry))
+		return nil
+	})
+	go bus.StartRetryReinjector(ctx, "retry-group", topic)
+
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", testPayload{PostID: "post-1"}, 0)
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+
+	retryTopic, _ := topic.GetRetryTopic(1)
+	waitFor(t, func() bool { return len(bus.Events(retryTopic)) == 1 })
+
+	bus.mu.Lock()
+	headers := bus.topics[retryTopic][0].headers
+	bus.mu.Unlock()
+	if got := findHeader(headers, "ce_retry"); got != "1" {
+		t.Fatalf("expected ce_retry=1 on retry message, got %q", got)
+	}
+	if got := findHeader(headers, "ce_lasterror"); got != "temporary failure" {
+		t.Fatalf("expected ce_lasterror on retry message, got %q", got)
+	}
+
+	clock.Advance(RetryDelays[0])
+	waitFor(t, func() bool { return calls.Load() == 2 })
+	if got := retried.Load(); got != 1 {
+		t.Fatalf("expected reinjected event to carry retry=1, got %d", got)
+	}
+}
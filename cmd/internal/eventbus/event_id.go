@@ -0,0 +1,65 @@
+package eventbus
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+var (
+	eventIDMu     sync.Mutex
+	eventIDLastMs int64
+	eventIDSeq    uint16
+)
+
+// NewEventID는 UUIDv7(RFC 9562) 형식의 이벤트 ID를 생성합니다.
+// 상위 48비트는 밀리초 타임스탬프이므로 시간순 정렬이 가능하고,
+// 같은 밀리초 안에서는 12비트 시퀀스를 증가시켜 프로세스 내 충돌과 역전을 막습니다.
+func NewEventID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand 실패는 사실상 발생하지 않지만, 발생 시 ID 생성을 중단할 수 없으므로 시퀀스만으로 보완한다.
+		clear(b[:])
+	}
+
+	eventIDMu.Lock()
+	ms := time.Now().UnixMilli()
+	if ms <= eventIDLastMs {
+		eventIDSeq++
+		if eventIDSeq > 0x0fff {
+			// 같은 밀리초에 4096개를 넘으면 다음 밀리초로 넘겨 단조 증가를 유지한다.
+			eventIDLastMs++
+			eventIDSeq = 0
+		}
+		ms = eventIDLastMs
+	} else {
+		eventIDLastMs = ms
+		eventIDSeq = binary.BigEndian.Uint16(b[6:8]) & 0x07ff // 시퀀스 시작값은 무작위(절반 범위)로 둔다.
+	}
+	seq := eventIDSeq
+	eventIDMu.Unlock()
+
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+	b[6] = 0x70 | byte(seq>>8) // version 7
+	b[7] = byte(seq)
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+	return string(out[:])
+}
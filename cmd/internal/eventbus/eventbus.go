@@ -8,7 +8,8 @@ import (
 	"time"
 )
 
-// RetryDelays는 재시도 횟수(1-based)별로 사용할 고정된 지연 시간 목록입니다.
+// RetryDelays는 재시도 횟수(1-based)별로 사용할 기본 지연 시간 목록입니다.
+// 토픽별로 다른 지연이 필요하면 Topic.WithRetryPolicy로 RetryPolicy를 지정합니다.
 var RetryDelays = []time.Duration{
 	1 * time.Minute,
 	5 * time.Minute,
@@ -17,19 +18,32 @@ var RetryDelays = []time.Duration{
 	1 * time.Hour,
 }
 
-// Topic은 토픽의 기본 이름, 재시도 토픽, DLQ 토픽 이름을 관리합니다.
+// Topic은 토픽의 기본 이름, 재시도 토픽, DLQ 토픽 이름과 재시도 정책을 관리합니다.
 type Topic struct {
-	base string
+	base  string
+	retry RetryPolicy
 }
 
+// NewTopic은 기본 재시도 정책(DefaultRetryPolicy)을 사용하는 토픽을 생성합니다.
 func NewTopic(base string) Topic {
-	return Topic{base: base}
+	return Topic{base: base, retry: DefaultRetryPolicy()}
+}
+
+// WithRetryPolicy는 재시도 정책을 교체한 토픽을 반환합니다.
+func (t Topic) WithRetryPolicy(policy RetryPolicy) Topic {
+	t.retry = policy
+	return t
 }
 
 func (t Topic) Base() string {
 	return t.base
 }
 
+// RetryPolicy는 토픽의 재시도 정책을 반환합니다.
+func (t Topic) RetryPolicy() RetryPolicy {
+	return t.retry
+}
+
 // DLQ는 DLQ 토픽 이름을 반환합니다 (예: my_topic.dlq).
 func (t Topic) DLQ() string {
 	return t.base + ".dlq"
@@ -37,8 +51,9 @@ func (t Topic) DLQ() string {
 
 // GetRetryTopics는 모든 재시도 토픽의 이름을 반환합니다.
 func (t Topic) GetRetryTopics() []string {
-	topics := make([]string, len(RetryDelays))
-	for i := range RetryDelays {
+	attempts := t.retry.Attempts()
+	topics := make([]string, attempts)
+	for i := range attempts {
 		// 토픽 이름 형식: base.retry.1, base.retry.2, ...
 		topics[i] = fmt.Sprintf("%s.retry.%d", t.base, i+1)
 	}
@@ -47,20 +62,45 @@ func (t Topic) GetRetryTopics() []string {
 
 // GetRetryTopic은 다음 재시도 횟수(1-based)에 해당하는 재시도 토픽 이름을 반환합니다.
 func (t Topic) GetRetryTopic(retryCount int) (string, error) {
-	// retryCount는 1부터 시작하며, 인덱스 (retryCount-1)를 사용합니다.
-	if retryCount <= 0 || retryCount > len(RetryDelays) {
+	// retryCount는 1부터 시작하며, 정책의 최대 재시도 횟수를 넘으면 DLQ 대상입니다.
+	if retryCount <= 0 || retryCount > t.retry.Attempts() {
 		return "", ErrMaxRetryExceeded
 	}
 	return fmt.Sprintf("%s.retry.%d", t.base, retryCount), nil
 }
 
+// RetryDelayForTopic은 이 토픽에 속한 재시도 토픽 이름에서 지연 시간을 계산합니다.
+// key는 지터 계산에 사용하는 메시지 키입니다. 다른 토픽의 이름이거나 범위를 벗어나면 false를 반환합니다.
+func (t Topic) RetryDelayForTopic(name string, key []byte) (time.Duration, bool) {
+	base, n, ok := ParseRetryTopicName(name)
+	if !ok || base != t.base {
+		return 0, false
+	}
+	return t.retry.delayWithJitter(n, key)
+}
+
 // Event는 Kafka 메시지의 페이로드로 사용되는 구조체입니다.
 type Event struct {
-	ID        string          `json:"id"`
-	Payload   json.RawMessage `json:"payload"`
-	Retry     int             `json:"retry"` // 현재 재시도 횟수 (0부터 시작)
-	MaxRetry  int             `json:"max_retry"`
-	LastError string          `json:"last_error,omitempty"`
+	ID      string          `json:"id"`
+	Payload json.RawMessage `json:"payload"`
+	// PayloadRef는 클레임 체크로 페이로드를 블롭 저장소에 분리했을 때의 참조입니다. 이때 Payload는 비어 있습니다.
+	PayloadRef *BlobRef `json:"payload_ref,omitempty"`
+	Retry      int      `json:"retry"` // 현재 재시도 횟수 (0부터 시작)
+	MaxRetry   int      `json:"max_retry"`
+	LastError  string   `json:"last_error,omitempty"`
+	// SchemaVersion은 Payload가 따르는 스키마 버전입니다. 0이면 페이로드 타입의 최신 버전으로 취급합니다.
+	SchemaVersion int `json:"schema_version,omitempty"`
+	// Failures는 시도별 실패 이력입니다. 재시도 토픽을 거치는 동안 누적됩니다.
+	Failures []FailureRecord `json:"failures,omitempty"`
+	// DLQ는 DLQ로 라우팅될 때 채워지는 실패 봉투(envelope)입니다.
+	DLQ *DLQEnvelope `json:"dlq,omitempty"`
+}
+
+// normalizeMaxRetry는 설정되지 않았거나 토픽 정책의 범위를 초과한 최대 재시도 횟수를 보정합니다.
+func (t Topic) normalizeMaxRetry(evt *Event) {
+	if attempts := t.retry.Attempts(); evt.MaxRetry <= 0 || evt.MaxRetry > attempts {
+		evt.MaxRetry = attempts
+	}
 }
 
 // EventHandler는 이벤트 처리 함수의 시그니처입니다.
@@ -69,10 +109,21 @@ type EventHandler func(ctx context.Context, event Event) error
 // EventBus 인터페이스는 이벤트 발행 및 구독의 추상화를 정의합니다.
 type EventBus interface {
 	Publish(ctx context.Context, topic string, event Event) error
+	// PublishAsync는 전달 결과를 기다리지 않고 발행하며, 결과는 PublishFuture로 확인합니다.
+	PublishAsync(ctx context.Context, topic string, event Event) *PublishFuture
+	// PublishBatch는 여러 이벤트를 한꺼번에 발행하고 이벤트별 결과를 입력 순서대로 반환합니다.
+	PublishBatch(ctx context.Context, topic string, events []Event) ([]PublishResult, error)
 	// Subscribe는 기본 토픽을 구독하여 메인 로직을 실행합니다.
-	Subscribe(ctx context.Context, groupID string, topic Topic, handler EventHandler) error
+	Subscribe(ctx context.Context, groupID string, topic Topic, handler EventHandler, opts ...SubscribeOption) error
 	// StartRetryReinjector는 모든 재시도 토픽을 구독하고 기본 토픽으로 이벤트를 재발행합니다.
 	StartRetryReinjector(ctx context.Context, groupID string, topic Topic) error
+	// PublishAt은 이벤트를 when 시각에 발행하도록 예약하고, PublishAfter는 지금부터 delay 뒤로 예약합니다.
+	PublishAt(ctx context.Context, topic string, event Event, when time.Time) error
+	PublishAfter(ctx context.Context, topic string, event Event, delay time.Duration) error
+	// CancelScheduled는 아직 발행되지 않은 예약을 이벤트 ID로 취소합니다.
+	CancelScheduled(ctx context.Context, id string) error
+	// StartScheduler는 예약 토픽을 구독하고 예약 시각이 된 이벤트를 발행합니다.
+	StartScheduler(ctx context.Context, groupID string) error
 	Close()
 }
 
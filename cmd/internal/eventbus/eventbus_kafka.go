@@ -2,11 +2,13 @@ package eventbus
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
@@ -18,6 +20,52 @@ import (
 type KafkaEventBus struct {
 	Producer *kafka.Producer
 	Brokers  string
+	// Clock은 재시도 메시지의 재주입 시각 판단과 실패 기록(DLQ 봉투)의 시각에 사용하는 시계입니다. nil이면 SystemClock을 사용합니다.
+	Clock Clock
+	// Metrics는 발행/처리/재시도 지표를 기록할 수집기입니다. nil이면 기록하지 않습니다.
+	Metrics *Metrics
+	// FlushTimeout은 Close 시 남은 메시지를 플러시하며 기다리는 최대 시간입니다.
+	FlushTimeout time.Duration
+	// Schemas는 발행/구독 시 페이로드를 검사할 카탈로그입니다. nil이면 검사하지 않습니다.
+	Schemas *SchemaCatalog
+	// Envelope는 발행 시 사용할 봉투 형식입니다. nil이면 기본 JSON 봉투를 사용합니다.
+	// 구독 쪽은 형식을 자동 판별하므로 이 값과 관계없이 모든 형식을 읽습니다.
+	Envelope EnvelopeCodec
+	// ClaimCheck는 큰 페이로드를 블롭 저장소로 분리하는 설정입니다. nil이면 분리하지 않습니다.
+	ClaimCheck *ClaimCheck
+	// Middlewares는 이 버스의 모든 구독에 적용할 미들웨어입니다. 기본값은 Recover()이며 Use로 추가합니다.
+	Middlewares []Middleware
+
+	// 토픽별 압축(SetTopicCompression)을 위해 압축 방식별로 추가 Producer를 둡니다.
+	producerCfg      kafka.ConfigMap
+	compression      string
+	producersMu      sync.Mutex
+	topicCompression map[string]string
+	producers        map[string]*kafka.Producer
+
+	// 토픽별 재주입기 상태와 일시 정지 여부(Reinjectors, PauseReinjection)입니다.
+	reinjectorsMu sync.Mutex
+	reinjectors   map[string]*reinjectorControl
+
+	// 토픽별 기본 속도 제한(SetTopicRateLimit)과 구독들이 공유하는 토큰 버킷입니다.
+	topicRateLimits map[string]RateLimit
+	rateLimits      rateLimiterSet
+
+	// 토픽 카탈로그(EVENTBUS_TOPIC_CATALOG)에 지정된 기본 토픽별 재시도 정책입니다. retryworker와 같은 정책으로 재시도를 보냅니다.
+	topicRetryPolicies map[string]RetryPolicy
+}
+
+// withCatalogRetryPolicy는 토픽 카탈로그에 재시도 정책이 있으면 그 정책으로 바꾼 topic을 반환합니다.
+func (k *KafkaEventBus) withCatalogRetryPolicy(topic Topic) Topic {
+	if policy, ok := k.topicRetryPolicies[topic.Base()]; ok {
+		return topic.WithRetryPolicy(policy)
+	}
+	return topic
+}
+
+// Use는 이 버스의 모든 구독에 적용할 미들웨어를 추가합니다. Subscribe 호출 전에 설정해야 합니다.
+func (k *KafkaEventBus) Use(mws ...Middleware) {
+	k.Middlewares = append(k.Middlewares, mws...)
 }
 
 // NewKafkaEventBus는 Kafka Producer를 초기화합니다.
@@ -30,37 +78,97 @@ func NewKafkaEventBus(brokers string) (*KafkaEventBus, error) {
 	if maxBytes := getKafkaMessageMaxBytesFromEnv(); maxBytes > 0 {
 		(*producerCfg)["message.max.bytes"] = maxBytes
 	}
+	compression := getKafkaCompressionTypeFromEnv()
+	if compression != "" {
+		(*producerCfg)["compression.type"] = compression
+	} else {
+		compression = CompressionNone
+	}
 
 	p, err := kafka.NewProducer(producerCfg)
 	if err != nil {
 		return nil, fmt.Errorf("kafka Producer 생성 실패: %w", err)
 	}
 
+	k := &KafkaEventBus{
+		Producer: p,
+		Brokers:  brokers,
+		Clock:    SystemClock,
+		Metrics:  DefaultMetrics,
+		Schemas:  DefaultSchemas,
+		Envelope: getKafkaEventEnvelopeFromEnv(),
+
+		Middlewares: []Middleware{Recover()},
+
+		FlushTimeout: getKafkaProducerFlushTimeoutFromEnv(),
+
+		producerCfg:      *producerCfg,
+		compression:      compression,
+		topicCompression: getKafkaTopicCompressionFromEnv(),
+		topicRateLimits:  getTopicRateLimitsFromEnv(),
+
+		topicRetryPolicies: getTopicRetryPoliciesFromEnv(),
+	}
+	if k.ClaimCheck = getClaimCheckFromEnv(); k.ClaimCheck != nil {
+		SetDefaultClaimCheck(k.ClaimCheck)
+		logger.Log.Infof("클레임 체크 사용: 임계값 %d바이트", k.ClaimCheck.threshold())
+	}
+	if len(k.topicCompression) > 0 {
+		logger.Log.Infof("토픽별 압축 설정: %s", describeCompression(k.topicCompression))
+	}
+
 	// Producer 이벤트를 처리하는 고루틴 (전달 보고서 등)
-	go func() {
-		for e := range p.Events() {
-			switch ev := e.(type) {
-			case *kafka.Message:
-				if ev.TopicPartition.Error != nil {
-					logger.Log.Errorf("메시지 전달 실패 %v: %v", ev.TopicPartition, ev.TopicPartition.Error)
-				}
-			case kafka.Error:
-				logger.Log.Errorf("Kafka 오류: %v", ev)
+	go k.handleProducerEvents(p)
+
+	return k, nil
+}
+
+// handleProducerEvents는 Producer 전달 보고서를 받아 발행 결과(PublishFuture)를 확정합니다.
+func (k *KafkaEventBus) handleProducerEvents(p *kafka.Producer) {
+	for e := range p.Events() {
+		switch ev := e.(type) {
+		case *kafka.Message:
+			tp := ev.TopicPartition
+			if tp.Error != nil {
+				logger.Log.Errorf("메시지 전달 실패 %v: %v", tp, tp.Error)
 			}
+			future, ok := ev.Opaque.(*PublishFuture)
+			if !ok {
+				continue
+			}
+			result := PublishResult{
+				EventID:   future.eventID,
+				Topic:     *tp.Topic,
+				Partition: tp.Partition,
+				Offset:    int64(tp.Offset),
+			}
+			if tp.Error != nil {
+				result.Err = fmt.Errorf("메시지 전달 실패: %w", tp.Error)
+			}
+			k.Metrics.observePublish(*tp.Topic, result.Err)
+			future.complete(result)
+		case kafka.Error:
+			logger.Log.Errorf("Kafka 오류: %v", ev)
 		}
-	}()
-
-	return &KafkaEventBus{
-		Producer: p,
-		Brokers:  brokers,
-	}, nil
+	}
 }
 
-// Close는 Producer를 안전하게 종료합니다.
+// Close는 Producer를 안전하게 종료합니다. 토픽별 압축용 Producer도 함께 플러시 후 종료합니다.
 func (k *KafkaEventBus) Close() {
+	k.producersMu.Lock()
+	extra := k.producers
+	k.producers = nil
+	k.producersMu.Unlock()
+	for compression, p := range extra {
+		if remaining := p.Flush(int(k.FlushTimeout / time.Millisecond)); remaining > 0 {
+			logger.Log.Warnf("%s 압축 Producer 플러시 후에도 %d개의 메시지가 남아 있습니다.", compression, remaining)
+		}
+		p.Close()
+	}
+
 	if k.Producer != nil {
-		// 5초 동안 남은 메시지를 모두 플러시합니다.
-		if remaining := k.Producer.Flush(5000); remaining > 0 {
+		// FlushTimeout 동안 남은 메시지를 모두 플러시합니다.
+		if remaining := k.Producer.Flush(int(k.FlushTimeout / time.Millisecond)); remaining > 0 {
 			logger.Log.Warnf("플러시 후에도 %d개의 메시지가 남아 있습니다.", remaining)
 		}
 		k.Producer.Close()
@@ -69,41 +177,117 @@ func (k *KafkaEventBus) Close() {
 }
 
 // Publish는 지정된 토픽에 이벤트를 발행합니다.
+// 컨텍스트의 트레이싱 정보(Request ID, Span ID)는 메시지 헤더로 기록됩니다.
+// 카탈로그(Schemas)에 등록된 토픽이면 페이로드 스키마를 먼저 검사합니다.
 func (k *KafkaEventBus) Publish(ctx context.Context, topic string, event Event) error {
-	data, err := json.Marshal(event)
+	_, err := k.PublishAsync(ctx, topic, event).Wait(ctx)
+	return err
+}
+
+// publish는 주어진 헤더를 그대로 붙여 이벤트를 발행하고 브로커 확인까지 기다립니다.
+// 재시도/DLQ/재주입처럼 원본 메시지의 트레이스를 유지해야 하는 경로에서 사용합니다.
+func (k *KafkaEventBus) publish(ctx context.Context, topic string, event Event, headers []kafka.Header) error {
+	_, err := k.publishAsync(ctx, topic, event, headers).Wait(ctx)
+	return err
+}
+
+// PublishAsync는 이벤트를 Producer 큐에 넣고 전달 결과를 기다리지 않고 반환합니다.
+// 결과는 반환된 PublishFuture의 Wait/Done/OnComplete로 확인합니다.
+func (k *KafkaEventBus) PublishAsync(ctx context.Context, topic string, event Event) *PublishFuture {
+	event, err := k.Schemas.Prepare(topic, event)
+	if err == nil {
+		event, err = k.ClaimCheck.offload(ctx, topic, event)
+	}
 	if err != nil {
-		return fmt.Errorf("이벤트 마샬링 실패: %w", err)
+		k.Metrics.observePublish(topic, err)
+		return completedFuture(PublishResult{EventID: event.ID, Topic: topic, Partition: -1, Offset: -1, Err: err})
+	}
+	return k.publishAsync(ctx, topic, event, traceHeadersFromContext(ctx))
+}
+
+// PublishBatch는 events를 모두 Producer 큐에 넣은 뒤 한꺼번에 전달 결과를 기다립니다.
+// 결과는 입력 순서대로 반환되며, 일부라도 실패하면 *BatchPublishError를 함께 반환합니다.
+// ctx가 취소되면 아직 확인되지 않은 이벤트는 ctx.Err()로 보고됩니다(실제로는 나중에 전달될 수 있습니다).
+func (k *KafkaEventBus) PublishBatch(ctx context.Context, topic string, events []Event) ([]PublishResult, error) {
+	futures := make([]*PublishFuture, len(events))
+	for i, event := range events {
+		futures[i] = k.PublishAsync(ctx, topic, event)
 	}
+	return waitBatch(ctx, futures)
+}
 
-	deliveryChan := make(chan kafka.Event, 1)
-	defer close(deliveryChan)
+// producerQueueFullBackoff는 Producer 로컬 큐가 가득 찼을 때 다시 넣기 전 대기 시간입니다.
+const producerQueueFullBackoff = 50 * time.Millisecond
 
-	// 메시지 생성 및 전송
-	err = k.Producer.Produce(&kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
-		Value:          data,
-		Key:            []byte(event.ID),
-	}, deliveryChan)
+func (k *KafkaEventBus) publishAsync(ctx context.Context, topic string, event Event, headers []kafka.Header) *PublishFuture {
+	fail := func(err error) *PublishFuture {
+		k.Metrics.observePublish(topic, err)
+		return completedFuture(PublishResult{EventID: event.ID, Topic: topic, Partition: -1, Offset: -1, Err: err})
+	}
+	if err := ctx.Err(); err != nil {
+		return fail(err)
+	}
+
+	data, headers, err := encodeEnvelope(k.Envelope, event, headers)
 	if err != nil {
-		return fmt.Errorf("메시지 발행 실패: %w", err)
+		return fail(err)
 	}
+	return k.produceAsync(ctx, topic, event.ID, data, headers)
+}
 
-	// 전달 성공/실패 대기
-	select {
-	case ev := <-deliveryChan:
-		m := ev.(*kafka.Message)
-		if m.TopicPartition.Error != nil {
-			return fmt.Errorf("메시지 전달 실패: %w", m.TopicPartition.Error)
-		}
-	case <-ctx.Done():
-		return ctx.Err()
+// produceAsync는 이미 인코딩된 값을 key로 발행합니다. value가 nil이면 톰스톤이 됩니다.
+func (k *KafkaEventBus) produceAsync(ctx context.Context, topic, key string, value []byte, headers []kafka.Header) *PublishFuture {
+	fail := func(err error) *PublishFuture {
+		k.Metrics.observePublish(topic, err)
+		return completedFuture(PublishResult{EventID: key, Topic: topic, Partition: -1, Offset: -1, Err: err})
+	}
+	producer, err := k.producerFor(topic)
+	if err != nil {
+		return fail(err)
+	}
+
+	future := newPublishFuture(key, topic)
+	msg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          value,
+		Key:            []byte(key),
+		Headers:        headers,
+		Opaque:         future,
 	}
 
-	return nil
+	// 메시지 생성 및 전송. 로컬 큐가 가득 차면 전달 보고서가 자리를 비울 때까지 잠시 기다린다.
+	for {
+		err = producer.Produce(msg, nil)
+		var kerr kafka.Error
+		if err == nil {
+			return future
+		}
+		if !errors.As(err, &kerr) || kerr.Code() != kafka.ErrQueueFull {
+			return fail(fmt.Errorf("메시지 발행 실패: %w", err))
+		}
+		select {
+		case <-ctx.Done():
+			return fail(ctx.Err())
+		case <-time.After(producerQueueFullBackoff):
+		}
+	}
 }
 
 // Subscribe는 기본 토픽을 구독하고 메인 비즈니스 핸들러를 실행합니다.
-func (k *KafkaEventBus) Subscribe(ctx context.Context, groupID string, topic Topic, handler EventHandler) error {
+// 메시지는 키 해시로 워커에 배정되어 병렬 처리되며, 동일 키의 메시지는 같은 워커에서 순서대로 처리됩니다.
+// 오프셋은 파티션별로 앞선 메시지가 모두 끝난 구간까지만 커밋합니다.
+//
+// ctx가 취소되면 새 메시지 수신을 멈추고, 이미 실행 중인 핸들러가 드레인 제한 시간 안에 끝나기를 기다려
+// 처리 결과를 커밋한 뒤 반환합니다. 아직 시작하지 않은 메시지는 커밋하지 않으므로 재시작 후 다시 처리됩니다.
+// 리밸런스로 파티션이 회수될 때도 처리 중인 메시지를 기다려 완료된 오프셋까지 커밋합니다.
+func (k *KafkaEventBus) Subscribe(ctx context.Context, groupID string, topic Topic, handler EventHandler, opts ...SubscribeOption) error {
+	topic = k.withCatalogRetryPolicy(topic)
+	options := newSubscribeOptions(opts)
+	handler = options.wrapHandler(groupID, handler)
+	handler = schemaHandler(k.Schemas, topic.Base(), handler)
+	handler = claimCheckHandler(k.ClaimCheck, handler)
+	handler = options.wrapMiddlewares(k.Middlewares, handler)
+
 	consumerCfg := &kafka.ConfigMap{
 		"bootstrap.servers":             k.Brokers,
 		"group.id":                      groupID, // 메인 컨슈머 그룹 ID
@@ -121,87 +305,406 @@ func (k *KafkaEventBus) Subscribe(ctx context.Context, groupID string, topic Top
 	}
 	defer c.Close()
 
+	// 핸들러는 ctx 취소와 분리된 컨텍스트에서 실행해, 종료 신호가 와도 드레인 제한 시간까지는 마무리할 수 있게 한다.
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+
+	tracker := newOffsetTracker()
+	releases := make(blobReleases)
+	gate := newCircuitGate(newCircuitBreaker(options.breaker, k.Clock, k.Metrics, groupID, topic.Base()))
+	limiter := k.rateLimiterFor(topic.Base(), options.rateLimit)
+	results := make(chan processedMessage, options.concurrency)
+	draining := make(chan struct{})
+	// drained는 drain이 시작되었는지 여부입니다. 메인 루프 고루틴(리밸런스 콜백 포함)에서만 다룹니다.
+	drained := false
+	var wg sync.WaitGroup
+	workers := make([]chan *kafka.Message, options.concurrency)
+	for i := range workers {
+		workers[i] = make(chan *kafka.Message, workerQueueSize)
+		wg.Add(1)
+		go func(jobs <-chan *kafka.Message) {
+			defer wg.Done()
+			for msg := range jobs {
+				var r processedMessage
+				select {
+				case <-draining:
+					// 종료 중에는 대기열에 남은 메시지를 시작하지 않는다.
+					r = processedMessage{msg: msg, skipped: true}
+				default:
+					if err := limiter.Wait(ctx); err != nil {
+						// 속도 제한을 기다리는 중에 종료되면 처리하지 않고 커밋도 하지 않는다.
+						r = processedMessage{msg: msg, skipped: true}
+						break
+					}
+					if gate.breaker.State() == CircuitOpen {
+						// 대기열에 있는 동안 차단기가 열렸으면 핸들러를 부르지 않고 보류한다.
+						r = processedMessage{msg: msg, hold: true}
+						break
+					}
+					r = k.processMessage(handlerCtx, groupID, topic, handler, gate.breaker, msg)
+				}
+				select {
+				case results <- r:
+				case <-handlerCtx.Done():
+					return
+				}
+			}
+		}(workers[i])
+	}
+
+	// commitProcessed는 워커가 끝낸 메시지를 반영하고, 커밋 가능한 오프셋이 전진했다면 커밋합니다.
+	commitProcessed := func(r processedMessage) {
+		gate.Finished()
+		tp := r.msg.TopicPartition
+		if r.skipped {
+			// 시작하지 않은 메시지: 커밋하지 않아 재시작 후 다시 처리되게 한다.
+			tracker.Skip(*tp.Topic, tp.Partition, int64(tp.Offset))
+			return
+		}
+		if r.hold {
+			// 차단기가 보류한 메시지: 커밋하지 않고, 소비를 재개할 때 이 위치부터 다시 읽는다.
+			gate.Hold(r.msg, tracker)
+			return
+		}
+		if !r.commit {
+			// 종료 중 재시도/DLQ 발행을 포기한 메시지: 이 오프셋 이후로는 커밋하지 않아 재시작 시 다시 처리되게 한다.
+			tracker.Skip(*tp.Topic, tp.Partition, int64(tp.Offset))
+			return
+		}
+		key := messagePartitionKey(r.msg)
+		if r.release != nil {
+			releases.Add(key, int64(tp.Offset), *r.release)
+		}
+		next, advanced := tracker.Done(*tp.Topic, tp.Partition, int64(tp.Offset))
+		if !advanced {
+			return
+		}
+		if _, err := c.CommitOffsets([]kafka.TopicPartition{{
+			Topic:     tp.Topic,
+			Partition: tp.Partition,
+			Offset:    kafka.Offset(next),
+		}}); err != nil {
+			logger.Log.Errorf("오프셋 커밋 오류: %v", err)
+			return
+		}
+		// 커밋된 메시지의 블롭만 지운다. 커밋 전에 지우면 다시 받은 메시지가 블롭을 찾지 못한다.
+		for _, evt := range releases.Committed(key, next) {
+			k.ClaimCheck.release(handlerCtx, groupID, evt)
+		}
+	}
+
+	// rebalanceCb는 파티션이 회수되기 전에 해당 파티션의 처리 중인 메시지를 기다렸다가 커밋합니다.
+	// 콜백은 Poll을 호출한 메인 루프에서 실행되므로 결과 채널을 직접 소비해도 안전합니다.
+	// drain 뒤 Consumer.Close가 부르는 회수에서는 이미 기다렸으므로 다시 기다리지 않습니다.
+	// 차단기로 소비를 멈춘 동안 새로 할당받은 파티션은 바로 멈춰 둔다.
+	rebalanceCb := func(c *kafka.Consumer, ev kafka.Event) error {
+		if assigned, ok := ev.(kafka.AssignedPartitions); ok {
+			return gate.Assigned(c, assigned.Partitions)
+		}
+		revoked, ok := ev.(kafka.RevokedPartitions)
+		if !ok {
+			return nil
+		}
+		pending := func() int {
+			n := 0
+			for _, tp := range revoked.Partitions {
+				n += tracker.Pending(*tp.Topic, tp.Partition)
+			}
+			return n
+		}
+		timer := time.NewTimer(options.drainTimeout)
+		defer timer.Stop()
+	wait:
+		for !drained && pending() > 0 {
+			select {
+			case r := <-results:
+				commitProcessed(r)
+			case <-timer.C:
+				logger.Log.Warnf("파티션 회수 전 처리 대기 시간 초과. 미완료 메시지 %d개는 새 소유자가 다시 처리합니다.", pending())
+				break wait
+			}
+		}
+		for _, tp := range revoked.Partitions {
+			tracker.Reset(*tp.Topic, tp.Partition)
+			releases.Forget(partitionKey{topic: *tp.Topic, partition: tp.Partition})
+		}
+		gate.Revoked(revoked.Partitions)
+		logger.Log.Infof("메인 컨슈머 (%s) 파티션 회수: %v", groupID, revoked.Partitions)
+		return nil
+	}
+
 	topicsToSubscribe := []string{topic.Base()}
-	if err := c.SubscribeTopics(topicsToSubscribe, nil); err != nil {
+	if err := c.SubscribeTopics(topicsToSubscribe, rebalanceCb); err != nil {
+		close(draining)
+		for _, jobs := range workers {
+			close(jobs)
+		}
 		return fmt.Errorf("토픽 구독 실패 %v: %w", topicsToSubscribe, err)
 	}
 
-	logger.Log.Infof("메인 컨슈머 (%s) 시작됨. 구독 토픽: %s", groupID, strings.Join(topicsToSubscribe, ", "))
+	logger.Log.Infof("메인 컨슈머 (%s) 시작됨. 구독 토픽: %s, 워커 수: %d", groupID, strings.Join(topicsToSubscribe, ", "), options.concurrency)
+
+	// drain은 수신을 멈춘 뒤 실행 중인 핸들러를 제한 시간까지 기다리고, 끝난 결과를 커밋합니다.
+	drain := func() error {
+		logger.Log.Infof("메인 컨슈머 (%s) 종료 중. 처리 중인 메시지를 최대 %s 동안 기다립니다.", groupID, options.drainTimeout)
+		drained = true
+		close(draining)
+		for _, jobs := range workers {
+			close(jobs)
+		}
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		timer := time.NewTimer(options.drainTimeout)
+		defer timer.Stop()
+		for {
+			select {
+			case r := <-results:
+				commitProcessed(r)
+			case <-done:
+				for drained := false; !drained; {
+					select {
+					case r := <-results:
+						commitProcessed(r)
+					default:
+						drained = true
+					}
+				}
+				logger.Log.Info("메인 컨슈머 드레인 완료.")
+				return ctx.Err()
+			case <-timer.C:
+				logger.Log.Warnf("메인 컨슈머 드레인 제한 시간(%s) 초과. 남은 핸들러를 취소합니다.", options.drainTimeout)
+				cancelHandlers()
+				return ctx.Err()
+			}
+		}
+	}
 
 	for {
+		// 처리 완료된 메시지를 먼저 비워 커밋을 반영한다.
+		for drained := false; !drained; {
+			select {
+			case r := <-results:
+				commitProcessed(r)
+			default:
+				drained = true
+			}
+		}
+
 		select {
 		case <-ctx.Done():
-			logger.Log.Info("메인 컨슈머 종료 중.")
-			return ctx.Err()
+			return drain()
 		default:
-			msg, err := c.ReadMessage(100 * time.Millisecond)
-			if err != nil {
-				if kerr, ok := err.(kafka.Error); ok && kerr.Code() == kafka.ErrTimedOut {
-					continue // 타임아웃은 정상적인 상황입니다.
-				}
-				continue
-			}
+		}
 
-			var evt Event
-			if err := json.Unmarshal(msg.Value, &evt); err != nil {
-				logger.Log.Errorf("토픽 %s의 이벤트 페이로드 오류: %v. 메시지를 건너뛰고 커밋합니다.", *msg.TopicPartition.Topic, err)
-				c.CommitMessage(msg)
-				continue
-			}
+		gate.Sync(c, tracker)
 
-			// 이벤트의 최대 재시도 기본값 보정 (설정되지 않았거나 범위를 초과한 경우)
-			if evt.MaxRetry <= 0 || evt.MaxRetry > len(RetryDelays) {
-				evt.MaxRetry = len(RetryDelays)
+		msg, err := c.ReadMessage(100 * time.Millisecond)
+		if err != nil {
+			if kerr, ok := err.(kafka.Error); ok && kerr.Code() == kafka.ErrTimedOut {
+				continue // 타임아웃은 정상적인 상황입니다.
 			}
+			continue
+		}
 
-			// 1. 핸들러 실행 (비즈니스 로직)
-			if evt.Retry > 0 {
-				logger.Log.Infof("이벤트 %s 처리 시작 (재시도 %d/%d) - 토픽: %s", evt.ID, evt.Retry, evt.MaxRetry, *msg.TopicPartition.Topic)
-			} else {
-				logger.Log.Debugf("이벤트 %s 처리 시작 - 토픽: %s", evt.ID, *msg.TopicPartition.Topic)
-			}
-			err = handler(ctx, evt)
-
-			if err != nil {
-				// 2. 핸들러 실패: 재시도 또는 DLQ 결정
-				evt.LastError = err.Error()
-				nextRetryCount := evt.Retry + 1
-				nextRetryTopic, getTopicErr := topic.GetRetryTopic(nextRetryCount)
-
-				if getTopicErr == ErrMaxRetryExceeded {
-					// 2-1. 최대 재시도 횟수 초과 -> DLQ 발행
-					logger.Log.Errorf("이벤트 %s의 최대 재시도 횟수 초과. DLQ %s로 전송. 최종 오류: %s", evt.ID, topic.DLQ(), err.Error())
-					publishErr := k.Publish(ctx, topic.DLQ(), evt)
-					if publishErr != nil {
-						logger.Log.Errorf("DLQ %s 발행 실패: %v. 오프셋 커밋 안함.\n", topic.DLQ(), publishErr)
-						continue // 발행 실패 시 메시지 재처리 시도
-					}
-				} else if getTopicErr != nil {
-					logger.Log.Errorf("재시도 토픽 결정 중 예상치 못한 오류 발생: %v. 오프셋 커밋 안함.", getTopicErr)
-					continue
-				} else {
-					// 2-2. 재시도 예약 (지연 토픽으로 발행)
-					evt.Retry = nextRetryCount
-					logger.Log.Warnf("이벤트 %s 처리 실패. 재시도 %d/%d를 토픽 %s에 예약.",
-						evt.ID, evt.Retry, evt.MaxRetry, nextRetryTopic)
-					publishErr := k.Publish(ctx, nextRetryTopic, evt)
-					if publishErr != nil {
-						logger.Log.Errorf("재시도 이벤트 토픽 %s 발행 실패: %v. 오프셋 커밋 안함.", nextRetryTopic, publishErr)
-						continue
-					}
-				}
-			}
+		if !gate.Accepting() {
+			// 멈추기 전에 이미 받은 메시지는 처리하지 않고 재개할 때 다시 읽는다.
+			gate.Hold(msg, tracker)
+			continue
+		}
+
+		tp := msg.TopicPartition
+		tracker.Track(*tp.Topic, tp.Partition, int64(tp.Offset))
+		jobs := workers[workerIndex(msg, len(workers))]
 
-			// 3. 성공 또는 재시도/DLQ 발행 성공 시 오프셋 커밋
-			if _, err := c.CommitMessage(msg); err != nil {
-				logger.Log.Errorf("오프셋 커밋 오류: %v", err)
+		// 워커 큐가 가득 차 있으면 완료 결과를 커밋하면서 자리가 날 때까지 기다린다.
+		for dispatched := false; !dispatched; {
+			select {
+			case jobs <- msg:
+				dispatched = true
+				gate.Dispatched()
+			case r := <-results:
+				commitProcessed(r)
+			case <-ctx.Done():
+				tracker.Skip(*tp.Topic, tp.Partition, int64(tp.Offset))
+				return drain()
 			}
 		}
 	}
 }
 
+// workerQueueSize는 워커별로 대기시킬 수 있는 최대 메시지 수입니다.
+const workerQueueSize = 16
+
+// processedMessage는 워커가 처리를 마친 메시지와 커밋 가능 여부입니다.
+// hold는 차단기가 재시도 예약 대신 메시지를 보류했다는 뜻이고, skipped는 종료 중이라 처리를 시작하지 않았다는 뜻입니다.
+// release는 오프셋을 커밋한 뒤 블롭을 지울 이벤트입니다.
+type processedMessage struct {
+	msg     *kafka.Message
+	commit  bool
+	hold    bool
+	skipped bool
+	release *Event
+}
+
+// workerIndex는 메시지 키 해시로 워커를 선택합니다. 키가 없으면 파티션 번호를 사용합니다.
+func workerIndex(msg *kafka.Message, n int) int {
+	if n <= 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	if len(msg.Key) > 0 {
+		h.Write(msg.Key)
+	} else {
+		h.Write([]byte(strconv.Itoa(int(msg.TopicPartition.Partition))))
+	}
+	return int(h.Sum32() % uint32(n))
+}
+
+// processMessage는 단일 메시지에 대해 핸들러를 실행하고, 실패 시 재시도 토픽 또는 DLQ로 발행합니다.
+// 오프셋을 커밋해도 되는 경우(성공, 페이로드 오류, 재시도/DLQ 발행 성공) commit=true를 반환합니다.
+// 재시도/DLQ 발행은 성공할 때까지 다시 시도하므로, commit=false는 종료 중 ctx가 취소된 경우뿐입니다.
+// 차단기가 열려 실패한 메시지를 재시도 토픽으로 보내지 않고 보류하면 hold=true를 반환합니다.
+// 블롭 참조가 있는 이벤트를 끝까지 처리했으면 release에 담아, 커밋한 뒤 블롭을 지우게 합니다.
+func (k *KafkaEventBus) processMessage(ctx context.Context, groupID string, topic Topic, handler EventHandler, breaker *circuitBreaker, msg *kafka.Message) processedMessage {
+	result := processedMessage{msg: msg}
+	evt, err := DecodeEnvelope(msg.Value, msg.Headers)
+	if err != nil {
+		logger.Log.Errorf("토픽 %s의 이벤트 페이로드 오류: %v. 메시지를 건너뛰고 커밋합니다.", *msg.TopicPartition.Topic, err)
+		breaker.Skipped()
+		result.commit = true
+		return result
+	}
+	if evt.PayloadRef != nil {
+		ref := evt
+		result.release = &ref
+	}
+
+	// 이벤트의 최대 재시도 기본값 보정 (설정되지 않았거나 범위를 초과한 경우)
+	topic.normalizeMaxRetry(&evt)
+
+	// 발행 측의 트레이싱 정보를 핸들러 컨텍스트로 복원한다.
+	ctx = contextWithTraceHeaders(ctx, msg.Headers)
+	ctx = withHandlerInfo(ctx, HandlerInfo{
+		GroupID:   groupID,
+		BaseTopic: topic.Base(),
+		Topic:     *msg.TopicPartition.Topic,
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+	})
+	headers := traceHeaders(msg.Headers)
+
+	// 1. 핸들러 실행 (비즈니스 로직)
+	fields := traceLogFields(ctx, logger.Fields{
+		"event_id": evt.ID,
+		"topic":    *msg.TopicPartition.Topic,
+		"retry":    evt.Retry,
+	})
+	if evt.Retry > 0 {
+		logger.InfoWithFields(fmt.Sprintf("이벤트 %s 처리 시작 (재시도 %d/%d)", evt.ID, evt.Retry, evt.MaxRetry), fields)
+	} else {
+		logger.DebugWithFields(fmt.Sprintf("이벤트 %s 처리 시작", evt.ID), fields)
+	}
+	started := time.Now()
+	err = handler(ctx, evt)
+	k.Metrics.observeHandler(topic.Base(), groupID, time.Since(started), err)
+	if breaker.Record(err) {
+		logger.Log.Warnf("이벤트 %s 처리 실패. 차단기가 열려 있어 재시도 예약 없이 보류합니다. 오류: %s", evt.ID, err.Error())
+		return processedMessage{msg: msg, hold: true}
+	}
+	if err == nil {
+		result.commit = true
+		return result
+	}
+
+	// 2. 핸들러 실패: 재시도 또는 DLQ 결정
+	route := routeFailure(topic, evt, err, failureSource{
+		GroupID:   groupID,
+		Topic:     *msg.TopicPartition.Topic,
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		At:        k.Clock.Now(),
+	})
+	if route.DLQ {
+		// 2-1. 영구 오류 또는 최대 재시도 횟수 초과 -> DLQ 발행
+		if route.Event.DLQ.Reason == DLQReasonPermanentError {
+			logger.Log.Errorf("이벤트 %s 처리 중 영구 오류 발생. 재시도 없이 DLQ %s로 전송. 오류: %s", evt.ID, route.Topic, err.Error())
+		} else {
+			logger.Log.Errorf("이벤트 %s의 최대 재시도 횟수 초과. DLQ %s로 전송. 최종 오류: %s", evt.ID, route.Topic, err.Error())
+		}
+		if publishErr := k.publishRoute(ctx, route, headers); publishErr != nil {
+			logger.Log.Errorf("DLQ %s 발행 실패: %v. 오프셋 커밋 안함.", route.Topic, publishErr)
+			return processedMessage{msg: msg}
+		}
+		k.Metrics.observeRoute(topic.Base(), route)
+		// DLQ 레코드도 참조만 가지므로, dlqctl show/replay가 페이로드를 읽을 수 있게 블롭을 남겨 둔다.
+		return processedMessage{msg: msg, commit: true}
+	}
+
+	// 2-2. 재시도 예약 (지연 토픽으로 발행)
+	logger.Log.Warnf("이벤트 %s 처리 실패. 재시도 %d/%d를 토픽 %s에 예약.",
+		evt.ID, route.Event.Retry, route.Event.MaxRetry, route.Topic)
+	if publishErr := k.publishRoute(ctx, route, headers); publishErr != nil {
+		logger.Log.Errorf("재시도 이벤트 토픽 %s 발행 실패: %v. 오프셋 커밋 안함.", route.Topic, publishErr)
+		return processedMessage{msg: msg}
+	}
+	k.Metrics.observeRoute(topic.Base(), route)
+	// 재시도 토픽으로는 참조만 넘기므로 블롭을 남겨 둔다.
+	return processedMessage{msg: msg, commit: true}
+}
+
+// routePublishMinBackoff, routePublishMaxBackoff는 재시도/DLQ 발행이 실패했을 때 다시 시도하기까지의 최소/최대 대기 시간입니다.
+const (
+	routePublishMinBackoff = time.Second
+	routePublishMaxBackoff = 30 * time.Second
+)
+
+// publishRoute는 실패한 이벤트를 재시도 토픽 또는 DLQ로 발행합니다. 발행이 실패하면 ctx가 취소될 때까지
+// 대기 시간을 두 배씩 늘리며 다시 시도합니다. 커밋하지 못한 메시지를 남겨 두면 같은 파티션의 뒤 오프셋도
+// 커밋할 수 없으므로 포기하지 않으며, 오류는 ctx가 취소(드레인 제한 시간 초과)된 경우에만 반환합니다.
+func (k *KafkaEventBus) publishRoute(ctx context.Context, route failureRoute, headers []kafka.Header) error {
+	backoff := routePublishMinBackoff
+	for {
+		err := k.publish(ctx, route.Topic, route.Event, headers)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		logger.Log.Errorf("이벤트 %s 토픽 %s 발행 실패: %v. %s 뒤 다시 시도합니다.", route.Event.ID, route.Topic, err, backoff)
+		timer := k.Clock.NewTimer(k.Clock.Now().Add(backoff))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C():
+		}
+		backoff = min(backoff*2, routePublishMaxBackoff)
+	}
+}
+
+// reinjectRetryBackoff는 재주입 발행이 실패했을 때 같은 메시지를 다시 시도하기까지의 대기 시간입니다.
+const reinjectRetryBackoff = time.Second
+
+// reinjectorPollInterval은 대기 중인 파티션이 없을 때 재주입 컨슈머의 최대 Poll 대기 시간입니다.
+const reinjectorPollInterval = 100 * time.Millisecond
+
 // StartRetryReinjector는 모든 재시도 토픽을 구독하고 메시지를 기본 토픽으로 재발행(re-publish)합니다.
-func (k *KafkaEventBus) StartRetryReinjector(ctx context.Context, groupID string, topic Topic) error {
+// 아직 지연 시간이 지나지 않은 메시지를 만나면 그 파티션만 Pause 하고 타이머 힙에 보관했다가,
+// readyAt이 되면 재주입 후 Resume 합니다. 다른 파티션은 그동안에도 계속 처리됩니다.
+// 실행 상태는 Reinjectors로 조회하고, PauseReinjection/ResumeReinjection으로 토픽 단위로 멈출 수 있습니다.
+func (k *KafkaEventBus) StartRetryReinjector(ctx context.Context, groupID string, topic Topic) (err error) {
+	topic = k.withCatalogRetryPolicy(topic)
+	rc := k.reinjectorFor(topic, groupID)
+	defer func() {
+		if ctx.Err() != nil {
+			rc.setState(ReinjectorStopped, nil, k.Clock.Now())
+			return
+		}
+		rc.setState(ReinjectorFailed, err, k.Clock.Now())
+	}()
+
 	consumerCfg := &kafka.ConfigMap{
 		"bootstrap.servers":             k.Brokers,
 		"group.id":                      groupID, // 전용 재주입 그룹 ID
@@ -219,11 +722,38 @@ func (k *KafkaEventBus) StartRetryReinjector(ctx context.Context, groupID string
 	}
 	defer c.Close()
 
+	sched := newDelayScheduler(k.Clock)
+	pause := &reinjectPause{skipped: make(map[partitionKey]kafka.TopicPartition)}
+
+	// 회수된 파티션의 대기 메시지는 커밋하지 않은 채 버려, 새 소유자가 다시 읽게 한다.
+	// 일시 정지 중에 새로 할당받은 파티션은 바로 멈춰 둔다.
+	rebalanceCb := func(c *kafka.Consumer, ev kafka.Event) error {
+		switch e := ev.(type) {
+		case kafka.AssignedPartitions:
+			if !pause.active {
+				return nil
+			}
+			if err := c.Assign(e.Partitions); err != nil {
+				return err
+			}
+			if err := c.Pause(e.Partitions); err != nil {
+				logger.Log.Errorf("재시도 파티션 %v 일시 정지 실패: %v", e.Partitions, err)
+			}
+		case kafka.RevokedPartitions:
+			for _, tp := range e.Partitions {
+				sched.Remove(*tp.Topic, tp.Partition)
+				delete(pause.skipped, partitionKey{topic: *tp.Topic, partition: tp.Partition})
+			}
+		}
+		return nil
+	}
+
 	retryTopics := topic.GetRetryTopics()
-	if err := c.SubscribeTopics(retryTopics, nil); err != nil {
+	if err := c.SubscribeTopics(retryTopics, rebalanceCb); err != nil {
 		return fmt.Errorf("재시도 토픽 구독 실패 %v: %w", retryTopics, err)
 	}
 
+	rc.setState(ReinjectorRunning, nil, k.Clock.Now())
 	logger.Log.Infof("재시도 재주입 컨슈머 (%s) 시작됨. 구독 토픽: %s", groupID, strings.Join(retryTopics, ", "))
 
 	for {
@@ -232,74 +762,183 @@ func (k *KafkaEventBus) StartRetryReinjector(ctx context.Context, groupID string
 			logger.Log.Info("재시도 재주입 컨슈머 종료 중.")
 			return ctx.Err()
 		default:
-			msg, err := c.ReadMessage(100 * time.Millisecond)
-			if err != nil {
-				if kerr, ok := err.(kafka.Error); ok {
-					if kerr.Code() == kafka.ErrTimedOut {
-						continue
-					}
-					if kerr.IsFatal() {
-						return fmt.Errorf("재시도 재주입 컨슈머 치명적 오류: %w", err)
-					}
-				}
-				logger.Log.Errorf("재시도 재주입 컨슈머 ReadMessage 오류: %v", err)
-				time.Sleep(500 * time.Millisecond)
-				continue
+		}
+
+		if paused := rc.paused(); paused != pause.active {
+			pause.apply(c, sched, paused, topic.Base())
+		}
+
+		wait := reinjectorPollInterval
+		if !pause.active {
+			for _, d := range sched.Due() {
+				k.releaseDelayed(ctx, c, topic, sched, d)
 			}
+			wait = sched.Wait(reinjectorPollInterval)
+		}
+		rc.setWaiting(sched)
 
-			// 토픽명에서 재시도 지연 시간 추출 및 준비시간 확인
-			topicName := *msg.TopicPartition.Topic
-			delayDur, ok := ParseRetryDelayFromTopicName(topicName)
-			if !ok {
-				logger.Log.Errorf("재시도 토픽 이름 파싱 실패: %s. 메시지를 건너뛰고 커밋합니다.", topicName)
-				c.CommitMessage(msg)
+		timeoutMs := int(wait / time.Millisecond)
+		if timeoutMs < 1 {
+			timeoutMs = 1
+		}
+		switch ev := c.Poll(timeoutMs).(type) {
+		case *kafka.Message:
+			if ev.TopicPartition.Error != nil {
+				logger.Log.Errorf("재시도 재주입 컨슈머 메시지 오류: %v", ev.TopicPartition.Error)
 				continue
 			}
-
-			readyAt := msg.Timestamp.Add(delayDur)
-			now := time.Now()
-			if now.Before(readyAt) {
-				remaining := readyAt.Sub(now)
-				// 전체 컨슈머 스레드를 오래 블로킹하지 않기 위해 짧게만 대기하면서, 동일 오프셋으로 Seek하여 같은 메시지를 재검사한다.
-				sleepDur := remaining
-				if sleepDur > 500*time.Millisecond {
-					sleepDur = 500 * time.Millisecond
-				} else if sleepDur < 50*time.Millisecond {
-					sleepDur = 50 * time.Millisecond
-				}
-				time.Sleep(sleepDur)
-				if err := c.Seek(kafka.TopicPartition{
-					Topic:     msg.TopicPartition.Topic,
-					Partition: msg.TopicPartition.Partition,
-					Offset:    msg.TopicPartition.Offset,
-				}, 1000); err != nil {
-					logger.Log.Errorf("재시도 재주입 컨슈머 seek 오류: %v", err)
-				}
+			if sched.Holds(ev) {
 				continue
 			}
-
-			var evt Event
-			if err := json.Unmarshal(msg.Value, &evt); err != nil {
-				logger.Log.Errorf("재시도 토픽 %s의 이벤트 페이로드 오류: %v. 메시지를 건너뛰고 커밋합니다.\n", *msg.TopicPartition.Topic, err)
-				c.CommitMessage(msg)
+			if pause.active {
+				pause.skip(ev)
 				continue
 			}
+			k.handleRetryMessage(ctx, c, topic, sched, ev)
+		case kafka.Error:
+			if ev.IsFatal() {
+				return fmt.Errorf("재시도 재주입 컨슈머 치명적 오류: %w", ev)
+			}
+			logger.Log.Errorf("재시도 재주입 컨슈머 오류: %v", ev)
+		}
+	}
+}
 
-			// 1. 메시지를 메인 토픽으로 재주입
-			logger.Log.Infof("이벤트 %s를 %s에서 %s로 재주입. (재시도: %d)",
-				evt.ID, *msg.TopicPartition.Topic, topic.Base(), evt.Retry)
+// reinjectPause는 PauseReinjection으로 재주입기 전체를 멈춘 상태입니다. 재주입 루프 고루틴에서만 다룹니다.
+type reinjectPause struct {
+	active bool
+	// skipped는 Pause가 적용되기 전에 받아 버린 파티션별 첫 메시지 위치입니다. 재개할 때 이 위치로 Seek 합니다.
+	skipped map[partitionKey]kafka.TopicPartition
+}
 
-			if err := k.Publish(ctx, topic.Base(), evt); err != nil {
-				logger.Log.Errorf("이벤트 %s 재주입 실패: %v. 오프셋 커밋 안함.\n", evt.ID, err)
-				continue // 재발행 실패 시 메시지 재처리 시도
-			}
+// skip은 일시 정지 중에 받은 msg를 처리하지 않고, 재개 후 다시 읽도록 위치를 기록합니다.
+func (p *reinjectPause) skip(msg *kafka.Message) {
+	key := messagePartitionKey(msg)
+	if _, ok := p.skipped[key]; !ok {
+		p.skipped[key] = msg.TopicPartition
+	}
+}
+
+// apply는 할당된 모든 파티션을 멈추거나, 지연 대기 중이 아닌 파티션을 건너뛴 위치부터 재개합니다.
+// 지연 대기 중인 파티션은 계속 멈춰 두고 readyAt이 되면 releaseDelayed가 재개합니다.
+func (p *reinjectPause) apply(c *kafka.Consumer, sched *delayScheduler, paused bool, base string) {
+	p.active = paused
+	assigned, err := c.Assignment()
+	if err != nil {
+		logger.Log.Errorf("재시도 재주입 컨슈머 할당 조회 실패: %v", err)
+	}
+	if paused {
+		if err := c.Pause(assigned); err != nil {
+			logger.Log.Errorf("재시도 파티션 %v 일시 정지 실패: %v", assigned, err)
+		}
+		logger.Log.Warnf("토픽 %s의 재주입을 일시 정지했습니다.", base)
+		return
+	}
 
-			// 2. 재발행 성공했으므로, 지연 토픽의 오프셋 커밋
-			if _, err := c.CommitMessage(msg); err != nil {
-				logger.Log.Errorf("재주입 후 커밋 오류: %v\n", err)
+	resume := make([]kafka.TopicPartition, 0, len(assigned))
+	for _, tp := range assigned {
+		key := partitionKey{topic: *tp.Topic, partition: tp.Partition}
+		if _, held := sched.byPartition[key]; held {
+			continue
+		}
+		if at, ok := p.skipped[key]; ok {
+			if err := c.Seek(at, 0); err != nil {
+				logger.Log.Errorf("재시도 재주입 컨슈머 seek 오류: %v", err)
 			}
 		}
+		resume = append(resume, tp)
+	}
+	clear(p.skipped)
+	if err := c.Resume(resume); err != nil {
+		logger.Log.Errorf("재시도 파티션 %v 재개 실패: %v", resume, err)
+	}
+	logger.Log.Infof("토픽 %s의 재주입을 재개했습니다.", base)
+}
+
+// handleRetryMessage는 재시도 토픽에서 읽은 메시지를 즉시 재주입하거나, 아직 이르면 파티션을 멈추고 보관합니다.
+func (k *KafkaEventBus) handleRetryMessage(ctx context.Context, c *kafka.Consumer, topic Topic, sched *delayScheduler, msg *kafka.Message) {
+	// 토픽명에서 재시도 지연 시간 추출 및 준비시간 확인
+	topicName := *msg.TopicPartition.Topic
+	delayDur, ok := topic.RetryDelayForTopic(topicName, msg.Key)
+	if !ok {
+		logger.Log.Errorf("재시도 토픽 이름 파싱 실패: %s. 메시지를 건너뛰고 커밋합니다.", topicName)
+		c.CommitMessage(msg)
+		return
+	}
+
+	readyAt := msg.Timestamp.Add(delayDur)
+	if sched.clock.Now().Before(readyAt) {
+		k.pauseUntil(c, sched, msg, readyAt)
+		return
+	}
+
+	if !k.reinject(ctx, c, topic, msg) {
+		k.pauseUntil(c, sched, msg, sched.clock.Now().Add(reinjectRetryBackoff))
+	}
+}
+
+// pauseUntil은 msg의 파티션을 멈추고 readyAt에 다시 처리하도록 예약합니다.
+func (k *KafkaEventBus) pauseUntil(c *kafka.Consumer, sched *delayScheduler, msg *kafka.Message, readyAt time.Time) {
+	sched.Defer(msg, readyAt)
+	if err := c.Pause([]kafka.TopicPartition{msg.TopicPartition}); err != nil {
+		logger.Log.Errorf("재시도 파티션 %v 일시 정지 실패: %v", msg.TopicPartition, err)
+	}
+	logger.DebugWithFields("재시도 파티션 일시 정지", logger.Fields{
+		"topic":     *msg.TopicPartition.Topic,
+		"partition": msg.TopicPartition.Partition,
+		"offset":    int64(msg.TopicPartition.Offset),
+		"ready_at":  readyAt,
+	})
+}
+
+// releaseDelayed는 readyAt이 된 메시지를 재주입하고 파티션을 재개합니다.
+// 재주입에 실패하면 파티션을 멈춘 채로 reinjectRetryBackoff 뒤에 다시 시도합니다.
+func (k *KafkaEventBus) releaseDelayed(ctx context.Context, c *kafka.Consumer, topic Topic, sched *delayScheduler, d *delayedPartition) {
+	if !k.reinject(ctx, c, topic, d.msg) {
+		d.readyAt = sched.clock.Now().Add(reinjectRetryBackoff)
+		sched.requeue(d)
+		return
+	}
+
+	tp := d.msg.TopicPartition
+	if d.skipped {
+		// Pause 전에 받아 버린 뒤쪽 메시지를 다시 읽도록 보관했던 메시지 다음 위치로 되돌린다.
+		if err := c.Seek(kafka.TopicPartition{Topic: tp.Topic, Partition: tp.Partition, Offset: tp.Offset + 1}, 0); err != nil {
+			logger.Log.Errorf("재시도 재주입 컨슈머 seek 오류: %v", err)
+		}
+	}
+	if err := c.Resume([]kafka.TopicPartition{tp}); err != nil {
+		logger.Log.Errorf("재시도 파티션 %v 재개 실패: %v", tp, err)
+	}
+}
+
+// reinject는 재시도 메시지를 기본 토픽으로 재발행하고 커밋합니다.
+// 재발행에 실패하면 false를 반환하며, 이때 오프셋은 커밋하지 않습니다.
+func (k *KafkaEventBus) reinject(ctx context.Context, c *kafka.Consumer, topic Topic, msg *kafka.Message) bool {
+	evt, err := DecodeEnvelope(msg.Value, msg.Headers)
+	if err != nil {
+		logger.Log.Errorf("재시도 토픽 %s의 이벤트 페이로드 오류: %v. 메시지를 건너뛰고 커밋합니다.\n", *msg.TopicPartition.Topic, err)
+		c.CommitMessage(msg)
+		return true
+	}
+
+	// 1. 메시지를 메인 토픽으로 재주입 (원본 트레이싱 헤더 유지)
+	msgCtx := contextWithTraceHeaders(ctx, msg.Headers)
+	logger.InfoWithFields(fmt.Sprintf("이벤트 %s를 %s에서 %s로 재주입. (재시도: %d)",
+		evt.ID, *msg.TopicPartition.Topic, topic.Base(), evt.Retry),
+		traceLogFields(msgCtx, logger.Fields{"event_id": evt.ID, "topic": topic.Base(), "retry": evt.Retry}))
+
+	if err := k.publish(ctx, topic.Base(), evt, traceHeaders(msg.Headers)); err != nil {
+		logger.Log.Errorf("이벤트 %s 재주입 실패: %v. 오프셋 커밋 안함.\n", evt.ID, err)
+		return false
+	}
+	k.Metrics.observeReinject(topic.Base(), *msg.TopicPartition.Topic)
+
+	// 2. 재발행 성공했으므로, 지연 토픽의 오프셋 커밋
+	if _, err := c.CommitMessage(msg); err != nil {
+		logger.Log.Errorf("재주입 후 커밋 오류: %v\n", err)
 	}
+	return true
 }
 
 func getKafkaMessageMaxBytesFromEnv() int {
@@ -344,3 +983,60 @@ func getKafkaMaxPollIntervalMsFromEnv() int {
 
 	return value
 }
+
+// getKafkaConsumerConcurrencyFromEnv 는 KAFKA_CONSUMER_CONCURRENCY 환경변수에서
+// Subscribe 워커 수를 읽어온다. 비어 있거나 1 미만, 파싱 실패 시 1 을 반환하여
+// 기존과 같은 순차 처리를 유지한다.
+func getKafkaConsumerConcurrencyFromEnv() int {
+	raw := strings.TrimSpace(os.Getenv("KAFKA_CONSUMER_CONCURRENCY"))
+	if raw == "" {
+		return 1
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		logger.Log.Warnf("KAFKA_CONSUMER_CONCURRENCY 환경변수 파싱 실패: %v. 기본값 1 사용.", err)
+		return 1
+	}
+
+	if value < 1 {
+		logger.Log.Warnf("KAFKA_CONSUMER_CONCURRENCY 환경변수 값이 1 미만입니다. 기본값 1 사용.")
+		return 1
+	}
+
+	return value
+}
+
+// getKafkaConsumerDrainTimeoutFromEnv 는 KAFKA_CONSUMER_DRAIN_TIMEOUT_MS 환경변수에서
+// 종료/리밸런스 시 처리 중인 핸들러를 기다릴 최대 시간을 읽어온다.
+// 비어 있거나 0 이하, 파싱 실패 시 기본값 30초를 사용한다.
+func getKafkaConsumerDrainTimeoutFromEnv() time.Duration {
+	return getDurationMsFromEnv("KAFKA_CONSUMER_DRAIN_TIMEOUT_MS", 30*time.Second)
+}
+
+// getKafkaProducerFlushTimeoutFromEnv 는 KAFKA_PRODUCER_FLUSH_TIMEOUT_MS 환경변수에서
+// Close 시 Producer 플러시 제한 시간을 읽어온다. 비어 있거나 0 이하, 파싱 실패 시 기본값 5초를 사용한다.
+func getKafkaProducerFlushTimeoutFromEnv() time.Duration {
+	return getDurationMsFromEnv("KAFKA_PRODUCER_FLUSH_TIMEOUT_MS", 5*time.Second)
+}
+
+// getDurationMsFromEnv 는 밀리초 단위 환경변수를 읽어 time.Duration 으로 반환한다.
+func getDurationMsFromEnv(key string, fallback time.Duration) time.Duration {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		logger.Log.Warnf("%s 환경변수 파싱 실패: %v. 기본값 %s 사용.", key, err, fallback)
+		return fallback
+	}
+
+	if value <= 0 {
+		logger.Log.Warnf("%s 환경변수 값이 0 이하입니다. 기본값 %s 사용.", key, fallback)
+		return fallback
+	}
+
+	return time.Duration(value) * time.Millisecond
+}
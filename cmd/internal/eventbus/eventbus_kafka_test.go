@@ -0,0 +1,182 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// newMockKafkaBus는 librdkafka 내장 모의 클러스터에 topics를 만들고, 그 클러스터에 연결한 버스를 반환합니다.
+func newMockKafkaBus(t *testing.T, topics ...string) *KafkaEventBus {
+	t.Helper()
+	mc, err := kafka.NewMockCluster(1)
+	if err != nil {
+		t.Fatalf("mock cluster: %v", err)
+	}
+	t.Cleanup(mc.Close)
+	for _, topic := range topics {
+		if err := mc.CreateTopic(topic, 1, 1); err != nil {
+			t.Fatalf("create topic %s: %v", topic, err)
+		}
+	}
+	bus, err := NewKafkaEventBus(mc.BootstrapServers())
+	if err != nil {
+		t.Fatalf("bus: %v", err)
+	}
+	bus.FlushTimeout = time.Second
+	t.Cleanup(bus.Close)
+	return bus
+}
+
+func publishTestEvents(t *testing.T, bus *KafkaEventBus, topic string, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if err := bus.Publish(context.Background(), topic, Event{ID: id, Payload: json.RawMessage(`{}`)}); err != nil {
+			t.Fatalf("publish %s: %v", id, err)
+		}
+	}
+}
+
+// readTestEvent는 topic의 첫 메시지를 처음부터 읽어 Event로 해석합니다.
+func readTestEvent(t *testing.T, bus *KafkaEventBus, topic string) Event {
+	t.Helper()
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  bus.Brokers,
+		"group.id":           "test-reader",
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		t.Fatalf("consumer: %v", err)
+	}
+	defer c.Close()
+	if err := c.Assign([]kafka.TopicPartition{{Topic: &topic, Partition: 0, Offset: kafka.OffsetBeginning}}); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	msg, err := c.ReadMessage(30 * time.Second)
+	if err != nil {
+		t.Fatalf("read %s: %v", topic, err)
+	}
+	evt, err := DecodeEnvelope(msg.Value, msg.Headers)
+	if err != nil {
+		t.Fatalf("decode %s: %v", topic, err)
+	}
+	return evt
+}
+
+func TestKafkaSubscribeRecordsFailureTimeWithBusClock(t *testing.T) {
+	topic := NewTopic("tech-letter.test.clock")
+	bus := newMockKafkaBus(t, topic.Base(), topic.DLQ())
+	clock := NewManualClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	bus.Clock = clock
+	publishTestEvents(t, bus, topic.Base(), "evt-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go bus.Subscribe(ctx, "clock-test", topic, func(ctx context.Context, evt Event) error {
+		return Permanent(errors.New("bad payload"))
+	})
+
+	env := readTestEvent(t, bus, topic.DLQ()).DLQ
+	if env == nil || !env.LastFailedAt.Equal(clock.Now()) || !env.Errors[0].FailedAt.Equal(clock.Now()) {
+		t.Fatalf("failure time should come from bus clock %v, got %+v", clock.Now(), env)
+	}
+}
+
+// committedOffset는 group이 topic 0번 파티션에 커밋한 오프셋을 조회합니다.
+func committedOffset(t *testing.T, bus *KafkaEventBus, group, topic string) kafka.Offset {
+	t.Helper()
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{"bootstrap.servers": bus.Brokers, "group.id": group})
+	if err != nil {
+		t.Fatalf("consumer: %v", err)
+	}
+	defer c.Close()
+	committed, err := c.Committed([]kafka.TopicPartition{{Topic: &topic, Partition: 0}}, 10000)
+	if err != nil {
+		t.Fatalf("committed: %v", err)
+	}
+	return committed[0].Offset
+}
+
+func TestKafkaSubscribeDrainDoesNotWaitForQueuedMessages(t *testing.T) {
+	topic := NewTopic("tech-letter.test.drain")
+	bus := newMockKafkaBus(t, topic.Base())
+	publishTestEvents(t, bus, topic.Base(), "evt-1", "evt-2", "evt-3", "evt-4")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	var handled atomic.Int32
+	done := make(chan error, 1)
+	go func() {
+		done <- bus.Subscribe(ctx, "drain-test", topic, func(ctx context.Context, evt Event) error {
+			started <- struct{}{}
+			<-release
+			handled.Add(1)
+			return nil
+		}, WithConcurrency(1), WithDrainTimeout(10*time.Second))
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(20 * time.Second):
+		t.Fatalf("handler was not called")
+	}
+	// 나머지 메시지가 워커 대기열에 들어갈 시간을 준 뒤 종료한다.
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	// 메인 루프가 종료를 알아채고 드레인을 시작한 뒤에 실행 중인 핸들러를 끝낸다.
+	time.Sleep(300 * time.Millisecond)
+	close(release)
+
+	// 대기열에 남은 메시지는 결과가 오지 않으므로, 파티션 회수에서 드레인 제한 시간을 다시 기다리면 안 된다.
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Subscribe kept waiting for queued messages after drain")
+	}
+	if got := handled.Load(); got != 1 {
+		t.Fatalf("expected only the running handler to finish, got %d", got)
+	}
+	if got := committedOffset(t, bus, "drain-test", topic.Base()); got != 1 {
+		t.Fatalf("expected commit at offset 1, got %v", got)
+	}
+}
+
+func TestKafkaSubscribeHoldsQueuedMessagesWhileCircuitOpen(t *testing.T) {
+	topic := NewTopic("tech-letter.test.circuit")
+	bus := newMockKafkaBus(t, append([]string{topic.Base()}, topic.GetRetryTopics()...)...)
+	publishTestEvents(t, bus, topic.Base(), "evt-1", "evt-2", "evt-3")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int32
+	done := make(chan error, 1)
+	go func() {
+		done <- bus.Subscribe(ctx, "circuit-test", topic, func(ctx context.Context, evt Event) error {
+			calls.Add(1)
+			return errors.New("downstream unavailable")
+		}, WithConcurrency(1), WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, CoolDown: time.Hour}))
+	}()
+
+	deadline := time.Now().Add(20 * time.Second)
+	for calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	// 첫 실패로 차단기가 열린 뒤에는 이미 워커 대기열에 있던 메시지도 핸들러에 넘기지 않는다.
+	time.Sleep(time.Second)
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected only the first message to reach the handler, got %d", got)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Subscribe did not stop")
+	}
+}
@@ -0,0 +1,125 @@
+package eventbus
+
+import (
+	"errors"
+	"time"
+)
+
+// permanentError는 재시도해도 성공할 수 없는 오류를 표시합니다.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent는 err를 영구 오류로 표시합니다. 핸들러가 영구 오류를 반환하면
+// 재시도 토픽을 거치지 않고 곧바로 DLQ로 보냅니다(예: 잘못된 페이로드, 삭제된 포스트).
+// err가 nil이면 nil을 반환합니다.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent는 err 체인에 Permanent로 표시된 오류가 있는지 확인합니다.
+func IsPermanent(err error) bool {
+	var perm *permanentError
+	return errors.As(err, &perm)
+}
+
+// DLQ 라우팅 사유
+const (
+	DLQReasonMaxRetryExceeded = "max_retry_exceeded"
+	DLQReasonPermanentError   = "permanent_error"
+)
+
+// FailureRecord는 한 번의 처리 실패 기록입니다.
+type FailureRecord struct {
+	Attempt   int       `json:"attempt"` // 실패 당시의 재시도 횟수 (0 = 최초 처리)
+	Error     string    `json:"error"`
+	Permanent bool      `json:"permanent,omitempty"`
+	Topic     string    `json:"topic"`
+	Partition int32     `json:"partition"`
+	Offset    int64     `json:"offset"`
+	FailedAt  time.Time `json:"failed_at"`
+}
+
+// DLQEnvelope는 DLQ 메시지에 함께 기록되는 실패 정보입니다.
+// 원본 위치(토픽/파티션/오프셋)는 마지막으로 실패한 메시지를 기준으로 합니다.
+type DLQEnvelope struct {
+	Reason        string          `json:"reason"`
+	OriginalTopic string          `json:"original_topic"`
+	Partition     int32           `json:"partition"`
+	Offset        int64           `json:"offset"`
+	ConsumerGroup string          `json:"consumer_group"`
+	FirstFailedAt time.Time       `json:"first_failed_at"`
+	LastFailedAt  time.Time       `json:"last_failed_at"`
+	Errors        []FailureRecord `json:"errors"`
+}
+
+// failureSource는 실패한 메시지의 위치와 처리한 컨슈머 그룹입니다.
+type failureSource struct {
+	GroupID   string
+	Topic     string
+	Partition int32
+	Offset    int64
+	At        time.Time
+}
+
+// failureRoute는 핸들러 실패 후 이벤트를 발행할 목적지입니다.
+type failureRoute struct {
+	Topic string
+	Event Event
+	DLQ   bool
+}
+
+// routeFailure는 핸들러 오류를 이벤트 실패 이력에 기록하고, 다음 재시도 토픽 또는 DLQ 중 목적지를 결정합니다.
+// 영구 오류이거나 이벤트의 MaxRetry(토픽 정책 범위로 보정된 값)를 넘으면 DLQ 봉투를 채워 DLQ로 보냅니다.
+func routeFailure(topic Topic, evt Event, handlerErr error, src failureSource) failureRoute {
+	permanent := IsPermanent(handlerErr)
+	evt.LastError = handlerErr.Error()
+	evt.Failures = append(append([]FailureRecord(nil), evt.Failures...), FailureRecord{
+		Attempt:   evt.Retry,
+		Error:     handlerErr.Error(),
+		Permanent: permanent,
+		Topic:     src.Topic,
+		Partition: src.Partition,
+		Offset:    src.Offset,
+		FailedAt:  src.At,
+	})
+
+	reason := DLQReasonPermanentError
+	if !permanent {
+		nextRetryCount := evt.Retry + 1
+		retryTopic, err := topic.GetRetryTopic(nextRetryCount)
+		if err == nil && evt.MaxRetry > 0 && nextRetryCount > evt.MaxRetry {
+			err = ErrMaxRetryExceeded
+		}
+		if err == nil {
+			evt.Retry = nextRetryCount
+			return failureRoute{Topic: retryTopic, Event: evt}
+		}
+		reason = DLQReasonMaxRetryExceeded
+	}
+
+	evt.DLQ = &DLQEnvelope{
+		Reason:        reason,
+		OriginalTopic: src.Topic,
+		Partition:     src.Partition,
+		Offset:        src.Offset,
+		ConsumerGroup: src.GroupID,
+		FirstFailedAt: evt.Failures[0].FailedAt,
+		LastFailedAt:  src.At,
+		Errors:        evt.Failures,
+	}
+	// 실패 이력은 봉투에 옮겼으므로 이벤트 본문에서는 중복 기록하지 않는다.
+	evt.Failures = nil
+	return failureRoute{Topic: topic.DLQ(), Event: evt, DLQ: true}
+}
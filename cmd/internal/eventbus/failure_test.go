@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIsPermanentDetectsWrappedErrors(t *testing.T) {
+	base := errors.New("post deleted")
+	err := fmt.Errorf("handle summary: %w", Permanent(base))
+
+	if !IsPermanent(err) {
+		t.Fatalf("expected wrapped permanent error to be detected")
+	}
+	if !errors.Is(err, base) {
+		t.Fatalf("expected permanent error to unwrap to the original error")
+	}
+	if IsPermanent(base) {
+		t.Fatalf("expected plain error not to be permanent")
+	}
+	if Permanent(nil) != nil {
+		t.Fatalf("expected Permanent(nil) to be nil")
+	}
+}
+
+func TestMemoryEventBusSendsPermanentErrorsStraightToDLQ(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewManualClock(time.Unix(0, 0))
+	bus := NewMemoryEventBus(clock)
+	defer bus.Close()
+
+	topic := NewTopic("test.failure.permanent")
+	go bus.Subscribe(ctx, "summary-group", topic, func(ctx context.Context, evt Event) error {
+		return Permanent(errors.New("invalid payload"))
+	})
+
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", testPayload{PostID: "post-1"}, 0)
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+
+	waitFor(t, func() bool { return len(bus.Events(topic.DLQ())) == 1 })
+	for _, retryTopic := range topic.GetRetryTopics() {
+		if got := len(bus.Events(retryTopic)); got != 0 {
+			t.Fatalf("expected no retry on %s, got %d events", retryTopic, got)
+		}
+	}
+
+	envelope := bus.Events(topic.DLQ())[0].DLQ
+	if envelope == nil || envelope.Reason != DLQReasonPermanentError {
+		t.Fatalf("expected permanent error envelope, got %+v", envelope)
+	}
+	if len(envelope.Errors) != 1 || !envelope.Errors[0].Permanent || envelope.Errors[0].Error != "invalid payload" {
+		t.Fatalf("unexpected error history: %+v", envelope.Errors)
+	}
+	if envelope.ConsumerGroup != "summary-group" || envelope.Offset != 0 {
+		t.Fatalf("unexpected envelope source: %+v", envelope)
+	}
+}
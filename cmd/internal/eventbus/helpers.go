@@ -4,17 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 )
 
 // NewJSONEvent 생성: payload를 JSON으로 인코딩하여 Event를 구성합니다.
-// id가 빈 문자열이면 고해상도 타임스탬프 기반의 ID를 생성합니다.
+// id가 빈 문자열이면 UUIDv7 기반의 ID(NewEventID)를 생성합니다.
+// maxRetry가 1~기본 재시도 정책(DefaultRetryPolicy)의 최대 재시도 횟수 범위를 벗어나면 정책의 최대값을 사용합니다.
+// 토픽별 재시도 정책을 따르려면 NewTopicJSONEvent를 사용합니다.
 func NewJSONEvent(id string, payload any, maxRetry int) (Event, error) {
-	if maxRetry <= 0 || maxRetry > len(RetryDelays) {
-		maxRetry = len(RetryDelays)
+	return newJSONEvent(DefaultRetryPolicy(), id, payload, maxRetry)
+}
+
+// NewTopicJSONEvent는 NewJSONEvent와 같지만 maxRetry를 topic의 재시도 정책 범위로 맞춥니다.
+func NewTopicJSONEvent(topic Topic, id string, payload any, maxRetry int) (Event, error) {
+	return newJSONEvent(topic.RetryPolicy(), id, payload, maxRetry)
+}
+
+func newJSONEvent(policy RetryPolicy, id string, payload any, maxRetry int) (Event, error) {
+	if attempts := policy.Attempts(); maxRetry <= 0 || maxRetry > attempts {
+		maxRetry = attempts
 	}
 	if id == "" {
-		id = fmt.Sprintf("%d", time.Now().UnixNano())
+		id = NewEventID()
 	}
 	b, err := json.Marshal(payload)
 	if err != nil {
@@ -29,8 +39,22 @@ func NewJSONEvent(id string, payload any, maxRetry int) (Event, error) {
 }
 
 // DecodeJSON은 Event.Payload를 제네릭 타입으로 언마샬합니다.
+// 페이로드가 클레임 체크로 분리되어 참조만 있으면 기본 블롭 저장소(SetDefaultClaimCheck)에서 읽어 옵니다.
+// 핸들러 안에서는 취소가 전달되도록 DecodeJSONContext를 사용합니다.
 func DecodeJSON[T any](evt Event) (T, error) {
+	return DecodeJSONContext[T](context.Background(), evt)
+}
+
+// DecodeJSONContext는 DecodeJSON과 같지만 블롭을 읽을 때 ctx를 사용합니다.
+func DecodeJSONContext[T any](ctx context.Context, evt Event) (T, error) {
 	var out T
+	if evt.offloaded() {
+		resolved, err := (*ClaimCheck)(nil).Resolve(ctx, evt)
+		if err != nil {
+			return out, err
+		}
+		evt = resolved
+	}
 	if err := json.Unmarshal(evt.Payload, &out); err != nil {
 		var zero T
 		return zero, fmt.Errorf("payload unmarshal 실패: %w", err)
@@ -40,12 +64,12 @@ func DecodeJSON[T any](evt Event) (T, error) {
 
 // SubscribeJSON은 JSON 페이로드를 자동으로 디코딩해주는 Subscribe 헬퍼입니다.
 // handler는 디코딩된 payload와 원본 메타(Event)를 함께 받습니다.
-func SubscribeJSON[T any](ctx context.Context, bus EventBus, groupID string, topic Topic, handler func(ctx context.Context, payload T, meta Event) error) error {
+func SubscribeJSON[T any](ctx context.Context, bus EventBus, groupID string, topic Topic, handler func(ctx context.Context, payload T, meta Event) error, opts ...SubscribeOption) error {
 	return bus.Subscribe(ctx, groupID, topic, func(ctx context.Context, evt Event) error {
-		v, err := DecodeJSON[T](evt)
+		v, err := DecodeJSONContext[T](ctx, evt)
 		if err != nil {
 			return err
 		}
 		return handler(ctx, v, evt)
-	})
+	}, opts...)
 }
@@ -0,0 +1,477 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// defaultLagCheckInterval은 LagMonitor가 지연을 다시 계산하는 기본 주기입니다.
+const defaultLagCheckInterval = time.Minute
+
+// TopicKind는 기본/재시도/DLQ 토픽 구분입니다.
+type TopicKind string
+
+const (
+	TopicKindBase  TopicKind = "base"
+	TopicKindRetry TopicKind = "retry"
+	TopicKindDLQ   TopicKind = "dlq"
+)
+
+// topicKind는 토픽 이름을 기본 토픽 이름과 종류로 나눕니다.
+func topicKind(name string) (string, TopicKind) {
+	if base, _, ok := ParseRetryTopicName(name); ok {
+		return base, TopicKindRetry
+	}
+	if base, ok := strings.CutSuffix(name, ".dlq"); ok && base != "" {
+		return base, TopicKindDLQ
+	}
+	return name, TopicKindBase
+}
+
+// PartitionLag는 파티션 하나의 커밋 오프셋과 high watermark 차이입니다.
+// 커밋 기록이 없으면 Committed는 -1이며, low watermark부터 남은 것으로 계산합니다.
+type PartitionLag struct {
+	Partition     int32 `json:"partition"`
+	Committed     int64 `json:"committed"`
+	HighWatermark int64 `json:"high_watermark"`
+	Lag           int64 `json:"lag"`
+}
+
+// GroupLag는 컨슈머 그룹 하나가 토픽 하나에서 밀린 메시지 수입니다.
+type GroupLag struct {
+	Group      string         `json:"group"`
+	Topic      string         `json:"topic"`
+	Base       string         `json:"base"`
+	Kind       TopicKind      `json:"kind"`
+	Lag        int64          `json:"lag"`
+	Partitions []PartitionLag `json:"partitions"`
+}
+
+// DLQSize는 DLQ 토픽에 남은 메시지 수와 직전 검사 이후 늘어난 수입니다.
+type DLQSize struct {
+	Topic    string `json:"topic"`
+	Base     string `json:"base"`
+	Messages int64  `json:"messages"`
+	// Growth는 직전 검사 대비 증가량입니다. 첫 검사에서는 0이며, purge 후에는 음수일 수 있습니다.
+	Growth int64 `json:"growth"`
+}
+
+// LagAlert는 임계값을 넘은 항목입니다. DLQ 증가 알림은 Group이 비어 있습니다.
+type LagAlert struct {
+	Group     string    `json:"group,omitempty"`
+	Topic     string    `json:"topic"`
+	Kind      TopicKind `json:"kind"`
+	Value     int64     `json:"value"`
+	Threshold int64     `json:"threshold"`
+}
+
+func (a LagAlert) key() string {
+	return a.Group + "|" + a.Topic
+}
+
+func (a LagAlert) String() string {
+	if a.Kind == TopicKindDLQ {
+		return fmt.Sprintf("DLQ %s 증가 %d건 (임계값 %d)", a.Topic, a.Value, a.Threshold)
+	}
+	return fmt.Sprintf("그룹 %s의 %s 지연 %d건 (임계값 %d)", a.Group, a.Topic, a.Value, a.Threshold)
+}
+
+// LagReport는 한 번의 검사 결과입니다.
+type LagReport struct {
+	CheckedAt time.Time  `json:"checked_at"`
+	Groups    []GroupLag `json:"groups"`
+	DLQs      []DLQSize  `json:"dlqs"`
+	// Alerts는 현재 임계값을 넘은 모든 항목입니다. 새로 넘은 항목만 로그/OnAlert로 알립니다.
+	Alerts []LagAlert `json:"alerts"`
+}
+
+// LagThresholds는 알림 임계값입니다. 0 이하이면 해당 알림을 끕니다.
+type LagThresholds struct {
+	// Base는 기본 토픽의 그룹별 지연 임계값입니다.
+	Base int64
+	// Retry는 재시도 토픽의 그룹별 지연 임계값입니다. 재시도 토픽은 지연 시간 동안 메시지가 쌓이는 것이 정상이므로 넉넉히 잡습니다.
+	Retry int64
+	// DLQGrowth는 검사 주기 한 번 동안 DLQ에 새로 쌓인 메시지 수 임계값입니다.
+	DLQGrowth int64
+	// Topics는 기본 토픽별 Base 임계값입니다. 같은 기본 토픽의 재시도 토픽에는 적용하지 않습니다.
+	Topics map[string]int64
+}
+
+// LagThresholdsFromEnv는 환경변수에서 임계값을 읽습니다.
+//   - EVENTBUS_LAG_ALERT_BASE (기본 1000)
+//   - EVENTBUS_LAG_ALERT_RETRY (기본 0, 끔)
+//   - EVENTBUS_DLQ_ALERT_GROWTH (기본 1)
+//   - EVENTBUS_LAG_ALERT_TOPICS: "tech-letter.post.summary=100,..." 형식의 기본 토픽별 임계값
+func LagThresholdsFromEnv() LagThresholds {
+	return LagThresholds{
+		Base:      getInt64FromEnv("EVENTBUS_LAG_ALERT_BASE", 1000),
+		Retry:     getInt64FromEnv("EVENTBUS_LAG_ALERT_RETRY", 0),
+		DLQGrowth: getInt64FromEnv("EVENTBUS_DLQ_ALERT_GROWTH", 1),
+		Topics:    parseTopicThresholds("EVENTBUS_LAG_ALERT_TOPICS", os.Getenv("EVENTBUS_LAG_ALERT_TOPICS")),
+	}
+}
+
+func getInt64FromEnv(key string, def int64) int64 {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		logger.Log.Warnf("%s 환경변수 파싱 실패: %v. 기본값 사용.", key, err)
+		return def
+	}
+	return v
+}
+
+func parseTopicThresholds(key, raw string) map[string]int64 {
+	out := make(map[string]int64)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		topic, value, ok := strings.Cut(item, "=")
+		topic = strings.TrimSpace(topic)
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if !ok || topic == "" || err != nil {
+			logger.Log.Warnf("%s 환경변수 항목 %q 파싱 실패. 건너뜁니다.", key, item)
+			continue
+		}
+		out[topic] = n
+	}
+	return out
+}
+
+func (t LagThresholds) forGroup(g GroupLag) int64 {
+	switch g.Kind {
+	case TopicKindRetry:
+		return t.Retry
+	case TopicKindBase:
+		if n, ok := t.Topics[g.Base]; ok {
+			return n
+		}
+		return t.Base
+	}
+	return 0
+}
+
+// Evaluate는 report에서 임계값을 넘은 항목을 찾습니다.
+func (t LagThresholds) Evaluate(report LagReport) []LagAlert {
+	var alerts []LagAlert
+	for _, g := range report.Groups {
+		if limit := t.forGroup(g); limit > 0 && g.Lag >= limit {
+			alerts = append(alerts, LagAlert{Group: g.Group, Topic: g.Topic, Kind: g.Kind, Value: g.Lag, Threshold: limit})
+		}
+	}
+	for _, d := range report.DLQs {
+		if t.DLQGrowth > 0 && d.Growth >= t.DLQGrowth {
+			alerts = append(alerts, LagAlert{Topic: d.Topic, Kind: TopicKindDLQ, Value: d.Growth, Threshold: t.DLQGrowth})
+		}
+	}
+	return alerts
+}
+
+// LagMonitor는 컨슈머 그룹의 커밋 오프셋과 파티션 high watermark를 비교해 기본/재시도/DLQ 토픽의 지연을 주기적으로 계산합니다.
+// 결과는 Report로 조회하고 Metrics(consumer_lag, dlq_messages)로 노출하며, 임계값을 새로 넘으면 경고 로그를 남기고 OnAlert를 호출합니다.
+type LagMonitor struct {
+	Brokers string
+	// Filter는 검사할 기본 토픽을 고릅니다. 비어 있으면 eventbus 명명 규칙의 모든 토픽을 검사합니다.
+	Filter     TopicFilter
+	Thresholds LagThresholds
+	// Interval은 검사 주기입니다. 0 이하이면 defaultLagCheckInterval을 사용합니다.
+	Interval time.Duration
+	// Metrics는 지연 지표를 기록할 수집기입니다. nil이면 기록하지 않습니다.
+	Metrics *Metrics
+	// Clock은 CheckedAt 기록에 사용하는 시계입니다. nil이면 SystemClock을 사용합니다.
+	Clock Clock
+	// OnAlert는 임계값을 새로 넘은 항목마다 호출됩니다. 계속 넘어 있는 동안에는 다시 호출하지 않습니다.
+	OnAlert func(LagAlert)
+
+	mu     sync.Mutex
+	last   LagReport
+	prev   map[string]int64
+	active map[string]LagAlert
+}
+
+// Run은 ctx가 취소될 때까지 Interval마다 지연을 검사합니다. 검사 실패는 로그만 남기고 계속합니다.
+func (m *LagMonitor) Run(ctx context.Context) error {
+	admin, err := kafka.NewAdminClient(&kafka.ConfigMap{"bootstrap.servers": m.Brokers})
+	if err != nil {
+		return fmt.Errorf("AdminClient 생성 실패: %w", err)
+	}
+	defer admin.Close()
+
+	interval := m.Interval
+	if interval <= 0 {
+		interval = defaultLagCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.check(ctx, admin); err != nil && ctx.Err() == nil {
+			logger.Log.Errorf("컨슈머 지연 검사 실패: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check는 지연을 한 번 검사하고 결과를 반환합니다.
+func (m *LagMonitor) Check(ctx context.Context) (LagReport, error) {
+	admin, err := kafka.NewAdminClient(&kafka.ConfigMap{"bootstrap.servers": m.Brokers})
+	if err != nil {
+		return LagReport{}, fmt.Errorf("AdminClient 생성 실패: %w", err)
+	}
+	defer admin.Close()
+	return m.check(ctx, admin)
+}
+
+// Report는 마지막 검사 결과를 반환합니다. 아직 검사하지 않았으면 CheckedAt이 0입니다.
+func (m *LagMonitor) Report() LagReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
+
+func (m *LagMonitor) check(ctx context.Context, admin *kafka.AdminClient) (LagReport, error) {
+	partitions, err := m.watchedPartitions(admin)
+	if err != nil {
+		return LagReport{}, err
+	}
+	low, high, err := listWatermarks(ctx, admin, partitions)
+	if err != nil {
+		return LagReport{}, err
+	}
+	committed, err := listCommittedOffsets(ctx, admin, partitions)
+	if err != nil {
+		return LagReport{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clock := m.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	report := buildLagReport(partitions, committed, low, high, m.prev)
+	report.CheckedAt = clock.Now()
+	report.Alerts = m.Thresholds.Evaluate(report)
+
+	prev := make(map[string]int64, len(report.DLQs))
+	for _, d := range report.DLQs {
+		prev[d.Topic] = d.Messages
+	}
+	// 크기를 조회하지 못해 빠진 DLQ는 직전 값을 남겨 다음 검사에서 증가량을 계산한다.
+	for topic, n := range m.prev {
+		if _, ok := prev[topic]; !ok && len(partitions[topic]) > 0 {
+			prev[topic] = n
+		}
+	}
+	m.prev = prev
+	m.notify(report.Alerts)
+	m.last = report
+	m.Metrics.observeLag(report)
+	return report, nil
+}
+
+// notify는 새로 임계값을 넘은 항목을 알리고, 임계값 아래로 돌아온 항목을 기록합니다.
+func (m *LagMonitor) notify(alerts []LagAlert) {
+	active := make(map[string]LagAlert, len(alerts))
+	for _, a := range alerts {
+		active[a.key()] = a
+		if _, ok := m.active[a.key()]; ok {
+			continue
+		}
+		logger.Log.Warnf("eventbus 지연 임계값 초과: %s", a)
+		m.Metrics.observeLagAlert(a)
+		if m.OnAlert != nil {
+			m.OnAlert(a)
+		}
+	}
+	for key, a := range m.active {
+		if _, ok := active[key]; !ok {
+			logger.Log.Infof("eventbus 지연 정상화: %s", a)
+		}
+	}
+	m.active = active
+}
+
+// watchedPartitions는 검사 대상 토픽(eventbus 명명 규칙, Filter 통과)의 파티션 번호를 조회합니다.
+func (m *LagMonitor) watchedPartitions(admin *kafka.AdminClient) (map[string][]int32, error) {
+	md, err := admin.GetMetadata(nil, true, 10000)
+	if err != nil {
+		return nil, fmt.Errorf("토픽 메타데이터 조회 실패: %w", err)
+	}
+	out := make(map[string][]int32)
+	for name, tm := range md.Topics {
+		if strings.HasPrefix(name, "__") || name == SchedulerTopic || tm.Error.Code() == kafka.ErrUnknownTopicOrPart {
+			continue
+		}
+		if base, _ := topicKind(name); !m.Filter.Match(base) {
+			continue
+		}
+		for _, p := range tm.Partitions {
+			out[name] = append(out[name], p.ID)
+		}
+	}
+	return out, nil
+}
+
+// listWatermarks는 파티션별 low/high watermark를 한 번에 조회합니다.
+func listWatermarks(ctx context.Context, admin *kafka.AdminClient, partitions map[string][]int32) (low, high map[partitionKey]int64, err error) {
+	query := func(spec kafka.OffsetSpec) (map[partitionKey]int64, error) {
+		req := make(map[kafka.TopicPartition]kafka.OffsetSpec)
+		for topic, ps := range partitions {
+			for _, p := range ps {
+				req[kafka.TopicPartition{Topic: &topic, Partition: p}] = spec
+			}
+		}
+		out := make(map[partitionKey]int64, len(req))
+		if len(req) == 0 {
+			return out, nil
+		}
+		res, err := admin.ListOffsets(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("파티션 오프셋 조회 실패: %w", err)
+		}
+		for tp, info := range res.ResultInfos {
+			if info.Error.Code() != kafka.ErrNoError {
+				logger.Log.Warnf("파티션 %s[%d] 오프셋 조회 실패: %v", *tp.Topic, tp.Partition, info.Error)
+				continue
+			}
+			out[partitionKey{topic: *tp.Topic, partition: tp.Partition}] = int64(info.Offset)
+		}
+		return out, nil
+	}
+	if low, err = query(kafka.EarliestOffsetSpec); err != nil {
+		return nil, nil, err
+	}
+	if high, err = query(kafka.LatestOffsetSpec); err != nil {
+		return nil, nil, err
+	}
+	return low, high, nil
+}
+
+// listCommittedOffsets는 모든 컨슈머 그룹의 검사 대상 파티션 커밋 오프셋을 그룹별로 조회합니다.
+// 오프셋을 조회하지 못한 그룹은 경고를 남기고 결과에서 뺍니다.
+func listCommittedOffsets(ctx context.Context, admin *kafka.AdminClient, partitions map[string][]int32) (map[string]map[partitionKey]int64, error) {
+	groups, err := admin.ListConsumerGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("컨슈머 그룹 목록 조회 실패: %w", err)
+	}
+	out := make(map[string]map[partitionKey]int64, len(groups.Valid))
+	for _, g := range groups.Valid {
+		// 파티션을 지정하지 않으면 그룹이 커밋한 모든 파티션을 돌려받는다.
+		res, err := admin.ListConsumerGroupOffsets(ctx, []kafka.ConsumerGroupTopicPartitions{{Group: g.GroupID}})
+		if err != nil {
+			logger.Log.Warnf("그룹 %s 오프셋 조회 실패: %v. 이번 검사에서 제외합니다.", g.GroupID, err)
+			continue
+		}
+		for _, gp := range res.ConsumerGroupsTopicPartitions {
+			for _, tp := range gp.Partitions {
+				if tp.Topic == nil || tp.Offset < 0 {
+					continue
+				}
+				if _, ok := partitions[*tp.Topic]; !ok {
+					continue
+				}
+				if out[g.GroupID] == nil {
+					out[g.GroupID] = make(map[partitionKey]int64)
+				}
+				out[g.GroupID][partitionKey{topic: *tp.Topic, partition: tp.Partition}] = int64(tp.Offset)
+			}
+		}
+	}
+	return out, nil
+}
+
+// buildLagReport는 조회한 오프셋으로 그룹별 지연과 DLQ 크기를 계산합니다.
+// 그룹이 한 파티션이라도 커밋한 토픽만 그 그룹의 지연으로 보고합니다. prev는 직전 검사의 DLQ 메시지 수입니다.
+// watermark를 조회하지 못한 파티션은 지연 0으로 보이지 않도록 보고에서 빼고, 그런 파티션이 있는 DLQ는 크기를 보고하지 않습니다.
+func buildLagReport(partitions map[string][]int32, committed map[string]map[partitionKey]int64, low, high map[partitionKey]int64, prev map[string]int64) LagReport {
+	var report LagReport
+
+	for group, offsets := range committed {
+		topics := make(map[string]bool)
+		for key := range offsets {
+			topics[key.topic] = true
+		}
+		for topic := range topics {
+			base, kind := topicKind(topic)
+			gl := GroupLag{Group: group, Topic: topic, Base: base, Kind: kind}
+			ps := append([]int32(nil), partitions[topic]...)
+			sort.Slice(ps, func(i, j int) bool { return ps[i] < ps[j] })
+			for _, p := range ps {
+				key := partitionKey{topic: topic, partition: p}
+				if !knownWatermarks(key, low, high) {
+					continue
+				}
+				pl := PartitionLag{Partition: p, Committed: -1, HighWatermark: high[key]}
+				from := low[key]
+				if c, ok := offsets[key]; ok {
+					pl.Committed = c
+					from = max(c, from)
+				}
+				pl.Lag = max(pl.HighWatermark-from, 0)
+				gl.Lag += pl.Lag
+				gl.Partitions = append(gl.Partitions, pl)
+			}
+			if len(gl.Partitions) == 0 {
+				continue
+			}
+			report.Groups = append(report.Groups, gl)
+		}
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Group != report.Groups[j].Group {
+			return report.Groups[i].Group < report.Groups[j].Group
+		}
+		return report.Groups[i].Topic < report.Groups[j].Topic
+	})
+
+	for topic, ps := range partitions {
+		base, kind := topicKind(topic)
+		if kind != TopicKindDLQ {
+			continue
+		}
+		d := DLQSize{Topic: topic, Base: base}
+		known := true
+		for _, p := range ps {
+			key := partitionKey{topic: topic, partition: p}
+			if !knownWatermarks(key, low, high) {
+				known = false
+				break
+			}
+			d.Messages += max(high[key]-low[key], 0)
+		}
+		if !known {
+			continue
+		}
+		if before, ok := prev[topic]; ok {
+			d.Growth = d.Messages - before
+		}
+		report.DLQs = append(report.DLQs, d)
+	}
+	sort.Slice(report.DLQs, func(i, j int) bool { return report.DLQs[i].Topic < report.DLQs[j].Topic })
+	return report
+}
+
+func knownWatermarks(key partitionKey, low, high map[partitionKey]int64) bool {
+	_, lowOK := low[key]
+	_, highOK := high[key]
+	return lowOK && highOK
+}
@@ -0,0 +1,87 @@
+package eventbus
+
+import (
+	"testing"
+)
+
+func TestBuildLagReport(t *testing.T) {
+	const base = "tech-letter.post.summary"
+	retry := base + ".retry.1"
+	dlq := base + ".dlq"
+	pk := func(topic string, p int32) partitionKey { return partitionKey{topic: topic, partition: p} }
+
+	partitions := map[string][]int32{base: {1, 0}, retry: {0}, dlq: {0}}
+	low := map[partitionKey]int64{pk(base, 0): 0, pk(base, 1): 10, pk(retry, 0): 0, pk(dlq, 0): 5}
+	high := map[partitionKey]int64{pk(base, 0): 100, pk(base, 1): 50, pk(retry, 0): 7, pk(dlq, 0): 12}
+	committed := map[string]map[partitionKey]int64{
+		// 파티션 1은 커밋 기록이 없으므로 low watermark(10)부터 남은 것으로 본다.
+		"summary-worker": {pk(base, 0): 40},
+		"retry-worker":   {pk(retry, 0): 7},
+	}
+
+	report := buildLagReport(partitions, committed, low, high, map[string]int64{dlq: 3})
+	if len(report.Groups) != 2 {
+		t.Fatalf("expected 2 group lags, got %+v", report.Groups)
+	}
+	retryLag, summaryLag := report.Groups[0], report.Groups[1]
+	if retryLag.Kind != TopicKindRetry || retryLag.Base != base || retryLag.Lag != 0 {
+		t.Fatalf("unexpected retry lag: %+v", retryLag)
+	}
+	if summaryLag.Kind != TopicKindBase || summaryLag.Lag != 60+40 || len(summaryLag.Partitions) != 2 {
+		t.Fatalf("unexpected base lag: %+v", summaryLag)
+	}
+	if p := summaryLag.Partitions[1]; p.Partition != 1 || p.Committed != -1 || p.Lag != 40 {
+		t.Fatalf("unexpected uncommitted partition lag: %+v", p)
+	}
+	if len(report.DLQs) != 1 || report.DLQs[0].Messages != 7 || report.DLQs[0].Growth != 4 {
+		t.Fatalf("unexpected dlq size: %+v", report.DLQs)
+	}
+
+	thresholds := LagThresholds{Base: 1000, DLQGrowth: 5, Topics: map[string]int64{base: 100}}
+	alerts := thresholds.Evaluate(report)
+	if len(alerts) != 1 || alerts[0].Group != "summary-worker" || alerts[0].Threshold != 100 {
+		t.Fatalf("unexpected alerts: %+v", alerts)
+	}
+}
+
+func TestBuildLagReportSkipsUnknownWatermarks(t *testing.T) {
+	const base = "tech-letter.post.summary"
+	dlq := base + ".dlq"
+	pk := func(topic string, p int32) partitionKey { return partitionKey{topic: topic, partition: p} }
+
+	// 파티션 1과 DLQ는 오프셋 조회에 실패해 watermark가 없다.
+	partitions := map[string][]int32{base: {0, 1}, dlq: {0}}
+	low := map[partitionKey]int64{pk(base, 0): 0, pk(base, 1): 0}
+	high := map[partitionKey]int64{pk(base, 0): 100}
+	committed := map[string]map[partitionKey]int64{"summary-worker": {pk(base, 0): 40, pk(base, 1): 10}}
+
+	report := buildLagReport(partitions, committed, low, high, map[string]int64{dlq: 3})
+	if len(report.Groups) != 1 || len(report.Groups[0].Partitions) != 1 || report.Groups[0].Lag != 60 {
+		t.Fatalf("partitions without watermarks should be left out, got %+v", report.Groups)
+	}
+	if len(report.DLQs) != 0 {
+		t.Fatalf("dlq without watermarks should not be reported, got %+v", report.DLQs)
+	}
+}
+
+func TestLagMonitorNotifiesOnlyNewAlerts(t *testing.T) {
+	var notified []LagAlert
+	m := &LagMonitor{OnAlert: func(a LagAlert) { notified = append(notified, a) }}
+	a := LagAlert{Group: "g", Topic: "t", Kind: TopicKindBase, Value: 10, Threshold: 5}
+	b := LagAlert{Topic: "t.dlq", Kind: TopicKindDLQ, Value: 3, Threshold: 1}
+
+	m.notify([]LagAlert{a})
+	m.notify([]LagAlert{a, b})
+	m.notify(nil)
+	m.notify([]LagAlert{a})
+	if len(notified) != 3 || notified[1] != b || notified[2] != a {
+		t.Fatalf("unexpected notifications: %+v", notified)
+	}
+}
+
+func TestParseTopicThresholds(t *testing.T) {
+	got := parseTopicThresholds("TEST", "a=1, b = 20,bad,c=x")
+	if len(got) != 2 || got["a"] != 1 || got["b"] != 20 {
+		t.Fatalf("unexpected thresholds: %+v", got)
+	}
+}
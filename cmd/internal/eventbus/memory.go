@@ -0,0 +1,321 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// ErrBusClosed는 이미 종료된 EventBus를 사용하려 할 때 반환되는 오류입니다.
+var ErrBusClosed = errors.New("이벤트 버스가 종료됨")
+
+// memoryMessage는 인메모리 토픽에 저장되는 단일 메시지입니다.
+// Kafka와 동일하게 직렬화된 바이트를 보관하여 인코딩/디코딩 경로를 그대로 검증합니다.
+type memoryMessage struct {
+	key       string
+	value     []byte
+	headers   []kafka.Header
+	offset    int64
+	timestamp time.Time
+}
+
+// MemoryEventBus는 브로커 없이 프로세스 내부에서 동작하는 EventBus 구현체입니다.
+// 테스트와 로컬 개발 용도로 사용하며, 재시도 토픽(.retry.N), 토픽별 RetryPolicy 지연,
+// DLQ 토픽(.dlq) 흐름을 KafkaEventBus와 동일하게 흉내 냅니다.
+// 시간은 주입된 Clock을 기준으로 흐르므로 ManualClock으로 재시도 시점을 제어할 수 있습니다.
+type MemoryEventBus struct {
+	clock Clock
+	// Schemas는 발행/구독 시 페이로드를 검사할 카탈로그입니다. nil이면 검사하지 않습니다.
+	Schemas *SchemaCatalog
+	// Envelope는 발행 시 사용할 봉투 형식입니다. nil이면 기본 JSON 봉투를 사용합니다.
+	Envelope EnvelopeCodec
+	// ClaimCheck는 큰 페이로드를 블롭 저장소로 분리하는 설정입니다. nil이면 분리하지 않습니다.
+	ClaimCheck *ClaimCheck
+	// Middlewares는 이 버스의 모든 구독에 적용할 미들웨어입니다. 기본값은 Recover()이며 Use로 추가합니다.
+	Middlewares []Middleware
+
+	mu      sync.Mutex
+	topics  map[string][]memoryMessage
+	offsets map[string]int // key: groupID + "/" + topic
+	notify  chan struct{}
+	closed  bool
+
+	// rateLimits는 WithRateLimit을 지정한 구독들이 토픽별로 공유하는 토큰 버킷입니다.
+	rateLimits rateLimiterSet
+}
+
+// NewMemoryEventBus는 인메모리 EventBus를 생성합니다. clock이 nil이면 SystemClock을 사용합니다.
+func NewMemoryEventBus(clock Clock) *MemoryEventBus {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &MemoryEventBus{
+		clock:       clock,
+		Schemas:     DefaultSchemas,
+		Middlewares: []Middleware{Recover()},
+		topics:      make(map[string][]memoryMessage),
+		offsets:     make(map[string]int),
+		notify:      make(chan struct{}),
+	}
+}
+
+// Use는 이 버스의 모든 구독에 적용할 미들웨어를 추가합니다. Subscribe 호출 전에 설정해야 합니다.
+func (m *MemoryEventBus) Use(mws ...Middleware) {
+	m.Middlewares = append(m.Middlewares, mws...)
+}
+
+// Close는 버스를 종료하고 대기 중인 구독자를 모두 깨웁니다.
+func (m *MemoryEventBus) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return
+	}
+	m.closed = true
+	close(m.notify)
+}
+
+// Publish는 지정된 토픽에 이벤트를 추가합니다. 메시지 타임스탬프는 Clock 기준 현재 시각입니다.
+// 카탈로그에 등록된 토픽이면 페이로드 스키마를 먼저 검사합니다.
+func (m *MemoryEventBus) Publish(ctx context.Context, topic string, event Event) error {
+	_, err := m.PublishAsync(ctx, topic, event).Wait(ctx)
+	return err
+}
+
+func (m *MemoryEventBus) publish(ctx context.Context, topic string, event Event, headers []kafka.Header) error {
+	return m.append(ctx, topic, event, headers).Err
+}
+
+// PublishAsync는 Publish와 같지만 결과를 PublishFuture로 반환합니다. 메모리 구현은 항상 즉시 완료됩니다.
+func (m *MemoryEventBus) PublishAsync(ctx context.Context, topic string, event Event) *PublishFuture {
+	event, err := m.Schemas.Prepare(topic, event)
+	if err == nil {
+		event, err = m.ClaimCheck.offload(ctx, topic, event)
+	}
+	if err != nil {
+		return completedFuture(PublishResult{EventID: event.ID, Topic: topic, Partition: -1, Offset: -1, Err: err})
+	}
+	return completedFuture(m.append(ctx, topic, event, traceHeadersFromContext(ctx)))
+}
+
+// PublishBatch는 events를 순서대로 발행하고 이벤트별 결과를 반환합니다.
+// 일부라도 실패하면 *BatchPublishError를 함께 반환합니다.
+func (m *MemoryEventBus) PublishBatch(ctx context.Context, topic string, events []Event) ([]PublishResult, error) {
+	futures := make([]*PublishFuture, len(events))
+	for i, event := range events {
+		futures[i] = m.PublishAsync(ctx, topic, event)
+	}
+	return waitBatch(ctx, futures)
+}
+
+// append는 이벤트를 토픽 끝에 추가하고 발행 결과를 반환합니다.
+func (m *MemoryEventBus) append(ctx context.Context, topic string, event Event, headers []kafka.Header) PublishResult {
+	result := PublishResult{EventID: event.ID, Topic: topic, Partition: -1, Offset: -1}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return result
+	}
+	data, headers, err := encodeEnvelope(m.Envelope, event, headers)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	return m.appendRaw(topic, event.ID, data, headers)
+}
+
+// appendRaw는 이미 인코딩된 값을 토픽 끝에 추가합니다. value가 nil이면 톰스톤이 됩니다.
+func (m *MemoryEventBus) appendRaw(topic, key string, value []byte, headers []kafka.Header) PublishResult {
+	result := PublishResult{EventID: key, Topic: topic, Partition: -1, Offset: -1}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		result.Err = ErrBusClosed
+		return result
+	}
+	offset := int64(len(m.topics[topic]))
+	m.topics[topic] = append(m.topics[topic], memoryMessage{
+		key:       key,
+		value:     value,
+		headers:   headers,
+		offset:    offset,
+		timestamp: m.clock.Now(),
+	})
+	m.broadcastLocked()
+	result.Partition = 0
+	result.Offset = offset
+	return result
+}
+
+// Subscribe는 기본 토픽을 구독하고 메인 비즈니스 핸들러를 실행합니다.
+// 핸들러가 실패하면 KafkaEventBus와 동일하게 재시도 토픽 또는 DLQ로 이벤트를 발행합니다.
+// 테스트 결정성을 위해 WithConcurrency는 무시하고 항상 순차적으로 처리합니다.
+func (m *MemoryEventBus) Subscribe(ctx context.Context, groupID string, topic Topic, handler EventHandler, opts ...SubscribeOption) error {
+	options := newSubscribeOptions(opts)
+	handler = options.wrapHandler(groupID, handler)
+	handler = schemaHandler(m.Schemas, topic.Base(), handler)
+	handler = claimCheckHandler(m.ClaimCheck, handler)
+	handler = options.wrapMiddlewares(m.Middlewares, handler)
+	var limiter *rateLimiter
+	if options.rateLimit != nil {
+		limiter = m.rateLimits.get(topic.Base(), *options.rateLimit, m.clock, nil)
+	}
+
+	for {
+		msg, topicName, err := m.next(ctx, groupID, []string{topic.Base()}, nil)
+		if err != nil {
+			return err
+		}
+
+		evt, err := DecodeEnvelope(msg.value, msg.headers)
+		if err != nil {
+			logger.Log.Errorf("토픽 %s의 이벤트 페이로드 오류: %v. 메시지를 건너뜁니다.", topicName, err)
+			continue
+		}
+		topic.normalizeMaxRetry(&evt)
+
+		handlerCtx := withHandlerInfo(contextWithTraceHeaders(ctx, msg.headers), HandlerInfo{
+			GroupID:   groupID,
+			BaseTopic: topic.Base(),
+			Topic:     topicName,
+			Offset:    msg.offset,
+		})
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		if err := handler(handlerCtx, evt); err != nil {
+			route := routeFailure(topic, evt, err, failureSource{
+				GroupID: groupID,
+				Topic:   topicName,
+				Offset:  msg.offset,
+				At:      m.clock.Now(),
+			})
+			if publishErr := m.publish(ctx, route.Topic, route.Event, traceHeaders(msg.headers)); publishErr != nil {
+				return fmt.Errorf("%w: %v", ErrRetryScheduleFailed, publishErr)
+			}
+			// 재시도/DLQ로 보낸 이벤트는 참조만 넘기므로 블롭을 남겨 둔다.
+			continue
+		}
+		// 처리에 성공한 이벤트의 블롭은 더 이상 필요 없다.
+		m.ClaimCheck.release(ctx, groupID, evt)
+	}
+}
+
+// StartRetryReinjector는 모든 재시도 토픽을 구독하고, 지연 시간이 지난 이벤트를 기본 토픽으로 재발행합니다.
+func (m *MemoryEventBus) StartRetryReinjector(ctx context.Context, groupID string, topic Topic) error {
+	ready := func(topicName string, msg memoryMessage) (time.Time, bool) {
+		delay, ok := topic.RetryDelayForTopic(topicName, []byte(msg.key))
+		if !ok {
+			return time.Time{}, true
+		}
+		readyAt := msg.timestamp.Add(delay)
+		return readyAt, !m.clock.Now().Before(readyAt)
+	}
+
+	for {
+		msg, topicName, err := m.next(ctx, groupID, topic.GetRetryTopics(), ready)
+		if err != nil {
+			return err
+		}
+
+		evt, err := DecodeEnvelope(msg.value, msg.headers)
+		if err != nil {
+			logger.Log.Errorf("재시도 토픽 %s의 이벤트 페이로드 오류: %v. 메시지를 건너뜁니다.", topicName, err)
+			continue
+		}
+
+		if err := m.publish(ctx, topic.Base(), evt, traceHeaders(msg.headers)); err != nil {
+			return fmt.Errorf("이벤트 %s 재주입 실패: %w", evt.ID, err)
+		}
+	}
+}
+
+// Events는 토픽에 발행된 모든 이벤트를 발행 순서대로 반환합니다. 테스트 검증용입니다.
+func (m *MemoryEventBus) Events(topic string) []Event {
+	m.mu.Lock()
+	msgs := append([]memoryMessage(nil), m.topics[topic]...)
+	m.mu.Unlock()
+
+	events := make([]Event, 0, len(msgs))
+	for _, msg := range msgs {
+		evt, err := DecodeEnvelope(msg.value, msg.headers)
+		if err != nil {
+			continue
+		}
+		events = append(events, evt)
+	}
+	return events
+}
+
+// next는 groupID 기준으로 topics 중 처리 가능한 다음 메시지를 가져오고 오프셋을 전진시킵니다.
+// ready가 주어지면 각 토픽의 선두 메시지가 준비될 때까지(지연 시간 경과) 기다립니다.
+// 토픽 내 메시지는 타임스탬프 순이므로 선두 메시지만 확인해도 됩니다.
+func (m *MemoryEventBus) next(
+	ctx context.Context,
+	groupID string,
+	topics []string,
+	ready func(topicName string, msg memoryMessage) (time.Time, bool),
+) (memoryMessage, string, error) {
+	for {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return memoryMessage{}, "", ErrBusClosed
+		}
+
+		var earliest time.Time
+		for _, name := range topics {
+			key := groupID + "/" + name
+			offset := m.offsets[key]
+			msgs := m.topics[name]
+			if offset >= len(msgs) {
+				continue
+			}
+			msg := msgs[offset]
+			if ready != nil {
+				readyAt, ok := ready(name, msg)
+				if !ok {
+					if earliest.IsZero() || readyAt.Before(earliest) {
+						earliest = readyAt
+					}
+					continue
+				}
+			}
+			m.offsets[key] = offset + 1
+			m.mu.Unlock()
+			return msg, name, nil
+		}
+		notify := m.notify
+		m.mu.Unlock()
+
+		var timer Timer
+		var timerC <-chan time.Time
+		if !earliest.IsZero() {
+			timer = m.clock.NewTimer(earliest)
+			timerC = timer.C()
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-notify:
+		case <-timerC:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err := ctx.Err(); err != nil {
+			return memoryMessage{}, "", err
+		}
+	}
+}
+
+// broadcastLocked는 새 메시지 도착을 대기 중인 모든 구독자에게 알립니다. mu를 보유한 상태에서 호출해야 합니다.
+func (m *MemoryEventBus) broadcastLocked() {
+	close(m.notify)
+	m.notify = make(chan struct{})
+}
@@ -0,0 +1,217 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"tech-letter/cmd/internal/trace"
+)
+
+var _ EventBus = (*MemoryEventBus)(nil)
+
+// waitFor는 cond가 참이 될 때까지 짧게 폴링합니다. 고루틴 기반 구독자의 처리를 기다리는 용도입니다.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met within timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type testPayload struct {
+	PostID string `json:"post_id"`
+}
+
+func TestMemoryEventBusSubscribeJSONDecodesPayload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewMemoryEventBus(NewManualClock(time.Unix(0, 0)))
+	defer bus.Close()
+
+	topic := NewTopic("test.memory.json")
+	received := make(chan testPayload, 1)
+	go SubscribeJSON(ctx, bus, "group", topic, func(ctx context.Context, payload testPayload, meta Event) error {
+		received <- payload
+		return nil
+	})
+
+	evt, err := NewTopicJSONEvent(topic, "evt-1", testPayload{PostID: "post-1"}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got.PostID != "post-1" {
+			t.Fatalf("expected post-1, got %q", got.PostID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("handler was not called")
+	}
+}
+
+func TestMemoryEventBusRetriesAfterDelay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewManualClock(time.Unix(0, 0))
+	bus := NewMemoryEventBus(clock)
+	defer bus.Close()
+
+	topic := NewTopic("test.memory.retry")
+	var calls atomic.Int32
+	go bus.Subscribe(ctx, "group", topic, func(ctx context.Context, evt Event) error {
+		if calls.Add(1) == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	go bus.StartRetryReinjector(ctx, "retry-group", topic)
+
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", testPayload{PostID: "post-1"}, 0)
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+
+	retryTopic, _ := topic.GetRetryTopic(1)
+	waitFor(t, func() bool { return len(bus.Events(retryTopic)) == 1 })
+
+	// 지연 시간이 지나기 전에는 재주입되지 않아야 한다.
+	clock.Advance(RetryDelays[0] - time.Second)
+	time.Sleep(20 * time.Millisecond)
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 call before delay elapsed, got %d", got)
+	}
+
+	clock.Advance(time.Second)
+	waitFor(t, func() bool { return calls.Load() == 2 })
+
+	if got := bus.Events(retryTopic)[0]; got.Retry != 1 || got.LastError != "temporary failure" {
+		t.Fatalf("unexpected retry event: %+v", got)
+	}
+	if got := len(bus.Events(topic.DLQ())); got != 0 {
+		t.Fatalf("expected empty DLQ, got %d events", got)
+	}
+}
+
+func TestMemoryEventBusRoutesToDLQAfterAllRetries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewManualClock(time.Unix(0, 0))
+	bus := NewMemoryEventBus(clock)
+	defer bus.Close()
+
+	topic := NewTopic("test.memory.dlq")
+	go bus.Subscribe(ctx, "group", topic, func(ctx context.Context, evt Event) error {
+		return errors.New("always fails")
+	})
+	go bus.StartRetryReinjector(ctx, "retry-group", topic)
+
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", testPayload{PostID: "post-1"}, 0)
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+
+	for i, delay := range RetryDelays {
+		retryTopic, _ := topic.GetRetryTopic(i + 1)
+		waitFor(t, func() bool { return len(bus.Events(retryTopic)) == 1 })
+		clock.Advance(delay)
+	}
+
+	waitFor(t, func() bool { return len(bus.Events(topic.DLQ())) == 1 })
+	dlq := bus.Events(topic.DLQ())[0]
+	if dlq.ID != "evt-1" || dlq.Retry != len(RetryDelays) || dlq.LastError != "always fails" {
+		t.Fatalf("unexpected DLQ event: %+v", dlq)
+	}
+
+	envelope := dlq.DLQ
+	if envelope == nil {
+		t.Fatalf("expected DLQ envelope")
+	}
+	if envelope.Reason != DLQReasonMaxRetryExceeded || envelope.ConsumerGroup != "group" || envelope.OriginalTopic != topic.Base() {
+		t.Fatalf("unexpected DLQ envelope: %+v", envelope)
+	}
+	if got := len(envelope.Errors); got != len(RetryDelays)+1 {
+		t.Fatalf("expected %d recorded failures, got %d", len(RetryDelays)+1, got)
+	}
+	var total time.Duration
+	for _, d := range RetryDelays {
+		total += d
+	}
+	if got := envelope.LastFailedAt.Sub(envelope.FirstFailedAt); got != total {
+		t.Fatalf("expected failures to span %s, got %s", total, got)
+	}
+}
+
+func TestMemoryEventBusCloseStopsSubscribers(t *testing.T) {
+	bus := NewMemoryEventBus(nil)
+	done := make(chan error, 1)
+	go func() {
+		done <- bus.Subscribe(context.Background(), "group", NewTopic("test.memory.close"), func(ctx context.Context, evt Event) error {
+			return nil
+		})
+	}()
+
+	bus.Close()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrBusClosed) {
+			t.Fatalf("expected ErrBusClosed, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("subscriber did not stop after Close")
+	}
+}
+
+func TestMemoryEventBusPropagatesTraceAcrossRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewManualClock(time.Unix(0, 0))
+	bus := NewMemoryEventBus(clock)
+	defer bus.Close()
+
+	topic := NewTopic("test.memory.trace")
+	requestIDs := make(chan string, 2)
+	var calls atomic.Int32
+	go bus.Subscribe(ctx, "group", topic, func(ctx context.Context, evt Event) error {
+		requestIDs <- trace.RequestIDFromContext(ctx)
+		if calls.Add(1) == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	go bus.StartRetryReinjector(ctx, "retry-group", topic)
+
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", testPayload{PostID: "post-1"}, 0)
+	publishCtx := trace.WithRequestAndSpan(ctx, "req-123", 0)
+	if err := bus.Publish(publishCtx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+
+	retryTopic, _ := topic.GetRetryTopic(1)
+	waitFor(t, func() bool { return len(bus.Events(retryTopic)) == 1 })
+	clock.Advance(RetryDelays[0])
+
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-requestIDs:
+			if got != "req-123" {
+				t.Fatalf("attempt %d: expected request id req-123, got %q", i+1, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("attempt %d: handler was not called", i+1)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package eventbus
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics는 이벤트 버스의 발행/처리/재시도 흐름을 토픽별로 집계하는 Prometheus 수집기 묶음입니다.
+// KafkaEventBus는 기본적으로 DefaultMetrics에 기록하며, 이벤트 버스를 사용하는 Go 프로세스는
+// RegisterMetrics로 같은 수집기를 자신의 레지스트리에 등록해 노출할 수 있습니다.
+type Metrics struct {
+	Published        *prometheus.CounterVec
+	PublishFailures  *prometheus.CounterVec
+	HandlerSuccesses *prometheus.CounterVec
+	HandlerFailures  *prometheus.CounterVec
+	RetriesScheduled *prometheus.CounterVec
+	DLQRouted        *prometheus.CounterVec
+	Reinjected       *prometheus.CounterVec
+	HandlerDuration  *prometheus.HistogramVec
+	// 아래 지표는 MetricsMiddleware를 적용한 구독에서만 기록됩니다.
+	HandlerInFlight *prometheus.GaugeVec
+	HandlerPanics   *prometheus.CounterVec
+	HandlerTimeouts *prometheus.CounterVec
+	// ScheduledPending과 ScheduledFired는 StartScheduler를 실행하는 프로세스(retryworker)에서만 기록됩니다.
+	ScheduledPending prometheus.Gauge
+	ScheduledFired   *prometheus.CounterVec
+	// ConsumerLag, DLQMessages, LagAlerts는 LagMonitor를 실행하는 프로세스(retryworker)에서만 기록됩니다.
+	ConsumerLag *prometheus.GaugeVec
+	DLQMessages *prometheus.GaugeVec
+	LagAlerts   *prometheus.CounterVec
+	// CircuitBreakerState는 WithCircuitBreaker를 적용한 구독에서만 기록됩니다 (closed=0, half-open=1, open=2).
+	CircuitBreakerState *prometheus.GaugeVec
+	// RateLimitWait는 속도 제한(WithRateLimit)으로 처리를 기다린 시간(초)입니다.
+	RateLimitWait *prometheus.CounterVec
+}
+
+const metricsNamespace = "techletter"
+const metricsSubsystem = "eventbus"
+
+// NewMetrics는 레지스트리에 등록되지 않은 새 Metrics를 생성합니다.
+func NewMetrics() *Metrics {
+	counter := func(name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      name,
+			Help:      help,
+		}, labels)
+	}
+	return &Metrics{
+		Published:        counter("published_total", "발행에 성공한 이벤트 수", "topic"),
+		PublishFailures:  counter("publish_failures_total", "발행에 실패한 이벤트 수", "topic"),
+		HandlerSuccesses: counter("handler_successes_total", "핸들러 처리에 성공한 이벤트 수", "topic", "group"),
+		HandlerFailures:  counter("handler_failures_total", "핸들러 처리에 실패한 이벤트 수", "topic", "group"),
+		RetriesScheduled: counter("retries_scheduled_total", "재시도 토픽으로 예약된 이벤트 수", "topic", "retry_topic"),
+		DLQRouted:        counter("dlq_routed_total", "DLQ로 보낸 이벤트 수", "topic", "reason"),
+		Reinjected:       counter("reinjected_total", "재시도 토픽에서 기본 토픽으로 재주입된 이벤트 수", "topic", "retry_topic"),
+		HandlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "handler_duration_seconds",
+			Help:      "핸들러 처리 시간(초)",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 15, 30, 60, 120, 300},
+		}, []string{"topic", "group"}),
+		HandlerInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "handler_in_flight",
+			Help:      "처리 중인 핸들러 수",
+		}, []string{"topic", "group"}),
+		HandlerPanics:   counter("handler_panics_total", "핸들러 패닉 수", "topic", "group"),
+		HandlerTimeouts: counter("handler_timeouts_total", "핸들러 제한 시간 초과 수", "topic", "group"),
+		ScheduledPending: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "scheduled_pending",
+			Help:      "발행을 기다리는 예약 이벤트 수",
+		}),
+		ScheduledFired: counter("scheduled_fired_total", "예약 시각에 발행된 이벤트 수", "topic"),
+		ConsumerLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "consumer_lag",
+			Help:      "컨슈머 그룹이 아직 처리하지 않은 메시지 수(high watermark - 커밋 오프셋)",
+		}, []string{"group", "topic", "kind"}),
+		DLQMessages: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "dlq_messages",
+			Help:      "DLQ 토픽에 남아 있는 메시지 수",
+		}, []string{"topic"}),
+		LagAlerts: counter("lag_alerts_total", "지연/DLQ 증가 임계값을 넘은 횟수", "group", "topic", "kind"),
+		CircuitBreakerState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "circuit_breaker_state",
+			Help:      "구독별 차단기 상태 (closed=0, half-open=1, open=2)",
+		}, []string{"topic", "group"}),
+		RateLimitWait: counter("rate_limit_wait_seconds_total", "속도 제한으로 처리를 기다린 시간(초)", "topic"),
+	}
+}
+
+// DefaultMetrics는 KafkaEventBus가 별도 지정이 없을 때 기록하는 프로세스 전역 Metrics입니다.
+var DefaultMetrics = NewMetrics()
+
+// Collectors는 등록 대상 수집기 목록을 반환합니다.
+func (m *Metrics) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.Published,
+		m.PublishFailures,
+		m.HandlerSuccesses,
+		m.HandlerFailures,
+		m.RetriesScheduled,
+		m.DLQRouted,
+		m.Reinjected,
+		m.HandlerDuration,
+		m.HandlerInFlight,
+		m.HandlerPanics,
+		m.HandlerTimeouts,
+		m.ScheduledPending,
+		m.ScheduledFired,
+		m.ConsumerLag,
+		m.DLQMessages,
+		m.LagAlerts,
+		m.CircuitBreakerState,
+		m.RateLimitWait,
+	}
+}
+
+// Register는 수집기를 reg에 등록합니다. 이미 등록된 수집기는 오류로 보지 않습니다.
+func (m *Metrics) Register(reg prometheus.Registerer) error {
+	for _, c := range m.Collectors() {
+		if err := reg.Register(c); err != nil {
+			var already prometheus.AlreadyRegisteredError
+			if errors.As(err, &already) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterMetrics는 DefaultMetrics를 reg에 등록합니다.
+// reg가 nil이면 prometheus.DefaultRegisterer를 사용합니다.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	return DefaultMetrics.Register(reg)
+}
+
+// 아래 기록 함수는 Metrics가 nil이면 아무 것도 하지 않습니다.
+
+func (m *Metrics) observePublish(topic string, err error) {
+	if m == nil {
+		return
+	}
+	if err != nil {
+		m.PublishFailures.WithLabelValues(topic).Inc()
+		return
+	}
+	m.Published.WithLabelValues(topic).Inc()
+}
+
+func (m *Metrics) observeHandler(topic, groupID string, elapsed time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.HandlerDuration.WithLabelValues(topic, groupID).Observe(elapsed.Seconds())
+	if err != nil {
+		m.HandlerFailures.WithLabelValues(topic, groupID).Inc()
+		return
+	}
+	m.HandlerSuccesses.WithLabelValues(topic, groupID).Inc()
+}
+
+func (m *Metrics) observeRoute(topic string, route failureRoute) {
+	if m == nil {
+		return
+	}
+	if route.DLQ {
+		reason := ""
+		if route.Event.DLQ != nil {
+			reason = route.Event.DLQ.Reason
+		}
+		m.DLQRouted.WithLabelValues(topic, reason).Inc()
+		return
+	}
+	m.RetriesScheduled.WithLabelValues(topic, route.Topic).Inc()
+}
+
+func (m *Metrics) observeReinject(topic, retryTopic string) {
+	if m == nil {
+		return
+	}
+	m.Reinjected.WithLabelValues(topic, retryTopic).Inc()
+}
+
+func (m *Metrics) observeScheduled(pending int) {
+	if m == nil {
+		return
+	}
+	m.ScheduledPending.Set(float64(pending))
+}
+
+func (m *Metrics) observeScheduledFired(topic string) {
+	if m == nil {
+		return
+	}
+	m.ScheduledFired.WithLabelValues(topic).Inc()
+}
+
+// observeLag는 지연 지표를 report 기준으로 다시 채웁니다. 사라진 그룹/토픽의 값이 남지 않도록 먼저 비웁니다.
+func (m *Metrics) observeLag(report LagReport) {
+	if m == nil {
+		return
+	}
+	m.ConsumerLag.Reset()
+	for _, g := range report.Groups {
+		m.ConsumerLag.WithLabelValues(g.Group, g.Topic, string(g.Kind)).Set(float64(g.Lag))
+	}
+	m.DLQMessages.Reset()
+	for _, d := range report.DLQs {
+		m.DLQMessages.WithLabelValues(d.Topic).Set(float64(d.Messages))
+	}
+}
+
+func (m *Metrics) observeLagAlert(alert LagAlert) {
+	if m == nil {
+		return
+	}
+	m.LagAlerts.WithLabelValues(alert.Group, alert.Topic, string(alert.Kind)).Inc()
+}
+
+func (m *Metrics) observeCircuitState(topic, groupID string, state CircuitState) {
+	if m == nil {
+		return
+	}
+	m.CircuitBreakerState.WithLabelValues(topic, groupID).Set(state.metricValue())
+}
+
+func (m *Metrics) observeRateLimitWait(topic string, wait time.Duration) {
+	if m == nil {
+		return
+	}
+	m.RateLimitWait.WithLabelValues(topic).Add(wait.Seconds())
+}
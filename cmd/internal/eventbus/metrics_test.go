@@ -0,0 +1,62 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsObserve(t *testing.T) {
+	m := NewMetrics()
+	topic := NewTopic("tech-letter.test")
+
+	m.observePublish(topic.Base(), nil)
+	m.observePublish(topic.Base(), errors.New("boom"))
+	m.observeHandler(topic.Base(), "group", 20*time.Millisecond, errors.New("fail"))
+	m.observeHandler(topic.Base(), "group", 10*time.Millisecond, nil)
+
+	evt := Event{ID: "evt-1", MaxRetry: topic.RetryPolicy().Attempts()}
+	m.observeRoute(topic.Base(), routeFailure(topic, evt, errors.New("fail"), failureSource{}))
+	m.observeRoute(topic.Base(), routeFailure(topic, evt, Permanent(errors.New("bad")), failureSource{}))
+	m.observeReinject(topic.Base(), "tech-letter.test.retry.1")
+
+	checks := []struct {
+		name string
+		c    prometheus.Collector
+		want float64
+	}{
+		{"published", m.Published.WithLabelValues(topic.Base()), 1},
+		{"publish failures", m.PublishFailures.WithLabelValues(topic.Base()), 1},
+		{"handler successes", m.HandlerSuccesses.WithLabelValues(topic.Base(), "group"), 1},
+		{"handler failures", m.HandlerFailures.WithLabelValues(topic.Base(), "group"), 1},
+		{"retries", m.RetriesScheduled.WithLabelValues(topic.Base(), "tech-letter.test.retry.1"), 1},
+		{"dlq", m.DLQRouted.WithLabelValues(topic.Base(), DLQReasonPermanentError), 1},
+		{"reinjected", m.Reinjected.WithLabelValues(topic.Base(), "tech-letter.test.retry.1"), 1},
+	}
+	for _, c := range checks {
+		if got := testutil.ToFloat64(c.c); got != c.want {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+	if n := testutil.CollectAndCount(m.HandlerDuration); n != 1 {
+		t.Fatalf("expected 1 histogram series, got %d", n)
+	}
+
+	// nil Metrics는 기록을 건너뛴다.
+	var nilMetrics *Metrics
+	nilMetrics.observePublish(topic.Base(), nil)
+}
+
+func TestMetricsRegisterTwice(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics()
+	if err := m.Register(reg); err != nil {
+		t.Fatalf("first register: %v", err)
+	}
+	if err := m.Register(reg); err != nil {
+		t.Fatalf("second register should be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"tech-letter/cmd/internal/logger"
+	"tech-letter/cmd/internal/trace"
+)
+
+// Middleware는 EventHandler를 감싸 공통 처리(패닉 복구, 제한 시간, 로깅 등)를 덧붙입니다.
+// HTTP 미들웨어와 같은 방식으로, next를 호출하기 전후에 원하는 처리를 넣습니다.
+//
+// 버스 전역 미들웨어(KafkaEventBus.Use/MemoryEventBus.Use)가 가장 바깥에서, 그다음 구독별 미들웨어(WithMiddleware)가
+// 실행되고, 그 안쪽에서 클레임 체크/스키마 검사/중복 제거를 거쳐 실제 핸들러가 호출됩니다.
+type Middleware func(next EventHandler) EventHandler
+
+// Chain은 handler에 미들웨어를 적용합니다. 첫 번째 미들웨어가 가장 바깥에서 실행됩니다.
+func Chain(handler EventHandler, mws ...Middleware) EventHandler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		if mws[i] != nil {
+			handler = mws[i](handler)
+		}
+	}
+	return handler
+}
+
+// ErrHandlerPanic은 핸들러 패닉을 Recover 미들웨어가 오류로 바꿀 때 감싸는 오류입니다.
+// 영구 오류가 아니므로 일반 실패와 같이 재시도 토픽으로 보내집니다.
+var ErrHandlerPanic = errors.New("핸들러 패닉")
+
+// ErrHandlerTimeout은 Timeout 미들웨어의 제한 시간이 지난 뒤 핸들러가 실패했을 때 감싸는 오류입니다.
+var ErrHandlerTimeout = errors.New("핸들러 제한 시간 초과")
+
+// HandlerInfo는 핸들러가 처리 중인 메시지의 위치입니다. 버스가 핸들러 컨텍스트에 넣어 주며 미들웨어가 로그/지표에 사용합니다.
+type HandlerInfo struct {
+	GroupID   string
+	BaseTopic string
+	// Topic은 메시지를 읽은 실제 토픽입니다. 재주입된 이벤트도 기본 토픽에서 읽으므로 보통 BaseTopic과 같습니다.
+	Topic     string
+	Partition int32
+	Offset    int64
+}
+
+type handlerInfoKey struct{}
+
+func withHandlerInfo(ctx context.Context, info HandlerInfo) context.Context {
+	return context.WithValue(ctx, handlerInfoKey{}, info)
+}
+
+// HandlerInfoFromContext는 핸들러 컨텍스트에서 처리 중인 메시지의 위치를 꺼냅니다.
+func HandlerInfoFromContext(ctx context.Context) (HandlerInfo, bool) {
+	info, ok := ctx.Value(handlerInfoKey{}).(HandlerInfo)
+	return info, ok
+}
+
+// Recover는 핸들러 패닉을 ErrHandlerPanic 오류로 바꿔 재시도 경로로 넘깁니다.
+// 패닉 하나로 컨슈머 프로세스 전체가 죽지 않도록, 새로 만든 버스에는 기본으로 설정되어 있습니다.
+func Recover() Middleware {
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, evt Event) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					info, _ := HandlerInfoFromContext(ctx)
+					logger.ErrorWithFields(fmt.Sprintf("이벤트 %s 처리 중 패닉: %v", evt.ID, r), traceLogFields(ctx, logger.Fields{
+						"event_id": evt.ID,
+						"topic":    info.Topic,
+						"group":    info.GroupID,
+						"retry":    evt.Retry,
+						"stack":    string(debug.Stack()),
+					}))
+					err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+				}
+			}()
+			return next(ctx, evt)
+		}
+	}
+}
+
+// Timeout은 핸들러 컨텍스트에 제한 시간 d를 겁니다. 핸들러는 ctx를 따라 작업을 중단해야 하며,
+// 제한 시간이 지난 뒤 실패하면 ErrHandlerTimeout으로 감싸 재시도 경로로 넘깁니다. d가 0 이하이면 아무 것도 하지 않습니다.
+func Timeout(d time.Duration) Middleware {
+	return func(next EventHandler) EventHandler {
+		if d <= 0 {
+			return next
+		}
+		return func(ctx context.Context, evt Event) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			err := next(ctx, evt)
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && !errors.Is(err, ErrHandlerTimeout) {
+				return fmt.Errorf("%w (%s): %w", ErrHandlerTimeout, d, err)
+			}
+			return err
+		}
+	}
+}
+
+// Logging은 핸들러 처리 결과를 이벤트 ID, 재시도 횟수, 토픽, 컨슈머 그룹, 처리 시간과 함께 구조화 로그로 남깁니다.
+// 성공은 debug, 실패는 error 레벨입니다.
+func Logging() Middleware {
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, evt Event) error {
+			started := time.Now()
+			err := next(ctx, evt)
+
+			info, _ := HandlerInfoFromContext(ctx)
+			fields := traceLogFields(ctx, logger.Fields{
+				"event_id":   evt.ID,
+				"retry":      evt.Retry,
+				"topic":      info.Topic,
+				"group":      info.GroupID,
+				"partition":  info.Partition,
+				"offset":     info.Offset,
+				"elapsed_ms": time.Since(started).Milliseconds(),
+			})
+			if err != nil {
+				fields["error"] = err.Error()
+				logger.ErrorWithFields(fmt.Sprintf("이벤트 %s 처리 실패", evt.ID), fields)
+				return err
+			}
+			logger.DebugWithFields(fmt.Sprintf("이벤트 %s 처리 완료", evt.ID), fields)
+			return nil
+		}
+	}
+}
+
+// Tracing은 트레이싱 헤더 없이 들어온 이벤트(다른 언어의 발행자 등)에 새 Request ID를 부여해,
+// 핸들러 안의 로그와 후속 발행이 같은 트레이스로 묶이게 합니다. 헤더가 있으면 발행 측 트레이스를 그대로 이어갑니다.
+func Tracing() Middleware {
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, evt Event) error {
+			if trace.RequestIDFromContext(ctx) == "" {
+				ctx = trace.WithRequestAndSpan(ctx, trace.GenerateID(), 0)
+			}
+			return next(ctx, evt)
+		}
+	}
+}
+
+// MetricsMiddleware는 처리 중인 핸들러 수와 패닉/제한 시간 초과 횟수를 m에 기록합니다.
+// 처리 시간과 성공/실패 수는 버스가 항상 기록하므로 여기서는 다루지 않습니다. m이 nil이면 DefaultMetrics를 사용합니다.
+// Recover의 안쪽과 바깥쪽 어디에 두어도 패닉을 한 번씩 셉니다.
+func MetricsMiddleware(m *Metrics) Middleware {
+	if m == nil {
+		m = DefaultMetrics
+	}
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, evt Event) error {
+			info, _ := HandlerInfoFromContext(ctx)
+			inFlight := m.HandlerInFlight.WithLabelValues(info.BaseTopic, info.GroupID)
+			inFlight.Inc()
+			defer func() {
+				inFlight.Dec()
+				// Recover보다 안쪽에 있으면 패닉을 직접 보게 되므로 기록한 뒤 그대로 다시 던진다.
+				if r := recover(); r != nil {
+					m.HandlerPanics.WithLabelValues(info.BaseTopic, info.GroupID).Inc()
+					panic(r)
+				}
+			}()
+			err := next(ctx, evt)
+			switch {
+			case errors.Is(err, ErrHandlerPanic):
+				m.HandlerPanics.WithLabelValues(info.BaseTopic, info.GroupID).Inc()
+			case errors.Is(err, ErrHandlerTimeout):
+				m.HandlerTimeouts.WithLabelValues(info.BaseTopic, info.GroupID).Inc()
+			}
+			return err
+		}
+	}
+}
+
+// WithMiddleware는 이 구독에만 적용할 미들웨어를 추가합니다. 버스 전역 미들웨어 안쪽에서 실행됩니다.
+func WithMiddleware(mws ...Middleware) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.middlewares = append(o.middlewares, mws...)
+	}
+}
@@ -0,0 +1,113 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestChainAppliesMiddlewaresInOrder(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next EventHandler) EventHandler {
+			return func(ctx context.Context, evt Event) error {
+				calls = append(calls, name+":before")
+				err := next(ctx, evt)
+				calls = append(calls, name+":after")
+				return err
+			}
+		}
+	}
+	handler := Chain(func(ctx context.Context, evt Event) error {
+		calls = append(calls, "handler")
+		return nil
+	}, mw("outer"), nil, mw("inner"))
+
+	if err := handler(context.Background(), Event{ID: "evt-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "outer:before,inner:before,handler,inner:after,outer:after"
+	if got := strings.Join(calls, ","); got != want {
+		t.Fatalf("unexpected call order:\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestRecoverTurnsPanicIntoRetryableError(t *testing.T) {
+	handler := Chain(func(ctx context.Context, evt Event) error {
+		panic("nil map")
+	}, Recover())
+
+	err := handler(context.Background(), Event{ID: "evt-1"})
+	if !errors.Is(err, ErrHandlerPanic) || !strings.Contains(err.Error(), "nil map") {
+		t.Fatalf("expected ErrHandlerPanic, got %v", err)
+	}
+	if IsPermanent(err) {
+		t.Fatalf("panic should be retryable, got permanent error")
+	}
+}
+
+func TestTimeoutSetsDeadline(t *testing.T) {
+	handler := Chain(func(ctx context.Context, evt Event) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatalf("expected handler context to have a deadline")
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}, Timeout(10*time.Millisecond))
+
+	err := handler(context.Background(), Event{ID: "evt-1"})
+	if !errors.Is(err, ErrHandlerTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrHandlerTimeout wrapping DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestMemoryEventBusMiddlewares(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewManualClock(time.Unix(0, 0))
+	bus := NewMemoryEventBus(clock)
+	defer bus.Close()
+	metrics := NewMetrics()
+	bus.Use(MetricsMiddleware(metrics))
+	topic := NewTopic("test.memory.middleware")
+
+	infos := make(chan HandlerInfo, 1)
+	capture := func(next EventHandler) EventHandler {
+		return func(ctx context.Context, evt Event) error {
+			info, _ := HandlerInfoFromContext(ctx)
+			infos <- info
+			return next(ctx, evt)
+		}
+	}
+	go bus.Subscribe(ctx, "group", topic, func(ctx context.Context, evt Event) error {
+		panic("boom")
+	}, WithMiddleware(capture))
+
+	evt, _ := NewTopicJSONEvent(topic, "evt-1", testPayload{PostID: "post-1"}, 0)
+	if err := bus.Publish(ctx, topic.Base(), evt); err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+
+	// 기본 Recover 덕분에 패닉이 구독을 멈추지 않고 재시도로 넘어간다.
+	retryTopic, _ := topic.GetRetryTopic(1)
+	waitFor(t, func() bool { return len(bus.Events(retryTopic)) == 1 })
+	if got := bus.Events(retryTopic)[0].LastError; !strings.Contains(got, ErrHandlerPanic.Error()) {
+		t.Fatalf("expected panic error to be recorded, got %q", got)
+	}
+
+	info := <-infos
+	if info.GroupID != "group" || info.BaseTopic != topic.Base() || info.Topic != topic.Base() || info.Offset != 0 {
+		t.Fatalf("unexpected handler info: %+v", info)
+	}
+	if got := testutil.ToFloat64(metrics.HandlerPanics.WithLabelValues(topic.Base(), "group")); got != 1 {
+		t.Fatalf("expected 1 panic recorded, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.HandlerInFlight.WithLabelValues(topic.Base(), "group")); got != 0 {
+		t.Fatalf("expected no handlers in flight, got %v", got)
+	}
+}
@@ -0,0 +1,101 @@
+package eventbus
+
+import "sync"
+
+// offsetTracker는 파티션별로 처리 중인 오프셋을 추적하여, 커밋 가능한 오프셋을 계산합니다.
+// 여러 워커가 메시지를 병렬로 처리하더라도, 앞선 오프셋이 모두 끝난 구간까지만 커밋하므로
+// 프로세스가 중단되어도 처리되지 않은 메시지를 건너뛰지 않습니다.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[string]map[int32]*partitionOffsets // key: topic -> partition
+}
+
+type partitionOffsets struct {
+	inflight  []int64        // 디스패치 순서(오름차순)로 쌓인 미완료 오프셋
+	done      map[int64]bool // 처리가 끝났거나 건너뛰었지만 앞선 오프셋이 남아 있어 커밋하지 못한 오프셋
+	last      int64          // 마지막으로 추적한 오프셋
+	committed int64          // 커밋 가능한 다음 오프셋 (Kafka 커밋 규약: 다음에 읽을 오프셋)
+	hole      int64          // Skip으로 건너뛴 가장 앞선 오프셋. 이 오프셋부터는 커밋하지 않는다 (없으면 -1)
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[string]map[int32]*partitionOffsets)}
+}
+
+// Track은 디스패치된 메시지의 오프셋을 등록합니다.
+// 리밸런스 등으로 이전보다 작거나 같은 오프셋이 다시 들어오면 해당 파티션 상태를 초기화합니다.
+func (t *offsetTracker) Track(topic string, partition int32, offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	byPartition, ok := t.partitions[topic]
+	if !ok {
+		byPartition = make(map[int32]*partitionOffsets)
+		t.partitions[topic] = byPartition
+	}
+	p, ok := byPartition[partition]
+	if !ok || offset <= p.last {
+		p = &partitionOffsets{done: make(map[int64]bool), committed: offset, hole: -1}
+		byPartition[partition] = p
+	}
+	p.inflight = append(p.inflight, offset)
+	p.last = offset
+}
+
+// Done은 오프셋 처리 완료를 기록하고, 커밋 가능한 오프셋이 전진했다면 (다음 오프셋, true)를 반환합니다.
+func (t *offsetTracker) Done(topic string, partition int32, offset int64) (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[topic][partition]
+	if !ok || offset < p.committed || offset > p.last {
+		return 0, false
+	}
+	p.done[offset] = true
+
+	advanced := false
+	for len(p.inflight) > 0 && p.done[p.inflight[0]] && (p.hole < 0 || p.inflight[0] < p.hole) {
+		head := p.inflight[0]
+		delete(p.done, head)
+		p.inflight = p.inflight[1:]
+		p.committed = head + 1
+		advanced = true
+	}
+	return p.committed, advanced
+}
+
+// Skip은 처리하지 않고 건너뛴 오프셋을 기록합니다(종료 중 시작하지 않은 메시지, 차단기가 보류한 메시지 등).
+// 더 이상 결과를 기다리지 않으므로 Pending에서 빠지지만, 다시 읽어야 하므로 Reset 전까지 이 오프셋부터는 커밋하지 않습니다.
+func (t *offsetTracker) Skip(topic string, partition int32, offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[topic][partition]
+	if !ok || offset < p.committed || offset > p.last {
+		return
+	}
+	p.done[offset] = true
+	if p.hole < 0 || offset < p.hole {
+		p.hole = offset
+	}
+}
+
+// Pending은 파티션에서 처리 결과를 아직 받지 못한 메시지 수를 반환합니다.
+// 끝났지만 앞선 오프셋 때문에 커밋하지 못한 메시지와 Skip한 메시지는 세지 않습니다.
+func (t *offsetTracker) Pending(topic string, partition int32) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[topic][partition]
+	if !ok {
+		return 0
+	}
+	return len(p.inflight) - len(p.done)
+}
+
+// Reset은 파티션 할당이 해제되었을 때 해당 파티션의 추적 상태를 제거합니다.
+func (t *offsetTracker) Reset(topic string, partition int32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.partitions[topic], partition)
+}
@@ -0,0 +1,126 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestOffsetTrackerCommitsOnlyContiguousPrefix(t *testing.T) {
+	tracker := newOffsetTracker()
+	for _, offset := range []int64{10, 11, 12} {
+		tracker.Track("topic", 0, offset)
+	}
+
+	// 뒤쪽 오프셋이 먼저 끝나도 앞선 오프셋이 남아 있으면 커밋하지 않는다.
+	if _, ok := tracker.Done("topic", 0, 12); ok {
+		t.Fatalf("expected no commit while offset 10 is in flight")
+	}
+	if _, ok := tracker.Done("topic", 0, 11); ok {
+		t.Fatalf("expected no commit while offset 10 is in flight")
+	}
+
+	next, ok := tracker.Done("topic", 0, 10)
+	if !ok || next != 13 {
+		t.Fatalf("expected commit at 13, got %d (ok=%v)", next, ok)
+	}
+}
+
+func TestOffsetTrackerKeepsPartitionsIndependent(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.Track("topic", 0, 5)
+	tracker.Track("topic", 1, 7)
+
+	next, ok := tracker.Done("topic", 1, 7)
+	if !ok || next != 8 {
+		t.Fatalf("expected partition 1 commit at 8, got %d (ok=%v)", next, ok)
+	}
+	if _, ok := tracker.Done("topic", 0, 6); ok {
+		t.Fatalf("expected untracked offset to be ignored")
+	}
+}
+
+func TestOffsetTrackerResetsOnRewind(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.Track("topic", 0, 20)
+	tracker.Track("topic", 0, 21)
+
+	// 리밸런스 후 커밋된 위치부터 다시 읽는 경우 이전 상태를 버린다.
+	tracker.Track("topic", 0, 20)
+	next, ok := tracker.Done("topic", 0, 20)
+	if !ok || next != 21 {
+		t.Fatalf("expected commit at 21 after rewind, got %d (ok=%v)", next, ok)
+	}
+}
+
+func TestOffsetTrackerPendingAndReset(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.Track("topic", 0, 1)
+	tracker.Track("topic", 0, 2)
+
+	if got := tracker.Pending("topic", 0); got != 2 {
+		t.Fatalf("expected 2 pending, got %d", got)
+	}
+	// 끝난 메시지는 앞선 오프셋 때문에 커밋하지 못해도 더 기다리지 않는다.
+	tracker.Done("topic", 0, 2)
+	if got := tracker.Pending("topic", 0); got != 1 {
+		t.Fatalf("expected only offset 1 pending, got %d", got)
+	}
+	tracker.Done("topic", 0, 1)
+	if got := tracker.Pending("topic", 0); got != 0 {
+		t.Fatalf("expected 0 pending, got %d", got)
+	}
+
+	// 회수된 파티션의 늦게 끝난 결과는 커밋하지 않는다.
+	tracker.Track("topic", 1, 5)
+	tracker.Reset("topic", 1)
+	if got := tracker.Pending("topic", 1); got != 0 {
+		t.Fatalf("expected reset partition to have no pending, got %d", got)
+	}
+	if _, ok := tracker.Done("topic", 1, 5); ok {
+		t.Fatalf("expected no commit for reset partition")
+	}
+}
+
+func TestOffsetTrackerSkipStopsCommitsButNotPending(t *testing.T) {
+	tracker := newOffsetTracker()
+	for _, offset := range []int64{1, 2, 3} {
+		tracker.Track("topic", 0, offset)
+	}
+
+	// 건너뛴 오프셋은 기다리지 않지만, 다시 읽어야 하므로 그 위치를 넘어 커밋하지 않는다.
+	tracker.Skip("topic", 0, 2)
+	if got := tracker.Pending("topic", 0); got != 2 {
+		t.Fatalf("expected 2 pending after skip, got %d", got)
+	}
+	if next, ok := tracker.Done("topic", 0, 1); !ok || next != 2 {
+		t.Fatalf("expected commit at 2, got %d (ok=%v)", next, ok)
+	}
+	if _, ok := tracker.Done("topic", 0, 3); ok {
+		t.Fatalf("expected no commit past skipped offset 2")
+	}
+	tracker.Track("topic", 0, 4)
+	if _, ok := tracker.Done("topic", 0, 4); ok {
+		t.Fatalf("expected skipped offset to block later offsets too")
+	}
+	if got := tracker.Pending("topic", 0); got != 0 {
+		t.Fatalf("expected nothing pending, got %d", got)
+	}
+}
+
+func TestWorkerIndexIsStablePerKey(t *testing.T) {
+	topic := "topic"
+	first := workerIndex(newTestKafkaMessage(topic, 0, "post-1"), 8)
+	for i := 0; i < 10; i++ {
+		if got := workerIndex(newTestKafkaMessage(topic, int32(i%3), "post-1"), 8); got != first {
+			t.Fatalf("expected same worker %d for same key, got %d", first, got)
+		}
+	}
+}
+
+func newTestKafkaMessage(topic string, partition int32, key string) *kafka.Message {
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: partition},
+		Key:            []byte(key),
+	}
+}
@@ -0,0 +1,465 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// OutboxEntry는 아웃박스에 기록된 발행 대기 이벤트입니다.
+type OutboxEntry struct {
+	// ID는 아웃박스 항목 식별자입니다. UUIDv7이므로 문자열 정렬이 곧 기록 순서입니다.
+	ID    string `json:"id"`
+	Topic string `json:"topic"`
+	Event Event  `json:"event"`
+	// Headers는 Enqueue 시점의 트레이싱 헤더입니다. 릴레이가 발행할 때 컨텍스트로 복원합니다.
+	Headers       map[string]string `json:"headers,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Attempts      int               `json:"attempts"`
+	LastError     string            `json:"last_error,omitempty"`
+	LastAttemptAt time.Time         `json:"last_attempt_at,omitempty"`
+	NextAttemptAt time.Time         `json:"next_attempt_at"`
+	DeliveredAt   *time.Time        `json:"delivered_at,omitempty"`
+	// FailedAt은 다시 시도해도 성공할 수 없는 오류(스키마 위반, 영구 오류 등)로 발행을 포기한 시각입니다.
+	// 설정된 항목은 릴레이가 더 이상 시도하지 않고 Status의 Failed로 보고합니다.
+	FailedAt *time.Time `json:"failed_at,omitempty"`
+}
+
+// OutboxStore는 아웃박스 항목을 보관하는 영속 저장소입니다.
+type OutboxStore interface {
+	// Append는 새 항목을 기록합니다. 반환 시점에 항목은 영속화되어 있어야 합니다.
+	Append(ctx context.Context, entry OutboxEntry) error
+	// Update는 항목의 발행 시도 결과를 기록합니다. DeliveredAt이 설정된 항목은 더 이상 조회되지 않습니다.
+	Update(ctx context.Context, entry OutboxEntry) error
+	// Undelivered는 아직 발행되지 않은 항목을 기록 순서대로 반환합니다.
+	Undelivered(ctx context.Context) ([]OutboxEntry, error)
+}
+
+// OutboxOption은 Outbox 동작을 조정하는 옵션입니다.
+type OutboxOption func(*Outbox)
+
+// WithOutboxClock은 릴레이가 사용할 시계를 지정합니다. 테스트에서 ManualClock을 주입할 때 사용합니다.
+func WithOutboxClock(clock Clock) OutboxOption {
+	return func(o *Outbox) {
+		o.clock = clock
+	}
+}
+
+// WithOutboxBackoff는 발행 실패 시 재시도 대기 시간의 최소/최대값을 지정합니다.
+// 대기 시간은 min에서 시작해 실패할 때마다 두 배로 늘어나며 max를 넘지 않습니다.
+func WithOutboxBackoff(min, max time.Duration) OutboxOption {
+	return func(o *Outbox) {
+		o.minBackoff = min
+		o.maxBackoff = max
+	}
+}
+
+// WithOutboxStuckAfter는 기록 후 d가 지나도록 발행되지 않은 항목을 정체(stuck)로 분류하게 합니다.
+func WithOutboxStuckAfter(d time.Duration) OutboxOption {
+	return func(o *Outbox) {
+		o.stuckAfter = d
+	}
+}
+
+// Outbox는 상태 변경과 이벤트 발행 사이의 유실을 막는 트랜잭셔널 아웃박스입니다.
+// Enqueue는 이벤트를 로컬 저장소에 먼저 기록하고, Run으로 시작한 릴레이가 EventBus.Publish로
+// 발행한 뒤 발행 완료로 표시합니다. 발행에 실패한 항목은 지수 백오프로 다시 시도합니다.
+type Outbox struct {
+	store      OutboxStore
+	bus        EventBus
+	clock      Clock
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	stuckAfter time.Duration
+	notify     chan struct{}
+}
+
+const (
+	defaultOutboxMinBackoff = time.Second
+	defaultOutboxMaxBackoff = 5 * time.Minute
+	defaultOutboxStuckAfter = 10 * time.Minute
+	outboxRelayPollInterval = 5 * time.Second
+)
+
+// NewOutbox는 store에 기록된 이벤트를 bus로 발행하는 Outbox를 생성합니다.
+func NewOutbox(store OutboxStore, bus EventBus, opts ...OutboxOption) *Outbox {
+	o := &Outbox{
+		store:      store,
+		bus:        bus,
+		clock:      SystemClock,
+		minBackoff: defaultOutboxMinBackoff,
+		maxBackoff: defaultOutboxMaxBackoff,
+		stuckAfter: defaultOutboxStuckAfter,
+		notify:     make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.clock == nil {
+		o.clock = SystemClock
+	}
+	return o
+}
+
+// Enqueue는 이벤트를 아웃박스에 기록하고 릴레이를 깨웁니다.
+// 상태 변경을 저장한 직후 호출하면, 이후 Kafka 발행이 실패하더라도 릴레이가 다시 발행합니다.
+func (o *Outbox) Enqueue(ctx context.Context, topic string, event Event) (OutboxEntry, error) {
+	now := o.clock.Now()
+	entry := OutboxEntry{
+		ID:            NewEventID(),
+		Topic:         topic,
+		Event:         event,
+		Headers:       headerMap(traceHeadersFromContext(ctx)),
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+	if err := o.store.Append(ctx, entry); err != nil {
+		return OutboxEntry{}, fmt.Errorf("아웃박스 기록 실패: %w", err)
+	}
+
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+	return entry, nil
+}
+
+// Run은 ctx가 취소될 때까지 아웃박스 릴레이를 실행합니다.
+func (o *Outbox) Run(ctx context.Context) error {
+	logger.Log.Info("아웃박스 릴레이 시작됨.")
+	for {
+		next, err := o.relayOnce(ctx)
+		if err != nil {
+			logger.Log.Errorf("아웃박스 릴레이 오류: %v", err)
+		}
+
+		deadline := o.clock.Now().Add(outboxRelayPollInterval)
+		if !next.IsZero() && next.Before(deadline) {
+			deadline = next
+		}
+		timer := o.clock.NewTimer(deadline)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			logger.Log.Info("아웃박스 릴레이 종료 중.")
+			return ctx.Err()
+		case <-o.notify:
+		case <-timer.C():
+		}
+		timer.Stop()
+	}
+}
+
+// relayOnce는 시도 시각이 된 항목을 기록 순서대로 발행하고, 남은 항목 중 가장 이른 다음 시도 시각을 반환합니다.
+// 영구적인 발행 오류가 난 항목은 실패로 표시하고 다시 시도하지 않습니다.
+func (o *Outbox) relayOnce(ctx context.Context) (time.Time, error) {
+	entries, err := o.store.Undelivered(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("아웃박스 조회 실패: %w", err)
+	}
+
+	var next time.Time
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return next, nil
+		}
+		if entry.FailedAt != nil {
+			continue
+		}
+		if entry.NextAttemptAt.After(o.clock.Now()) {
+			if next.IsZero() || entry.NextAttemptAt.Before(next) {
+				next = entry.NextAttemptAt
+			}
+			continue
+		}
+
+		pubCtx := contextWithTraceHeaders(ctx, headerList(entry.Headers))
+		publishErr := o.bus.Publish(pubCtx, entry.Topic, entry.Event)
+
+		now := o.clock.Now()
+		entry.Attempts++
+		entry.LastAttemptAt = now
+		switch {
+		case publishErr == nil:
+			entry.DeliveredAt = &now
+			entry.LastError = ""
+		case isPermanentPublishError(publishErr):
+			entry.LastError = publishErr.Error()
+			entry.FailedAt = &now
+			logger.Log.Errorf("아웃박스 항목 %s(이벤트 %s) 발행 실패: %v. 다시 시도해도 성공할 수 없어 실패로 표시합니다.",
+				entry.ID, entry.Event.ID, publishErr)
+		default:
+			entry.LastError = publishErr.Error()
+			entry.NextAttemptAt = now.Add(o.backoff(entry.Attempts))
+			if next.IsZero() || entry.NextAttemptAt.Before(next) {
+				next = entry.NextAttemptAt
+			}
+			logger.Log.Warnf("아웃박스 항목 %s(이벤트 %s) 발행 실패 (시도 %d): %v. %s 이후 재시도.",
+				entry.ID, entry.Event.ID, entry.Attempts, publishErr, entry.NextAttemptAt.Format(time.RFC3339))
+		}
+		if err := o.store.Update(ctx, entry); err != nil {
+			return next, fmt.Errorf("아웃박스 항목 %s 상태 기록 실패: %w", entry.ID, err)
+		}
+	}
+	return next, nil
+}
+
+// isPermanentPublishError는 다시 발행해도 같은 결과가 나올 오류인지 확인합니다.
+func isPermanentPublishError(err error) bool {
+	if IsPermanent(err) || errors.Is(err, ErrSchemaViolation) {
+		return true
+	}
+	var kerr kafka.Error
+	return errors.As(err, &kerr) && (kerr.Code() == kafka.ErrMsgSizeTooLarge || kerr.Code() == kafka.ErrInvalidMsgSize)
+}
+
+// backoff는 attempts번 실패한 항목의 다음 시도까지 대기 시간을 계산합니다.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	d := o.minBackoff
+	for i := 1; i < attempts && d < o.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > o.maxBackoff {
+		d = o.maxBackoff
+	}
+	return d
+}
+
+// OutboxStatus는 아웃박스 관리 화면에 보여줄 미발행 항목 현황입니다.
+type OutboxStatus struct {
+	// Pending은 릴레이가 발행을 시도 중인 항목입니다.
+	Pending []OutboxEntry `json:"pending"`
+	// Stuck은 Pending 중 기록 후 정체 기준 시간이 지나도록 발행되지 않은 항목입니다.
+	Stuck []OutboxEntry `json:"stuck"`
+	// Failed는 영구적인 발행 오류로 릴레이가 포기한 항목입니다. 원인을 고쳐 다시 기록해야 합니다.
+	Failed []OutboxEntry `json:"failed"`
+}
+
+// Status는 미발행 항목과 그중 정체된 항목, 발행을 포기한 항목을 반환합니다.
+func (o *Outbox) Status(ctx context.Context) (OutboxStatus, error) {
+	entries, err := o.store.Undelivered(ctx)
+	if err != nil {
+		return OutboxStatus{}, fmt.Errorf("아웃박스 조회 실패: %w", err)
+	}
+	status := OutboxStatus{Pending: []OutboxEntry{}, Stuck: []OutboxEntry{}, Failed: []OutboxEntry{}}
+	now := o.clock.Now()
+	for _, entry := range entries {
+		if entry.FailedAt != nil {
+			status.Failed = append(status.Failed, entry)
+			continue
+		}
+		status.Pending = append(status.Pending, entry)
+		if now.Sub(entry.CreatedAt) >= o.stuckAfter {
+			status.Stuck = append(status.Stuck, entry)
+		}
+	}
+	return status, nil
+}
+
+// AdminHandler는 Status를 JSON으로 응답하는 관리용 HTTP 핸들러를 반환합니다.
+// "?stuck=true"이면 정체된 항목과 발행을 포기한 항목만 응답합니다.
+func (o *Outbox) AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, err := o.Status(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Query().Get("stuck") == "true" {
+			status.Pending = nil
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	})
+}
+
+func headerMap(headers []kafka.Header) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(headers))
+	for _, h := range headers {
+		out[h.Key] = string(h.Value)
+	}
+	return out
+}
+
+func headerList(headers map[string]string) []kafka.Header {
+	out := make([]kafka.Header, 0, len(headers))
+	for k, v := range headers {
+		out = append(out, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return out
+}
+
+// FileOutboxStore는 항목 상태를 JSON Lines 파일에 추가 기록하는 OutboxStore입니다.
+// 같은 ID의 마지막 줄이 현재 상태이며, 시작 시 파일을 읽어 미발행 항목을 복원합니다.
+// 발행 완료로 쌓인 줄이 많아지면 미발행 항목만 남기도록 파일을 다시 씁니다.
+type FileOutboxStore struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	lines   int
+	entries map[string]OutboxEntry
+}
+
+// outboxCompactMinLines는 파일 정리를 시작하는 최소 줄 수입니다.
+const outboxCompactMinLines = 1000
+
+// NewFileOutboxStore는 path 파일을 열어(없으면 생성) FileOutboxStore를 생성합니다.
+func NewFileOutboxStore(path string) (*FileOutboxStore, error) {
+	s := &FileOutboxStore{path: path, entries: make(map[string]OutboxEntry)}
+
+	if f, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			var entry OutboxEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				f.Close()
+				return nil, fmt.Errorf("아웃박스 파일 파싱 실패 %s: %w", path, err)
+			}
+			s.apply(entry)
+			s.lines++
+		}
+		scanErr := scanner.Err()
+		f.Close()
+		if scanErr != nil {
+			return nil, fmt.Errorf("아웃박스 파일 읽기 실패 %s: %w", path, scanErr)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("아웃박스 파일 열기 실패 %s: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("아웃박스 파일 열기 실패 %s: %w", path, err)
+	}
+	s.file = f
+	return s, nil
+}
+
+func (s *FileOutboxStore) apply(entry OutboxEntry) {
+	if entry.DeliveredAt != nil {
+		delete(s.entries, entry.ID)
+		return
+	}
+	s.entries[entry.ID] = entry
+}
+
+func (s *FileOutboxStore) Append(ctx context.Context, entry OutboxEntry) error {
+	if entry.ID == "" {
+		return errors.New("아웃박스 항목 ID가 비어 있습니다")
+	}
+	return s.write(entry)
+}
+
+func (s *FileOutboxStore) Update(ctx context.Context, entry OutboxEntry) error {
+	return s.write(entry)
+}
+
+func (s *FileOutboxStore) write(entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("아웃박스 항목 마샬링 실패: %w", err)
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("아웃박스 항목 기록 실패: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("아웃박스 파일 동기화 실패: %w", err)
+	}
+	s.lines++
+	s.apply(entry)
+
+	if s.lines > outboxCompactMinLines && s.lines > 2*len(s.entries) {
+		if err := s.compactLocked(); err != nil {
+			logger.Log.Warnf("아웃박스 파일 정리 실패 %s: %v", s.path, err)
+		}
+	}
+	return nil
+}
+
+func (s *FileOutboxStore) Undelivered(ctx context.Context) ([]OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedLocked(), nil
+}
+
+func (s *FileOutboxStore) sortedLocked() []OutboxEntry {
+	out := make([]OutboxEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// compactLocked는 미발행 항목만으로 파일을 다시 씁니다.
+func (s *FileOutboxStore) compactLocked() error {
+	entries := s.sortedLocked()
+
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		w.Write(append(data, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	s.file.Close()
+	s.file = f
+	s.lines = len(entries)
+	return nil
+}
+
+// Close는 기록 파일을 닫습니다.
+func (s *FileOutboxStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
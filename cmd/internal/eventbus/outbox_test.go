@@ -0,0 +1,234 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// flakyBus는 fail이 true인 동안 Publish를 실패시키는 테스트용 EventBus입니다.
+type flakyBus struct {
+	*MemoryEventBus
+	mu   sync.Mutex
+	fail bool
+}
+
+func (b *flakyBus) setFail(fail bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fail = fail
+}
+
+func (b *flakyBus) Publish(ctx context.Context, topic string, event Event) error {
+	b.mu.Lock()
+	fail := b.fail
+	b.mu.Unlock()
+	if fail {
+		return errors.New("broker unavailable")
+	}
+	return b.MemoryEventBus.Publish(ctx, topic, event)
+}
+
+// rejectingBus는 Publish를 항상 err로 실패시키는 테스트용 EventBus입니다.
+type rejectingBus struct {
+	*MemoryEventBus
+	err error
+}
+
+func (b *rejectingBus) Publish(ctx context.Context, topic string, event Event) error {
+	return b.err
+}
+
+func TestOutboxRelayRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	bus := &flakyBus{MemoryEventBus: NewMemoryEventBus(clock), fail: true}
+	defer bus.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	store, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+	outbox := NewOutbox(store, bus, WithOutboxClock(clock), WithOutboxBackoff(time.Second, 4*time.Second))
+
+	entry, err := outbox.Enqueue(ctx, "tech-letter.test", Event{ID: "evt-1"})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	// 첫 시도는 실패하고 1초 뒤로 미뤄진다.
+	next, err := outbox.relayOnce(ctx)
+	if err != nil {
+		t.Fatalf("relayOnce: %v", err)
+	}
+	if want := clock.Now().Add(time.Second); !next.Equal(want) {
+		t.Fatalf("expected next attempt at %v, got %v", want, next)
+	}
+	pending, _ := store.Undelivered(ctx)
+	if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastError == "" {
+		t.Fatalf("expected one failed pending entry, got %+v", pending)
+	}
+
+	// 시도 시각 전에는 발행하지 않는다.
+	bus.setFail(false)
+	outbox.relayOnce(ctx)
+	if len(bus.Events("tech-letter.test")) != 0 {
+		t.Fatalf("expected no publish before backoff elapsed")
+	}
+
+	clock.Advance(time.Second)
+	if _, err := outbox.relayOnce(ctx); err != nil {
+		t.Fatalf("relayOnce: %v", err)
+	}
+	events := bus.Events("tech-letter.test")
+	if len(events) != 1 || events[0].ID != "evt-1" {
+		t.Fatalf("expected evt-1 to be published once, got %+v", events)
+	}
+	if pending, _ := store.Undelivered(ctx); len(pending) != 0 {
+		t.Fatalf("expected no pending entries, got %+v", pending)
+	}
+	store.Close()
+
+	// 다시 열어도 발행 완료 항목은 복원되지 않는다.
+	reopened, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+	if pending, _ := reopened.Undelivered(ctx); len(pending) != 0 {
+		t.Fatalf("expected delivered entry %s to stay delivered, got %+v", entry.ID, pending)
+	}
+}
+
+func TestOutboxStatusReportsStuckEntries(t *testing.T) {
+	ctx := context.Background()
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	bus := &flakyBus{MemoryEventBus: NewMemoryEventBus(clock), fail: true}
+	defer bus.Close()
+
+	store, err := NewFileOutboxStore(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+	defer store.Close()
+	outbox := NewOutbox(store, bus, WithOutboxClock(clock), WithOutboxStuckAfter(time.Minute))
+
+	outbox.Enqueue(ctx, "tech-letter.test", Event{ID: "old"})
+	clock.Advance(2 * time.Minute)
+	outbox.Enqueue(ctx, "tech-letter.test", Event{ID: "new"})
+
+	status, err := outbox.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(status.Pending) != 2 || status.Pending[0].Event.ID != "old" {
+		t.Fatalf("expected two pending entries in order, got %+v", status.Pending)
+	}
+	if len(status.Stuck) != 1 || status.Stuck[0].Event.ID != "old" {
+		t.Fatalf("expected only old entry to be stuck, got %+v", status.Stuck)
+	}
+}
+
+func TestOutboxRelayParksPermanentFailures(t *testing.T) {
+	ctx := context.Background()
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	// 크기 제한을 넘은 메시지는 몇 번을 발행해도 브로커가 거절한다.
+	bus := &rejectingBus{
+		MemoryEventBus: NewMemoryEventBus(clock),
+		err:            kafka.NewError(kafka.ErrMsgSizeTooLarge, "message too large", false),
+	}
+	defer bus.Close()
+
+	store, err := NewFileOutboxStore(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+	defer store.Close()
+	outbox := NewOutbox(store, bus, WithOutboxClock(clock))
+
+	if _, err := outbox.Enqueue(ctx, TopicPostSummary.Base(), Event{ID: "evt-1"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	next, err := outbox.relayOnce(ctx)
+	if err != nil {
+		t.Fatalf("relayOnce: %v", err)
+	}
+	if !next.IsZero() {
+		t.Fatalf("expected no retry to be scheduled, got %v", next)
+	}
+
+	clock.Advance(time.Hour)
+	outbox.relayOnce(ctx)
+	status, err := outbox.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(status.Pending) != 0 || len(status.Failed) != 1 || status.Failed[0].Attempts != 1 || status.Failed[0].FailedAt == nil {
+		t.Fatalf("expected one failed entry attempted once, got %+v", status)
+	}
+}
+
+func TestOutboxRunPublishesOnEnqueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := NewMemoryEventBus(nil)
+	defer bus.Close()
+
+	store, err := NewFileOutboxStore(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+	defer store.Close()
+	outbox := NewOutbox(store, bus)
+
+	done := make(chan error, 1)
+	go func() { done <- outbox.Run(ctx) }()
+
+	outbox.Enqueue(ctx, "tech-letter.test", Event{ID: "evt-1"})
+	waitFor(t, func() bool { return len(bus.Events("tech-letter.test")) == 1 })
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestOutboxRelayParksSchemaViolations(t *testing.T) {
+	ctx := context.Background()
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	bus := NewMemoryEventBus(clock)
+	defer bus.Close()
+
+	store, err := NewFileOutboxStore(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+	defer store.Close()
+	outbox := NewOutbox(store, bus, WithOutboxClock(clock))
+
+	// 카탈로그에 없는 페이로드 타입은 몇 번을 발행해도 스키마 위반이다.
+	invalid := Event{ID: "evt-1", Payload: []byte(`{"type":"unknown"}`)}
+	if _, err := outbox.Enqueue(ctx, TopicPostSummary.Base(), invalid); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	next, err := outbox.relayOnce(ctx)
+	if err != nil {
+		t.Fatalf("relayOnce: %v", err)
+	}
+	if !next.IsZero() {
+		t.Fatalf("expected no retry to be scheduled, got %v", next)
+	}
+	status, err := outbox.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(status.Pending) != 0 || len(status.Failed) != 1 {
+		t.Fatalf("expected schema violation to be parked, got %+v", status)
+	}
+}
@@ -0,0 +1,145 @@
+package eventbus
+
+// 토픽별 페이로드 타입입니다. 필드 구성은 Python common/events 의 dataclass와 1:1로 맞추며,
+// 두 정의가 어긋나면 testdata/event_contracts.golden.json 계약 테스트가 실패합니다.
+// 포인터 또는 omitempty 필드는 선택(null 허용) 필드이고, 나머지는 모두 필수 필드입니다.
+
+// 페이로드 type 필드 값입니다.
+const (
+	EventTypePostSummaryRequested          = "post.summary_requested"
+	EventTypePostSummaryResponse           = "post.summary_response"
+	EventTypePostEmbeddingRequested        = "post.embedding_requested"
+	EventTypePostEmbeddingResponse         = "post.embedding_response"
+	EventTypePostEmbeddingApplied          = "post.embedding_applied"
+	EventTypePostEmbeddingDeleteRequested  = "post.embedding_delete_requested"
+	EventTypeCreditConsumed                = "credit.consumed"
+	EventTypeCreditGranted                 = "credit.granted"
+	EventTypeChatCompleted                 = "chat.completed"
+	EventTypeChatFailed                    = "chat.failed"
+	EventTypeChatContextCompressionRequest = "chat.context_compression.requested"
+)
+
+// PayloadMeta는 모든 페이로드가 공통으로 가지는 메타 필드입니다.
+type PayloadMeta struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Source    string `json:"source"`
+	Version   string `json:"version"`
+}
+
+type PostSummaryRequestedPayload struct {
+	PayloadMeta
+	PostID      string `json:"post_id"`
+	Title       string `json:"title"`
+	BlogName    string `json:"blog_name"`
+	Link        string `json:"link"`
+	PublishedAt string `json:"published_at"`
+}
+
+type PostSummaryResponsePayload struct {
+	PayloadMeta
+	PostID       string   `json:"post_id"`
+	Link         string   `json:"link"`
+	Categories   []string `json:"categories"`
+	Tags         []string `json:"tags"`
+	Summary      string   `json:"summary"`
+	ModelName    string   `json:"model_name"`
+	PlainText    string   `json:"plain_text"`
+	ThumbnailURL string   `json:"thumbnail_url"`
+}
+
+type PostEmbeddingRequestedPayload struct {
+	PayloadMeta
+	PostID      string   `json:"post_id"`
+	Title       string   `json:"title"`
+	BlogName    string   `json:"blog_name"`
+	Link        string   `json:"link"`
+	PublishedAt string   `json:"published_at"`
+	Categories  []string `json:"categories"`
+	Tags        []string `json:"tags"`
+	PlainText   string   `json:"plain_text"`
+	Summary     string   `json:"summary"`
+}
+
+type EmbeddingChunk struct {
+	ChunkIndex int       `json:"chunk_index"`
+	ChunkText  string    `json:"chunk_text"`
+	Vector     []float64 `json:"vector"`
+}
+
+type PostEmbeddingResponsePayload struct {
+	PayloadMeta
+	PostID      string           `json:"post_id"`
+	Title       string           `json:"title"`
+	BlogName    string           `json:"blog_name"`
+	Link        string           `json:"link"`
+	PublishedAt string           `json:"published_at"`
+	Categories  []string         `json:"categories"`
+	Tags        []string         `json:"tags"`
+	Chunks      []EmbeddingChunk `json:"chunks"`
+	ModelName   string           `json:"model_name"`
+}
+
+type PostEmbeddingAppliedPayload struct {
+	PayloadMeta
+	PostID          string `json:"post_id"`
+	ModelName       string `json:"model_name"`
+	CollectionName  string `json:"collection_name"`
+	VectorDimension int    `json:"vector_dimension"`
+	ChunkCount      int    `json:"chunk_count"`
+}
+
+type PostEmbeddingDeleteRequestedPayload struct {
+	PayloadMeta
+	PostID string `json:"post_id"`
+}
+
+type CreditConsumedPayload struct {
+	PayloadMeta
+	UserCode        string  `json:"user_code"`
+	CreditExpiredAt string  `json:"credit_expired_at"`
+	Amount          int     `json:"amount"`
+	Remaining       int     `json:"remaining"`
+	Reason          string  `json:"reason"`
+	SessionID       *string `json:"session_id"`
+}
+
+type CreditGrantedPayload struct {
+	PayloadMeta
+	UserCode        string `json:"user_code"`
+	CreditExpiredAt string `json:"credit_expired_at"`
+	Amount          int    `json:"amount"`
+	Remaining       int    `json:"remaining"`
+	Reason          string `json:"reason"`
+	GrantedBy       string `json:"granted_by"`
+}
+
+type ChatCompletedPayload struct {
+	PayloadMeta
+	UserCode         string         `json:"user_code"`
+	SessionID        *string        `json:"session_id"`
+	Query            string         `json:"query"`
+	Answer           string         `json:"answer"`
+	CreditConsumedID string         `json:"credit_consumed_id"`
+	CreditExpiredAt  string         `json:"credit_expired_at"`
+	Metadata         map[string]any `json:"metadata,omitempty"`
+}
+
+type ChatFailedPayload struct {
+	PayloadMeta
+	UserCode         string  `json:"user_code"`
+	SessionID        *string `json:"session_id"`
+	Query            string  `json:"query"`
+	ErrorCode        string  `json:"error_code"`
+	CreditConsumedID string  `json:"credit_consumed_id"`
+	CreditExpiredAt  string  `json:"credit_expired_at"`
+}
+
+type ChatContextCompressionRequestedPayload struct {
+	PayloadMeta
+	UserCode     string `json:"user_code"`
+	SessionID    string `json:"session_id"`
+	MessageCount int    `json:"message_count"`
+	Threshold    int    `json:"threshold"`
+}
@@ -0,0 +1,156 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// PublishResult는 이벤트 1건의 발행(브로커 전달) 결과입니다.
+type PublishResult struct {
+	EventID   string
+	Topic     string
+	Partition int32
+	Offset    int64
+	// Err는 발행 실패 원인입니다. nil이면 브로커가 메시지를 확인(ack)한 것입니다.
+	Err error
+}
+
+// PublishFuture는 PublishAsync가 반환하는, 아직 끝나지 않았을 수 있는 발행 결과입니다.
+type PublishFuture struct {
+	eventID  string
+	topic    string
+	done     chan struct{}
+	once     sync.Once
+	result   PublishResult
+	mu       sync.Mutex
+	callback func(PublishResult)
+}
+
+func newPublishFuture(eventID, topic string) *PublishFuture {
+	return &PublishFuture{eventID: eventID, topic: topic, done: make(chan struct{})}
+}
+
+// completedFuture는 즉시 끝난 결과로 PublishFuture를 만듭니다.
+func completedFuture(result PublishResult) *PublishFuture {
+	f := newPublishFuture(result.EventID, result.Topic)
+	f.complete(result)
+	return f
+}
+
+// complete는 결과를 확정하고 대기 중인 호출자와 콜백에 알립니다. 두 번째 호출부터는 무시됩니다.
+func (f *PublishFuture) complete(result PublishResult) {
+	f.once.Do(func() {
+		f.mu.Lock()
+		f.result = result
+		close(f.done)
+		callback := f.callback
+		f.mu.Unlock()
+		if callback != nil {
+			callback(result)
+		}
+	})
+}
+
+// Done은 발행 결과가 확정되면 닫히는 채널을 반환합니다.
+func (f *PublishFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait는 발행 결과가 확정될 때까지 기다립니다.
+// ctx가 먼저 취소되면 ctx.Err()를 반환하며, 이 경우 메시지는 나중에 전달될 수도 있습니다.
+func (f *PublishFuture) Wait(ctx context.Context) (PublishResult, error) {
+	// 이미 확정된 결과는 ctx 상태와 관계없이 그대로 돌려준다.
+	select {
+	case <-f.done:
+		return f.completed()
+	default:
+	}
+	select {
+	case <-f.done:
+		return f.completed()
+	case <-ctx.Done():
+		return PublishResult{EventID: f.eventID, Topic: f.topic, Partition: -1, Offset: -1, Err: ctx.Err()}, ctx.Err()
+	}
+}
+
+func (f *PublishFuture) completed() (PublishResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.result, f.result.Err
+}
+
+// OnComplete는 발행 결과가 확정되면 fn을 호출하도록 등록합니다.
+// 이미 확정되었다면 즉시 호출합니다. fn은 Producer 이벤트 고루틴에서 실행되므로 오래 블로킹하면 안 됩니다.
+func (f *PublishFuture) OnComplete(fn func(PublishResult)) {
+	f.mu.Lock()
+	select {
+	case <-f.done:
+		result := f.result
+		f.mu.Unlock()
+		fn(result)
+		return
+	default:
+	}
+	f.callback = fn
+	f.mu.Unlock()
+}
+
+// BatchPublishError는 PublishBatch에서 일부 이벤트 발행이 실패했음을 나타냅니다.
+// Results에는 성공한 이벤트를 포함한 전체 결과가 입력 순서대로 담겨 있습니다.
+type BatchPublishError struct {
+	Results []PublishResult
+}
+
+// Failed는 실패한 결과만 반환합니다.
+func (e *BatchPublishError) Failed() []PublishResult {
+	var failed []PublishResult
+	for _, r := range e.Results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+func (e *BatchPublishError) Error() string {
+	failed := e.Failed()
+	msgs := make([]string, 0, len(failed))
+	for i, r := range failed {
+		if i == 3 {
+			msgs = append(msgs, fmt.Sprintf("외 %d건", len(failed)-3))
+			break
+		}
+		msgs = append(msgs, fmt.Sprintf("%s: %v", r.EventID, r.Err))
+	}
+	return fmt.Sprintf("배치 발행 중 %d/%d건 실패: %s", len(failed), len(e.Results), strings.Join(msgs, "; "))
+}
+
+// Unwrap은 errors.Is/As가 개별 실패 원인을 검사할 수 있도록 실패 원인 목록을 반환합니다.
+func (e *BatchPublishError) Unwrap() []error {
+	var errs []error
+	for _, r := range e.Results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs
+}
+
+// waitBatch는 futures를 모두 기다려 입력 순서대로 결과를 모읍니다.
+// ctx가 취소되면 아직 끝나지 않은 이벤트는 ctx.Err()로 채웁니다. 하나라도 실패하면 BatchPublishError를 반환합니다.
+func waitBatch(ctx context.Context, futures []*PublishFuture) ([]PublishResult, error) {
+	results := make([]PublishResult, len(futures))
+	failed := false
+	for i, f := range futures {
+		results[i], _ = f.Wait(ctx)
+		if results[i].Err != nil {
+			failed = true
+		}
+	}
+	if failed {
+		return results, &BatchPublishError{Results: results}
+	}
+	return results, nil
+}
@@ -0,0 +1,67 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryPublishBatchReportsPerEventResults(t *testing.T) {
+	bus := NewMemoryEventBus(nil)
+	defer bus.Close()
+
+	events := []Event{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	results, err := bus.PublishBatch(context.Background(), "tech-letter.test", events)
+	if err != nil {
+		t.Fatalf("PublishBatch: %v", err)
+	}
+	for i, r := range results {
+		if r.EventID != events[i].ID || r.Offset != int64(i) || r.Err != nil {
+			t.Fatalf("unexpected result %d: %+v", i, r)
+		}
+	}
+}
+
+func TestPublishBatchPartialFailure(t *testing.T) {
+	ok := completedFuture(PublishResult{EventID: "a", Topic: "t", Offset: 1})
+	bad := completedFuture(PublishResult{EventID: "b", Topic: "t", Err: ErrBusClosed})
+	pending := newPublishFuture("c", "t")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := waitBatch(ctx, []*PublishFuture{ok, bad, pending})
+
+	var batchErr *BatchPublishError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected BatchPublishError, got %v", err)
+	}
+	if len(results) != 3 || results[0].Err != nil {
+		t.Fatalf("expected first event to succeed, got %+v", results)
+	}
+	if failed := batchErr.Failed(); len(failed) != 2 || failed[0].EventID != "b" || failed[1].EventID != "c" {
+		t.Fatalf("expected b and c to fail, got %+v", failed)
+	}
+	if !errors.Is(err, ErrBusClosed) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected joined causes, got %v", err)
+	}
+}
+
+func TestPublishFutureOnComplete(t *testing.T) {
+	f := newPublishFuture("a", "t")
+	got := make(chan PublishResult, 2)
+	f.OnComplete(func(r PublishResult) { got <- r })
+
+	f.complete(PublishResult{EventID: "a", Topic: "t", Offset: 7})
+	if r := <-got; r.Offset != 7 {
+		t.Fatalf("expected callback with offset 7, got %+v", r)
+	}
+
+	// 이미 완료된 future에 등록한 콜백은 즉시 호출된다.
+	f.OnComplete(func(r PublishResult) { got <- r })
+	if r := <-got; r.Offset != 7 {
+		t.Fatalf("expected immediate callback, got %+v", r)
+	}
+	if r, err := f.Wait(context.Background()); err != nil || r.Offset != 7 {
+		t.Fatalf("unexpected Wait result %+v, %v", r, err)
+	}
+}
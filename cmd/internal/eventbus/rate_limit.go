@@ -0,0 +1,283 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// rateLimitReportInterval은 속도 제한으로 기다린 시간을 모아 로그로 남기는 주기입니다.
+const rateLimitReportInterval = time.Minute
+
+// RateLimit은 토큰 버킷 방식의 처리 속도 제한입니다. Rate가 0이면 제한하지 않습니다.
+type RateLimit struct {
+	// Rate는 초당 처리할 수 있는 메시지 수입니다.
+	Rate float64
+	// Burst는 한 번에 몰아서 처리할 수 있는 최대 메시지 수입니다. 0 이하이면 1입니다.
+	Burst int
+}
+
+// ParseRateLimit은 "<개수>/<단위>[:<burst>]" 형식(예: "30/m", "5/s:10", "1000/h")을 RateLimit으로 바꿉니다.
+// 단위는 s, m, h입니다. 빈 문자열은 제한 없음입니다.
+func ParseRateLimit(raw string) (RateLimit, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return RateLimit{}, nil
+	}
+	spec, burstRaw, hasBurst := strings.Cut(raw, ":")
+	countRaw, unit, ok := strings.Cut(spec, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("속도 제한 %q 형식 오류: <개수>/<s|m|h>[:<burst>]", raw)
+	}
+	count, err := strconv.ParseFloat(strings.TrimSpace(countRaw), 64)
+	if err != nil || count <= 0 {
+		return RateLimit{}, fmt.Errorf("속도 제한 %q 개수는 0보다 커야 합니다", raw)
+	}
+	var per time.Duration
+	switch strings.TrimSpace(unit) {
+	case "s":
+		per = time.Second
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return RateLimit{}, fmt.Errorf("속도 제한 %q 단위는 s, m, h 중 하나여야 합니다", raw)
+	}
+	limit := RateLimit{Rate: count / per.Seconds()}
+	if hasBurst {
+		if limit.Burst, err = strconv.Atoi(strings.TrimSpace(burstRaw)); err != nil || limit.Burst < 1 {
+			return RateLimit{}, fmt.Errorf("속도 제한 %q burst는 1 이상이어야 합니다", raw)
+		}
+	}
+	return limit, nil
+}
+
+// UnmarshalText는 토픽 카탈로그의 rate_limit 값을 ParseRateLimit으로 읽습니다.
+func (r *RateLimit) UnmarshalText(text []byte) error {
+	limit, err := ParseRateLimit(string(text))
+	if err != nil {
+		return err
+	}
+	*r = limit
+	return nil
+}
+
+// Enabled는 제한이 설정되어 있으면 true를 반환합니다.
+func (r RateLimit) Enabled() bool {
+	return r.Rate > 0
+}
+
+func (r RateLimit) burst() int {
+	if r.Burst < 1 {
+		return 1
+	}
+	return r.Burst
+}
+
+func (r RateLimit) String() string {
+	if !r.Enabled() {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%g/s:%d", r.Rate, r.burst())
+}
+
+// WithRateLimit은 구독의 처리 속도를 limit으로 제한합니다. 한 버스에서 같은 토픽을 구독하는 모든 워커와 파티션이
+// 토큰 버킷 하나를 나눠 쓰므로, 프로세스 전체의 처리 속도가 limit을 넘지 않습니다.
+// 옵션을 주지 않으면 KafkaEventBus는 KAFKA_TOPIC_RATE_LIMIT 환경변수와 토픽 카탈로그의 rate_limit을 사용합니다.
+//
+// 토큰을 기다리는 동안에는 해당 워커가 메시지를 처리하지 않으므로, 제한이 낮다면 max.poll.interval.ms를
+// (워커 큐 크기 × 메시지 간격)보다 넉넉히 잡아야 합니다.
+func WithRateLimit(limit RateLimit) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.rateLimit = &limit
+	}
+}
+
+// rateLimiter는 토픽 하나의 토큰 버킷입니다. 토큰이 모자라면 미리 예약(음수 잔량)하고 그만큼 기다리므로,
+// 기다리는 워커들은 도착 순서대로 limit 간격에 맞춰 처리를 시작합니다.
+type rateLimiter struct {
+	mu      sync.Mutex
+	clock   Clock
+	metrics *Metrics
+	topic   string
+	limit   RateLimit
+	tokens  float64
+	last    time.Time
+
+	// 아래는 대기 시간 로그 집계입니다.
+	throttled  int
+	waited     time.Duration
+	reportedAt time.Time
+}
+
+func newRateLimiter(topic string, limit RateLimit, clock Clock, metrics *Metrics) *rateLimiter {
+	if clock == nil {
+		clock = SystemClock
+	}
+	now := clock.Now()
+	return &rateLimiter{clock: clock, metrics: metrics, topic: topic, limit: limit, tokens: float64(limit.burst()), last: now, reportedAt: now}
+}
+
+// reserve는 토큰 하나를 가져가고, 처리를 시작하기 전에 기다려야 하는 시간을 반환합니다.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(float64(l.limit.burst()), l.tokens+elapsed.Seconds()*l.limit.Rate)
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.limit.Rate * float64(time.Second))
+}
+
+// cancel은 기다리다 취소된 예약의 토큰을 돌려줍니다.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(float64(l.limit.burst()), l.tokens+1)
+}
+
+// Wait는 토큰을 얻을 때까지 기다립니다. 기다린 시간은 지표와 주기적인 로그로 남깁니다.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	timer := l.clock.NewTimer(l.clock.Now().Add(wait))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C():
+	}
+	logger.DebugWithFields("속도 제한으로 처리 대기", logger.Fields{"topic": l.topic, "wait": wait.String(), "limit": l.limit.String()})
+	l.metrics.observeRateLimitWait(l.topic, wait)
+	l.record(wait)
+	return nil
+}
+
+// record는 대기 시간을 모았다가 rateLimitReportInterval마다 한 번 로그로 남깁니다.
+func (l *rateLimiter) record(wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.throttled++
+	l.waited += wait
+	now := l.clock.Now()
+	if now.Sub(l.reportedAt) < rateLimitReportInterval {
+		return
+	}
+	logger.InfoWithFields(fmt.Sprintf("토픽 %s 속도 제한으로 최근 %s 동안 %d건이 총 %s 대기했습니다.",
+		l.topic, now.Sub(l.reportedAt).Round(time.Second), l.throttled, l.waited.Round(time.Millisecond)),
+		logger.Fields{"topic": l.topic, "limit": l.limit.String(), "throttled": l.throttled, "waited_ms": l.waited.Milliseconds()})
+	l.throttled = 0
+	l.waited = 0
+	l.reportedAt = now
+}
+
+// rateLimiterSet은 버스 하나가 토픽별로 공유하는 토큰 버킷 모음입니다.
+type rateLimiterSet struct {
+	mu       sync.Mutex
+	limiters map[string]*rateLimiter
+}
+
+// get은 topic의 토큰 버킷을 반환합니다. 같은 토픽을 다른 제한으로 다시 구독하면 먼저 만든 버킷을 쓰고 경고를 남깁니다.
+// limit이 비어 있으면 nil(제한 없음)을 반환합니다.
+func (s *rateLimiterSet) get(topic string, limit RateLimit, clock Clock, metrics *Metrics) *rateLimiter {
+	if !limit.Enabled() {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l, ok := s.limiters[topic]; ok {
+		if l.limit != limit {
+			logger.Log.Warnf("토픽 %s 속도 제한이 이미 %s로 설정되어 있어 %s는 무시합니다.", topic, l.limit, limit)
+		}
+		return l
+	}
+	if s.limiters == nil {
+		s.limiters = make(map[string]*rateLimiter)
+	}
+	l := newRateLimiter(topic, limit, clock, metrics)
+	s.limiters[topic] = l
+	logger.Log.Infof("토픽 %s 처리 속도 제한: %s", topic, limit)
+	return l
+}
+
+// SetTopicRateLimit은 topic(기본 토픽 이름) 구독의 기본 처리 속도 제한을 지정합니다. WithRateLimit 옵션이 우선합니다.
+// 이미 시작한 구독에는 적용되지 않습니다.
+func (k *KafkaEventBus) SetTopicRateLimit(topic string, limit RateLimit) {
+	k.rateLimits.mu.Lock()
+	defer k.rateLimits.mu.Unlock()
+	if k.topicRateLimits == nil {
+		k.topicRateLimits = make(map[string]RateLimit)
+	}
+	k.topicRateLimits[baseTopicName(topic)] = limit
+}
+
+// rateLimiterFor는 구독 옵션이나 토픽 기본값에 따른 topic의 토큰 버킷을 반환합니다. 제한이 없으면 nil입니다.
+func (k *KafkaEventBus) rateLimiterFor(topic string, option *RateLimit) *rateLimiter {
+	var limit RateLimit
+	if option != nil {
+		limit = *option
+	} else {
+		k.rateLimits.mu.Lock()
+		limit = k.topicRateLimits[topic]
+		k.rateLimits.mu.Unlock()
+	}
+	return k.rateLimits.get(topic, limit, k.Clock, k.Metrics)
+}
+
+// getTopicRateLimitsFromEnv 는 토픽 카탈로그(EVENTBUS_TOPIC_CATALOG)의 rate_limit 위에
+// KAFKA_TOPIC_RATE_LIMIT 환경변수의 토픽별 속도 제한을 덮어써 읽어온다.
+// 형식: "tech-letter.post.summary=30/m,tech-letter.post.embedding=5/s:10". 잘못된 항목은 경고 후 건너뛴다.
+func getTopicRateLimitsFromEnv() map[string]RateLimit {
+	out := make(map[string]RateLimit)
+	if path := strings.TrimSpace(os.Getenv("EVENTBUS_TOPIC_CATALOG")); path != "" {
+		catalog, err := LoadTopicCatalog(path)
+		if err != nil {
+			logger.Log.Warnf("토픽 카탈로그의 속도 제한을 읽지 못했습니다: %v", err)
+		} else {
+			for topic, limit := range catalog.RateLimits() {
+				out[topic] = limit
+			}
+		}
+	}
+	for topic, limit := range parseTopicRateLimits("KAFKA_TOPIC_RATE_LIMIT", os.Getenv("KAFKA_TOPIC_RATE_LIMIT")) {
+		out[topic] = limit
+	}
+	return out
+}
+
+func parseTopicRateLimits(key, raw string) map[string]RateLimit {
+	out := make(map[string]RateLimit)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		topic, value, ok := strings.Cut(item, "=")
+		topic = strings.TrimSpace(topic)
+		limit, err := ParseRateLimit(value)
+		if !ok || topic == "" || err != nil {
+			logger.Log.Warnf("%s 환경변수 항목 %q 파싱 실패. 건너뜁니다.", key, item)
+			continue
+		}
+		out[baseTopicName(topic)] = limit
+	}
+	return out
+}
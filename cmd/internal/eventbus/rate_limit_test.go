@@ -0,0 +1,95 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	cases := map[string]RateLimit{
+		"":         {},
+		"30/m":     {Rate: 0.5},
+		"5/s:10":   {Rate: 5, Burst: 10},
+		" 3600/h ": {Rate: 1},
+	}
+	for raw, want := range cases {
+		got, err := ParseRateLimit(raw)
+		if err != nil || got != want {
+			t.Fatalf("ParseRateLimit(%q) = %+v, %v; want %+v", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"30", "0/s", "5/d", "5/s:0", "x/m"} {
+		if _, err := ParseRateLimit(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestRateLimiterSharedBucket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clock := NewManualClock(time.Unix(0, 0))
+	var set rateLimiterSet
+	first := set.get("topic", RateLimit{Rate: 1, Burst: 2}, clock, NewMetrics())
+	if second := set.get("topic", RateLimit{Rate: 10}, clock, nil); second != first {
+		t.Fatalf("subscriptions of the same topic should share one bucket")
+	}
+	if set.get("other", RateLimit{}, clock, nil) != nil {
+		t.Fatalf("expected nil limiter without limit")
+	}
+
+	for range 2 {
+		if err := first.Wait(ctx); err != nil {
+			t.Fatalf("burst should not wait: %v", err)
+		}
+	}
+	done := make(chan error, 1)
+	go func() { done <- first.Wait(ctx) }()
+	select {
+	case <-done:
+		t.Fatalf("third call should wait for a token")
+	case <-time.After(20 * time.Millisecond):
+	}
+	clock.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected wait error: %v", err)
+	}
+	if first.throttled != 1 || first.waited != time.Second {
+		t.Fatalf("expected throttled wait to be recorded, got %d %s", first.throttled, first.waited)
+	}
+
+	go func() { done <- first.Wait(ctx) }()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-done; err == nil {
+		t.Fatalf("expected context error while waiting")
+	}
+}
+
+func TestTopicRateLimitsFromCatalogAndEnv(t *testing.T) {
+	catalog, err := ParseTopicCatalog([]byte(`
+defaults:
+  partitions: 1
+  replication_factor: 1
+topics:
+  - name: tech-letter.post.summary
+    rate_limit: 30/m
+  - name: tech-letter.post.embedding
+`))
+	if err != nil {
+		t.Fatalf("unexpected catalog error: %v", err)
+	}
+	limits := catalog.RateLimits()
+	if len(limits) != 1 || limits["tech-letter.post.summary"] != (RateLimit{Rate: 0.5}) {
+		t.Fatalf("unexpected catalog rate limits: %+v", limits)
+	}
+	if _, err := ParseTopicCatalog([]byte("topics:\n  - name: a\n    rate_limit: fast\n")); err == nil {
+		t.Fatalf("expected error for malformed rate_limit")
+	}
+
+	env := parseTopicRateLimits("TEST", "tech-letter.post.summary.retry.1=10/s, bad=1/d, x")
+	if len(env) != 1 || env["tech-letter.post.summary"] != (RateLimit{Rate: 10}) {
+		t.Fatalf("unexpected env rate limits: %+v", env)
+	}
+}
@@ -0,0 +1,260 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// ReinjectorState는 토픽별 재시도 재주입기의 실행 상태입니다.
+type ReinjectorState string
+
+const (
+	ReinjectorStarting ReinjectorState = "starting"
+	ReinjectorRunning  ReinjectorState = "running"
+	// ReinjectorStopped는 ctx 취소로 정상 종료된 상태입니다.
+	ReinjectorStopped ReinjectorState = "stopped"
+	// ReinjectorFailed는 치명적 오류로 종료된 상태입니다. Error에 원인이 남습니다.
+	ReinjectorFailed ReinjectorState = "failed"
+)
+
+// ErrUnknownReinjector는 재주입기가 시작되지 않은 토픽을 제어하려 할 때 반환됩니다.
+var ErrUnknownReinjector = errors.New("재주입기가 없는 토픽")
+
+// ReinjectorStatus는 기본 토픽 하나의 재주입기 상태입니다.
+type ReinjectorStatus struct {
+	Topic   string          `json:"topic"`
+	GroupID string          `json:"group_id"`
+	State   ReinjectorState `json:"state"`
+	// Paused는 PauseReinjection으로 재주입을 멈춘 상태인지 여부입니다. State와 별개로 유지됩니다.
+	Paused      bool               `json:"paused"`
+	Since       time.Time          `json:"since"`
+	Error       string             `json:"error,omitempty"`
+	RetryTopics []RetryTopicStatus `json:"retry_topics"`
+}
+
+// RetryTopicStatus는 재시도 토픽 하나에서 재주입을 기다리는 메시지 현황입니다.
+type RetryTopicStatus struct {
+	Topic string `json:"topic"`
+	// Pending은 아직 재주입하지 않은 메시지 수(high watermark - 커밋 오프셋)입니다. ReinjectorsWithPending에서만 채워집니다.
+	Pending int64 `json:"pending"`
+	// WaitingPartitions는 지연 시간이 지나기를 기다리며 멈춰 둔 파티션 수입니다.
+	WaitingPartitions int        `json:"waiting_partitions"`
+	NextReadyAt       *time.Time `json:"next_ready_at,omitempty"`
+}
+
+// reinjectorControl은 실행 중인 재주입기와 HTTP 제어/상태 조회 사이에서 공유하는 상태입니다.
+// 컨슈머는 재주입 루프만 다루므로, 일시 정지 요청은 Paused 값만 바꾸고 루프가 다음 반복에서 반영합니다.
+type reinjectorControl struct {
+	mu      sync.Mutex
+	status  ReinjectorStatus
+	waiting map[partitionKey]time.Time
+}
+
+func newReinjectorControl(topic Topic, groupID string, now time.Time) *reinjectorControl {
+	status := ReinjectorStatus{Topic: topic.Base(), GroupID: groupID, State: ReinjectorStarting, Since: now}
+	for _, name := range topic.GetRetryTopics() {
+		status.RetryTopics = append(status.RetryTopics, RetryTopicStatus{Topic: name})
+	}
+	return &reinjectorControl{status: status}
+}
+
+func (rc *reinjectorControl) setState(state ReinjectorState, err error, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.status.State = state
+	rc.status.Since = now
+	rc.status.Error = ""
+	if err != nil {
+		rc.status.Error = err.Error()
+	}
+}
+
+func (rc *reinjectorControl) setPaused(paused bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.status.Paused = paused
+}
+
+func (rc *reinjectorControl) paused() bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.status.Paused
+}
+
+// setWaiting은 지연 대기 중인 파티션 목록을 갱신합니다. 재주입 루프가 매 반복마다 호출합니다.
+func (rc *reinjectorControl) setWaiting(sched *delayScheduler) {
+	waiting := make(map[partitionKey]time.Time, sched.Len())
+	for _, d := range sched.queue {
+		waiting[d.key] = d.readyAt
+	}
+	rc.mu.Lock()
+	rc.waiting = waiting
+	rc.mu.Unlock()
+}
+
+func (rc *reinjectorControl) snapshot() ReinjectorStatus {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	s := rc.status
+	s.RetryTopics = make([]RetryTopicStatus, len(rc.status.RetryTopics))
+	for i, rt := range rc.status.RetryTopics {
+		for key, readyAt := range rc.waiting {
+			if key.topic != rt.Topic {
+				continue
+			}
+			rt.WaitingPartitions++
+			if rt.NextReadyAt == nil || readyAt.Before(*rt.NextReadyAt) {
+				at := readyAt
+				rt.NextReadyAt = &at
+			}
+		}
+		s.RetryTopics[i] = rt
+	}
+	return s
+}
+
+// reinjectorFor는 기본 토픽의 재주입기 제어 상태를 등록합니다. 같은 토픽으로 다시 시작하면 일시 정지 여부를 이어받습니다.
+func (k *KafkaEventBus) reinjectorFor(topic Topic, groupID string) *reinjectorControl {
+	k.reinjectorsMu.Lock()
+	defer k.reinjectorsMu.Unlock()
+	if k.reinjectors == nil {
+		k.reinjectors = make(map[string]*reinjectorControl)
+	}
+	rc := newReinjectorControl(topic, groupID, k.Clock.Now())
+	if prev, ok := k.reinjectors[topic.Base()]; ok {
+		rc.status.Paused = prev.paused()
+	}
+	k.reinjectors[topic.Base()] = rc
+	return rc
+}
+
+func (k *KafkaEventBus) reinjector(topic string) (*reinjectorControl, error) {
+	k.reinjectorsMu.Lock()
+	defer k.reinjectorsMu.Unlock()
+	rc, ok := k.reinjectors[topic]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownReinjector, topic)
+	}
+	return rc, nil
+}
+
+// PauseReinjection은 기본 토픽 topic의 재주입을 멈춥니다. 재시도 토픽의 메시지는 그대로 쌓이며 커밋하지 않습니다.
+// 장애 대응 중 기본 토픽으로의 재유입을 막을 때 사용합니다.
+func (k *KafkaEventBus) PauseReinjection(topic string) error {
+	rc, err := k.reinjector(topic)
+	if err != nil {
+		return err
+	}
+	rc.setPaused(true)
+	return nil
+}
+
+// ResumeReinjection은 PauseReinjection으로 멈춘 재주입을 재개합니다. 멈춘 동안 지연 시간이 지난 메시지는 곧바로 재주입됩니다.
+func (k *KafkaEventBus) ResumeReinjection(topic string) error {
+	rc, err := k.reinjector(topic)
+	if err != nil {
+		return err
+	}
+	rc.setPaused(false)
+	return nil
+}
+
+// Reinjectors는 시작된 재주입기의 상태를 기본 토픽 이름순으로 반환합니다. 브로커에 묻지 않으므로 Pending은 0입니다.
+func (k *KafkaEventBus) Reinjectors() []ReinjectorStatus {
+	k.reinjectorsMu.Lock()
+	controls := make([]*reinjectorControl, 0, len(k.reinjectors))
+	for _, rc := range k.reinjectors {
+		controls = append(controls, rc)
+	}
+	k.reinjectorsMu.Unlock()
+
+	statuses := make([]ReinjectorStatus, len(controls))
+	for i, rc := range controls {
+		statuses[i] = rc.snapshot()
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Topic < statuses[j].Topic })
+	return statuses
+}
+
+// ReinjectorsWithPending은 Reinjectors에 재시도 토픽별 미재주입 메시지 수(Pending)를 채워 반환합니다.
+// 재주입기 그룹의 커밋 오프셋과 파티션 high watermark를 브로커에서 조회합니다.
+func (k *KafkaEventBus) ReinjectorsWithPending(ctx context.Context) ([]ReinjectorStatus, error) {
+	statuses := k.Reinjectors()
+	if len(statuses) == 0 {
+		return statuses, nil
+	}
+	admin, err := kafka.NewAdminClientFromProducer(k.Producer)
+	if err != nil {
+		return nil, fmt.Errorf("AdminClient 생성 실패: %w", err)
+	}
+	defer admin.Close()
+
+	for i := range statuses {
+		for j := range statuses[i].RetryTopics {
+			rt := &statuses[i].RetryTopics[j]
+			pending, err := k.groupPending(ctx, admin, statuses[i].GroupID, rt.Topic)
+			if err != nil {
+				return nil, err
+			}
+			rt.Pending = pending
+		}
+	}
+	return statuses, nil
+}
+
+// groupPending은 groupID가 topic에서 아직 처리하지 않은 메시지 수를 파티션별로 더해 반환합니다.
+// 커밋 기록이 없는 파티션은 low watermark부터 남은 것으로 계산합니다.
+func (k *KafkaEventBus) groupPending(ctx context.Context, admin *kafka.AdminClient, groupID, topic string) (int64, error) {
+	md, err := admin.GetMetadata(&topic, false, 5000)
+	if err != nil {
+		return 0, fmt.Errorf("토픽 %s 메타데이터 조회 실패: %w", topic, err)
+	}
+	tm, ok := md.Topics[topic]
+	if !ok || len(tm.Partitions) == 0 {
+		return 0, nil
+	}
+	partitions := make([]kafka.TopicPartition, len(tm.Partitions))
+	for i, p := range tm.Partitions {
+		partitions[i] = kafka.TopicPartition{Topic: &topic, Partition: p.ID}
+	}
+
+	res, err := admin.ListConsumerGroupOffsets(ctx, []kafka.ConsumerGroupTopicPartitions{{Group: groupID, Partitions: partitions}})
+	if err != nil {
+		return 0, fmt.Errorf("그룹 %s 오프셋 조회 실패: %w", groupID, err)
+	}
+	committed := make(map[int32]kafka.Offset, len(partitions))
+	for _, g := range res.ConsumerGroupsTopicPartitions {
+		for _, tp := range g.Partitions {
+			committed[tp.Partition] = tp.Offset
+		}
+	}
+
+	var pending int64
+	for _, tp := range partitions {
+		low, high, err := k.Producer.QueryWatermarkOffsets(topic, tp.Partition, 5000)
+		if err != nil {
+			return 0, fmt.Errorf("토픽 %s[%d] 워터마크 조회 실패: %w", topic, tp.Partition, err)
+		}
+		from := int64(committed[tp.Partition])
+		if from < low {
+			from = low
+		}
+		if high > from {
+			pending += high - from
+		}
+	}
+	return pending, nil
+}
+
+// forgetReinjector는 더 이상 관리하지 않는 기본 토픽의 재주입기 상태를 지웁니다.
+func (k *KafkaEventBus) forgetReinjector(topic string) {
+	k.reinjectorsMu.Lock()
+	defer k.reinjectorsMu.Unlock()
+	delete(k.reinjectors, topic)
+}
@@ -0,0 +1,51 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestReinjectorControlStatus(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewManualClock(start)
+	bus := &KafkaEventBus{Clock: clock}
+	topic := NewTopic("test.reinjector.status")
+
+	if err := bus.PauseReinjection(topic.Base()); !errors.Is(err, ErrUnknownReinjector) {
+		t.Fatalf("expected ErrUnknownReinjector, got %v", err)
+	}
+
+	rc := bus.reinjectorFor(topic, "group")
+	if err := bus.PauseReinjection(topic.Base()); err != nil {
+		t.Fatalf("unexpected pause error: %v", err)
+	}
+
+	retryTopic := topic.GetRetryTopics()[0]
+	sched := newDelayScheduler(clock)
+	for i, delay := range []time.Duration{time.Minute, 30 * time.Second} {
+		sched.Defer(&kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &retryTopic, Partition: int32(i)}}, start.Add(delay))
+	}
+	rc.setWaiting(sched)
+
+	statuses := bus.Reinjectors()
+	if len(statuses) != 1 || statuses[0].State != ReinjectorStarting || !statuses[0].Paused {
+		t.Fatalf("unexpected statuses: %+v", statuses)
+	}
+	rt := statuses[0].RetryTopics[0]
+	if rt.Topic != retryTopic || rt.WaitingPartitions != 2 || !rt.NextReadyAt.Equal(start.Add(30*time.Second)) {
+		t.Fatalf("unexpected retry topic status: %+v", rt)
+	}
+
+	// 같은 토픽으로 다시 시작해도 일시 정지 상태는 유지된다.
+	bus.reinjectorFor(topic, "group")
+	if !bus.Reinjectors()[0].Paused {
+		t.Fatalf("expected paused state to survive restart")
+	}
+	bus.ResumeReinjection(topic.Base())
+	if bus.Reinjectors()[0].Paused {
+		t.Fatalf("expected reinjection to be resumed")
+	}
+}
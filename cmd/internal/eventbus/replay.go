@@ -0,0 +1,533 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// headerReplayedFrom은 토픽 간 재생으로 복사된 메시지에 원본 위치(토픽/파티션/오프셋)를 기록하는 헤더입니다.
+const headerReplayedFrom = "X-Replayed-From"
+
+// ErrGroupActive는 활성 멤버가 있는 컨슈머 그룹의 오프셋을 바꾸려 할 때 반환되는 오류입니다.
+// Kafka는 구독 중인 그룹의 오프셋 변경을 허용하지 않으므로 해당 서비스를 먼저 중지해야 합니다.
+var ErrGroupActive = errors.New("컨슈머 그룹에 활성 멤버가 있음")
+
+// ErrGroupNotFresh는 새 그룹으로 재생하려 했지만 이미 커밋된 오프셋이 있을 때 반환되는 오류입니다.
+var ErrGroupNotFresh = errors.New("컨슈머 그룹에 이미 커밋된 오프셋이 있음")
+
+// OffsetTargetKind는 오프셋 초기화 기준의 종류입니다.
+type OffsetTargetKind string
+
+const (
+	OffsetTargetEarliest  OffsetTargetKind = "earliest"
+	OffsetTargetLatest    OffsetTargetKind = "latest"
+	OffsetTargetTimestamp OffsetTargetKind = "timestamp"
+	OffsetTargetOffset    OffsetTargetKind = "offset"
+)
+
+// OffsetTarget은 컨슈머 그룹 오프셋을 옮길 위치입니다.
+type OffsetTarget struct {
+	Kind OffsetTargetKind
+	// Time은 Kind가 timestamp일 때 사용합니다. 이 시각 이후(포함) 첫 메시지로 옮깁니다.
+	Time time.Time
+	// Offset은 Kind가 offset일 때 사용합니다. 모든 파티션에 같은 오프셋을 적용하며, 범위를 벗어나면 가장 가까운 끝으로 맞춥니다.
+	Offset int64
+}
+
+// ParseOffsetTarget은 "earliest", "latest", RFC3339 시각, 정수 오프셋 중 하나를 OffsetTarget으로 해석합니다.
+func ParseOffsetTarget(raw string) (OffsetTarget, error) {
+	raw = strings.TrimSpace(raw)
+	switch strings.ToLower(raw) {
+	case string(OffsetTargetEarliest):
+		return OffsetTarget{Kind: OffsetTargetEarliest}, nil
+	case string(OffsetTargetLatest):
+		return OffsetTarget{Kind: OffsetTargetLatest}, nil
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		if n < 0 {
+			return OffsetTarget{}, fmt.Errorf("오프셋은 0 이상이어야 합니다: %d", n)
+		}
+		return OffsetTarget{Kind: OffsetTargetOffset, Offset: n}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return OffsetTarget{}, fmt.Errorf("오프셋 기준 %q 파싱 실패 (earliest, latest, RFC3339 시각, 오프셋 중 하나)", raw)
+	}
+	return OffsetTarget{Kind: OffsetTargetTimestamp, Time: t}, nil
+}
+
+func (t OffsetTarget) String() string {
+	switch t.Kind {
+	case OffsetTargetTimestamp:
+		return t.Time.Format(time.RFC3339)
+	case OffsetTargetOffset:
+		return strconv.FormatInt(t.Offset, 10)
+	default:
+		return string(t.Kind)
+	}
+}
+
+// resolve는 파티션 워터마크 [low, high)와 시각 조회 결과(timeOffset)로 실제 오프셋을 계산합니다.
+// timeOffset이 음수면 해당 시각 이후 메시지가 없다는 뜻이므로 끝(high)으로 옮깁니다.
+func (t OffsetTarget) resolve(low, high, timeOffset int64) int64 {
+	switch t.Kind {
+	case OffsetTargetEarliest:
+		return low
+	case OffsetTargetTimestamp:
+		if timeOffset < 0 {
+			return high
+		}
+		return timeOffset
+	case OffsetTargetOffset:
+		return min(max(t.Offset, low), high)
+	default:
+		return high
+	}
+}
+
+// PartitionOffsetPlan은 파티션 하나의 오프셋 변경 계획입니다.
+type PartitionOffsetPlan struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Low       int64  `json:"low"`
+	High      int64  `json:"high"`
+	// Current는 현재 커밋된 오프셋입니다. 커밋 기록이 없으면 -1입니다.
+	Current int64 `json:"current"`
+	Target  int64 `json:"target"`
+}
+
+// from은 그룹이 다음에 읽을 위치입니다. 커밋 기록이 없으면 auto.offset.reset=earliest에 따라 low부터 읽습니다.
+func (p PartitionOffsetPlan) from() int64 {
+	if p.Current < 0 {
+		return p.Low
+	}
+	return min(max(p.Current, p.Low), p.High)
+}
+
+// Reprocess는 변경 후 다시 처리하게 되는 메시지 수입니다 (오프셋을 뒤로 옮길 때).
+func (p PartitionOffsetPlan) Reprocess() int64 {
+	return max(0, p.from()-p.Target)
+}
+
+// Skip은 변경 후 처리하지 않고 건너뛰게 되는 메시지 수입니다 (오프셋을 앞으로 옮길 때).
+func (p PartitionOffsetPlan) Skip() int64 {
+	return max(0, p.Target-p.from())
+}
+
+// PlanOffsetReset은 groupID의 topic 오프셋을 target으로 옮길 때의 파티션별 변경 내용을 계산합니다.
+// 오프셋은 바꾸지 않으므로 dry-run에 사용합니다.
+func PlanOffsetReset(ctx context.Context, brokers, groupID, topic string, target OffsetTarget) ([]PartitionOffsetPlan, error) {
+	c, err := newAdminConsumer(brokers, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	return planOffsetReset(ctx, c, groupID, topic, target)
+}
+
+// ResetOffsets는 groupID의 topic 오프셋을 target으로 옮기고 적용한 계획을 반환합니다.
+// 그룹에 활성 멤버가 있으면 ErrGroupActive를 반환하므로, 해당 그룹을 쓰는 서비스를 먼저 중지해야 합니다.
+// WithDedup을 쓰는 구독은 이미 처리한 이벤트를 건너뛰므로, 재처리가 목적이면 ReplayToGroup으로 새 그룹을 쓰는 편이 낫습니다.
+func ResetOffsets(ctx context.Context, brokers, groupID, topic string, target OffsetTarget) ([]PartitionOffsetPlan, error) {
+	c, err := newAdminConsumer(brokers, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	plans, err := planOffsetReset(ctx, c, groupID, topic, target)
+	if err != nil {
+		return nil, err
+	}
+	if err := commitGroupOffsets(ctx, c, groupID, plans); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func planOffsetReset(ctx context.Context, c *kafka.Consumer, groupID, topic string, target OffsetTarget) ([]PartitionOffsetPlan, error) {
+	partitions, err := topicPartitions(c, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	committed, err := c.Committed(partitionList(topic, partitions, kafka.OffsetInvalid), 10000)
+	if err != nil {
+		return nil, fmt.Errorf("컨슈머 그룹 %s 커밋 오프셋 조회 실패: %w", groupID, err)
+	}
+	current := make(map[int32]int64, len(committed))
+	for _, tp := range committed {
+		current[tp.Partition] = int64(tp.Offset)
+	}
+
+	var byTime map[int32]int64
+	if target.Kind == OffsetTargetTimestamp {
+		if byTime, err = offsetsForTime(c, topic, partitions, target.Time); err != nil {
+			return nil, err
+		}
+	}
+
+	plans := make([]PartitionOffsetPlan, 0, len(partitions))
+	for _, p := range partitions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		low, high, err := c.QueryWatermarkOffsets(topic, p, 10000)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d] 워터마크 조회 실패: %w", topic, p, err)
+		}
+		cur, ok := current[p]
+		if !ok || cur < 0 {
+			cur = -1
+		}
+		plans = append(plans, PartitionOffsetPlan{
+			Topic:     topic,
+			Partition: p,
+			Low:       low,
+			High:      high,
+			Current:   cur,
+			Target:    target.resolve(low, high, byTime[p]),
+		})
+	}
+	return plans, nil
+}
+
+// ReplayRange는 재생할 메시지의 기간입니다. 메시지 타임스탬프 기준 [Since, Until)이며 Until이 비어 있으면 현재 끝까지입니다.
+type ReplayRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// PartitionRange는 파티션 하나에서 재생할 오프셋 구간 [Start, End)입니다.
+type PartitionRange struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Start     int64  `json:"start"`
+	End       int64  `json:"end"`
+}
+
+// Count는 구간의 메시지 수입니다.
+func (r PartitionRange) Count() int64 {
+	return max(0, r.End-r.Start)
+}
+
+// PlanReplay는 topic 기본 토픽에서 기간에 해당하는 파티션별 오프셋 구간을 계산합니다. dry-run에 사용합니다.
+func PlanReplay(ctx context.Context, brokers string, topic Topic, r ReplayRange) ([]PartitionRange, error) {
+	c, err := newAdminConsumer(brokers, "tech-letter-replay-reader")
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	return planReplay(ctx, c, topic.Base(), r)
+}
+
+func planReplay(ctx context.Context, c *kafka.Consumer, topic string, r ReplayRange) ([]PartitionRange, error) {
+	if r.Since.IsZero() {
+		return nil, errors.New("재생 시작 시각이 필요합니다")
+	}
+	if !r.Until.IsZero() && !r.Since.Before(r.Until) {
+		return nil, fmt.Errorf("재생 기간이 올바르지 않습니다: %s ~ %s", r.Since.Format(time.RFC3339), r.Until.Format(time.RFC3339))
+	}
+	partitions, err := topicPartitions(c, topic)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := offsetsForTime(c, topic, partitions, r.Since)
+	if err != nil {
+		return nil, err
+	}
+	var ends map[int32]int64
+	if !r.Until.IsZero() {
+		if ends, err = offsetsForTime(c, topic, partitions, r.Until); err != nil {
+			return nil, err
+		}
+	}
+
+	ranges := make([]PartitionRange, 0, len(partitions))
+	for _, p := range partitions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		low, high, err := c.QueryWatermarkOffsets(topic, p, 10000)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d] 워터마크 조회 실패: %w", topic, p, err)
+		}
+		start := OffsetTarget{Kind: OffsetTargetTimestamp}.resolve(low, high, starts[p])
+		end := high
+		if ends != nil {
+			end = OffsetTarget{Kind: OffsetTargetTimestamp}.resolve(low, high, ends[p])
+		}
+		ranges = append(ranges, PartitionRange{Topic: topic, Partition: p, Start: start, End: max(start, end)})
+	}
+	return ranges, nil
+}
+
+// ReplayToGroup은 커밋 기록이 없는 새 컨슈머 그룹의 시작 오프셋을 r.Since 위치로 지정합니다.
+// 이 그룹 ID로 컨슈머를 띄우면 해당 시각 이후 메시지를 처음부터 다시 처리하고, 기존 그룹의 진행 상황에는 영향이 없습니다.
+// 그룹은 끝을 정해 멈출 수 없으므로 r.Until은 지원하지 않습니다. 기간을 자르려면 ReplayToTopic을 사용하세요.
+func ReplayToGroup(ctx context.Context, brokers string, topic Topic, groupID string, r ReplayRange) ([]PartitionRange, error) {
+	if !r.Until.IsZero() {
+		return nil, errors.New("새 그룹으로 재생할 때는 종료 시각을 지정할 수 없습니다")
+	}
+	c, err := newAdminConsumer(brokers, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	ranges, err := planReplay(ctx, c, topic.Base(), r)
+	if err != nil {
+		return nil, err
+	}
+	partitions := make([]int32, 0, len(ranges))
+	for _, pr := range ranges {
+		partitions = append(partitions, pr.Partition)
+	}
+	committed, err := c.Committed(partitionList(topic.Base(), partitions, kafka.OffsetInvalid), 10000)
+	if err != nil {
+		return nil, fmt.Errorf("컨슈머 그룹 %s 커밋 오프셋 조회 실패: %w", groupID, err)
+	}
+	for _, tp := range committed {
+		if tp.Offset >= 0 {
+			return nil, fmt.Errorf("%w: %s %s[%d]=%d", ErrGroupNotFresh, groupID, topic.Base(), tp.Partition, tp.Offset)
+		}
+	}
+
+	plans := make([]PartitionOffsetPlan, 0, len(ranges))
+	for _, pr := range ranges {
+		plans = append(plans, PartitionOffsetPlan{Topic: pr.Topic, Partition: pr.Partition, Current: -1, Target: pr.Start})
+	}
+	if err := commitGroupOffsets(ctx, c, groupID, plans); err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+// ReplayToTopic은 topic 기본 토픽에서 기간에 해당하는 메시지를 dest 토픽으로 그대로 복사하고 복사한 메시지 수를 반환합니다.
+// 키, 값, 헤더(코덱/트레이싱)는 원본 그대로 두고 원본 위치를 X-Replayed-From 헤더에 남깁니다.
+// 클레임 체크로 분리된 페이로드는 참조만 복사되므로, 원본 처리 후 삭제(GC)된 블롭은 재생할 수 없습니다.
+func ReplayToTopic(ctx context.Context, brokers string, topic Topic, dest string, r ReplayRange) (int, error) {
+	if dest == "" || dest == topic.Base() {
+		return 0, fmt.Errorf("재생 대상 토픽이 올바르지 않습니다: %q", dest)
+	}
+	c, err := newAdminConsumer(brokers, "tech-letter-replay-reader")
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	ranges, err := planReplay(ctx, c, topic.Base(), r)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := make(map[int32]int64)
+	var assignments []kafka.TopicPartition
+	src := topic.Base()
+	for _, pr := range ranges {
+		if pr.Count() == 0 {
+			continue
+		}
+		remaining[pr.Partition] = pr.End
+		assignments = append(assignments, kafka.TopicPartition{Topic: &src, Partition: pr.Partition, Offset: kafka.Offset(pr.Start)})
+	}
+	if len(assignments) == 0 {
+		return 0, nil
+	}
+	if err := c.Assign(assignments); err != nil {
+		return 0, fmt.Errorf("%s 파티션 할당 실패: %w", src, err)
+	}
+
+	producerCfg := &kafka.ConfigMap{"bootstrap.servers": brokers, "acks": "all"}
+	if maxBytes := getKafkaMessageMaxBytesFromEnv(); maxBytes > 0 {
+		(*producerCfg)["message.max.bytes"] = maxBytes
+	}
+	p, err := kafka.NewProducer(producerCfg)
+	if err != nil {
+		return 0, fmt.Errorf("kafka Producer 생성 실패: %w", err)
+	}
+	defer p.Close()
+
+	deliveries := make(chan kafka.Event, 1024)
+	produced, acked := 0, 0
+	// 전달 보고서를 확인합니다. wait이 0이면 이미 도착한 보고서만 비웁니다.
+	awaitDeliveries := func(want int, wait time.Duration) error {
+		timeout := time.After(wait)
+		for acked < want {
+			var e kafka.Event
+			select {
+			case e = <-deliveries:
+			case <-timeout:
+				if wait > 0 {
+					return fmt.Errorf("%s로 전송 확인되지 않은 메시지 %d건", dest, want-acked)
+				}
+				return nil
+			}
+			acked++
+			if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil {
+				return fmt.Errorf("%s 발행 실패: %w", dest, m.TopicPartition.Error)
+			}
+		}
+		return nil
+	}
+
+	for len(remaining) > 0 {
+		if err := ctx.Err(); err != nil {
+			return acked, err
+		}
+		var msg *kafka.Message
+		switch ev := c.Poll(500).(type) {
+		case *kafka.Message:
+			if ev.TopicPartition.Error != nil {
+				return acked, fmt.Errorf("%s 읽기 실패: %w", src, ev.TopicPartition.Error)
+			}
+			msg = ev
+		case kafka.PartitionEOF:
+			// 구간 끝 오프셋이 컴팩션으로 지워졌거나 트랜잭션 마커라 읽히지 않아도, 파티션 끝에 닿았으면 구간을 다 읽은 것이다.
+			delete(remaining, ev.Partition)
+			continue
+		case kafka.Error:
+			return acked, fmt.Errorf("%s 읽기 실패: %w", src, ev)
+		default:
+			continue
+		}
+		tp := msg.TopicPartition
+		end, ok := remaining[tp.Partition]
+		if !ok {
+			continue
+		}
+		// 구간 끝 직전 오프셋이 빠져 있어도 그 뒤 메시지를 받으면 파티션을 끝낸다.
+		if int64(tp.Offset) >= end-1 {
+			delete(remaining, tp.Partition)
+		}
+		if int64(tp.Offset) >= end {
+			continue
+		}
+
+		headers := append(append([]kafka.Header(nil), msg.Headers...), kafka.Header{
+			Key:   headerReplayedFrom,
+			Value: []byte(fmt.Sprintf("%s/%d/%d", src, tp.Partition, tp.Offset)),
+		})
+		if err := p.Produce(&kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &dest, Partition: kafka.PartitionAny},
+			Key:            msg.Key,
+			Value:          msg.Value,
+			Headers:        headers,
+		}, deliveries); err != nil {
+			return acked, fmt.Errorf("%s 발행 실패: %w", dest, err)
+		}
+		produced++
+		if err := awaitDeliveries(produced, 0); err != nil {
+			return acked, err
+		}
+	}
+
+	if err := awaitDeliveries(produced, getKafkaProducerFlushTimeoutFromEnv()); err != nil {
+		return acked, err
+	}
+	return produced, nil
+}
+
+// newAdminConsumer는 오프셋 조회/변경용 컨슈머를 만듭니다. 토픽을 구독하지 않으므로 그룹에 참여하지 않습니다.
+// 직접 할당한 파티션을 끝까지 읽는 ReplayToTopic을 위해 파티션 끝(PartitionEOF) 이벤트를 켭니다.
+func newAdminConsumer(brokers, groupID string) (*kafka.Consumer, error) {
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":    brokers,
+		"group.id":             groupID,
+		"enable.auto.commit":   false,
+		"enable.partition.eof": true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kafka Consumer 생성 실패: %w", err)
+	}
+	return c, nil
+}
+
+// commitGroupOffsets는 그룹에 활성 멤버가 없는지 확인한 뒤 계획한 오프셋을 커밋합니다.
+func commitGroupOffsets(ctx context.Context, c *kafka.Consumer, groupID string, plans []PartitionOffsetPlan) error {
+	if len(plans) == 0 {
+		return nil
+	}
+	admin, err := kafka.NewAdminClientFromConsumer(c)
+	if err != nil {
+		return fmt.Errorf("AdminClient 생성 실패: %w", err)
+	}
+	defer admin.Close()
+
+	desc, err := admin.DescribeConsumerGroups(ctx, []string{groupID})
+	if err != nil {
+		return fmt.Errorf("컨슈머 그룹 %s 조회 실패: %w", groupID, err)
+	}
+	for _, d := range desc.ConsumerGroupDescriptions {
+		if len(d.Members) > 0 {
+			return fmt.Errorf("%w: %s (멤버 %d, 상태 %s)", ErrGroupActive, groupID, len(d.Members), d.State)
+		}
+	}
+
+	partitions := make([]kafka.TopicPartition, 0, len(plans))
+	for _, p := range plans {
+		topic := p.Topic
+		partitions = append(partitions, kafka.TopicPartition{Topic: &topic, Partition: p.Partition, Offset: kafka.Offset(p.Target)})
+	}
+	res, err := admin.AlterConsumerGroupOffsets(ctx, []kafka.ConsumerGroupTopicPartitions{{Group: groupID, Partitions: partitions}})
+	if err != nil {
+		return fmt.Errorf("컨슈머 그룹 %s 오프셋 변경 실패: %w", groupID, err)
+	}
+	for _, g := range res.ConsumerGroupsTopicPartitions {
+		for _, tp := range g.Partitions {
+			if tp.Error != nil {
+				return fmt.Errorf("컨슈머 그룹 %s %s[%d] 오프셋 변경 실패: %w", groupID, *tp.Topic, tp.Partition, tp.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// topicPartitions는 토픽의 파티션 ID 목록을 오름차순으로 반환합니다.
+func topicPartitions(c *kafka.Consumer, topic string) ([]int32, error) {
+	md, err := c.GetMetadata(&topic, false, 10000)
+	if err != nil {
+		return nil, fmt.Errorf("%s 메타데이터 조회 실패: %w", topic, err)
+	}
+	tmd, ok := md.Topics[topic]
+	if !ok || tmd.Error.Code() == kafka.ErrUnknownTopicOrPart {
+		return nil, fmt.Errorf("토픽 %s가 존재하지 않습니다", topic)
+	}
+	partitions := make([]int32, 0, len(tmd.Partitions))
+	for _, p := range tmd.Partitions {
+		partitions = append(partitions, p.ID)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	return partitions, nil
+}
+
+// offsetsForTime은 파티션별로 t 이후(포함) 첫 메시지의 오프셋을 조회합니다. 해당 메시지가 없으면 -1입니다.
+func offsetsForTime(c *kafka.Consumer, topic string, partitions []int32, t time.Time) (map[int32]int64, error) {
+	found, err := c.OffsetsForTimes(partitionList(topic, partitions, kafka.Offset(t.UnixMilli())), 10000)
+	if err != nil {
+		return nil, fmt.Errorf("%s 시각별 오프셋 조회 실패: %w", topic, err)
+	}
+	out := make(map[int32]int64, len(found))
+	for _, tp := range found {
+		if tp.Error != nil {
+			return nil, fmt.Errorf("%s[%d] 시각별 오프셋 조회 실패: %w", topic, tp.Partition, tp.Error)
+		}
+		out[tp.Partition] = int64(tp.Offset)
+	}
+	return out, nil
+}
+
+func partitionList(topic string, partitions []int32, offset kafka.Offset) []kafka.TopicPartition {
+	out := make([]kafka.TopicPartition, 0, len(partitions))
+	for _, p := range partitions {
+		out = append(out, kafka.TopicPartition{Topic: &topic, Partition: p, Offset: offset})
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOffsetTarget(t *testing.T) {
+	cases := map[string]OffsetTarget{
+		"earliest":             {Kind: OffsetTargetEarliest},
+		" LATEST ":             {Kind: OffsetTargetLatest},
+		"42":                   {Kind: OffsetTargetOffset, Offset: 42},
+		"2025-06-10T00:00:00Z": {Kind: OffsetTargetTimestamp, Time: time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	for raw, want := range cases {
+		got, err := ParseOffsetTarget(raw)
+		if err != nil {
+			t.Fatalf("ParseOffsetTarget(%q) unexpected error: %v", raw, err)
+		}
+		if got.Kind != want.Kind || got.Offset != want.Offset || !got.Time.Equal(want.Time) {
+			t.Fatalf("ParseOffsetTarget(%q) = %+v, want %+v", raw, got, want)
+		}
+	}
+	for _, raw := range []string{"", "-1", "yesterday"} {
+		if _, err := ParseOffsetTarget(raw); err == nil {
+			t.Fatalf("ParseOffsetTarget(%q) expected error", raw)
+		}
+	}
+}
+
+func TestOffsetTargetResolve(t *testing.T) {
+	const low, high = 10, 100
+	cases := []struct {
+		target     OffsetTarget
+		timeOffset int64
+		want       int64
+	}{
+		{OffsetTarget{Kind: OffsetTargetEarliest}, 0, low},
+		{OffsetTarget{Kind: OffsetTargetLatest}, 0, high},
+		{OffsetTarget{Kind: OffsetTargetOffset, Offset: 5}, 0, low},
+		{OffsetTarget{Kind: OffsetTargetOffset, Offset: 50}, 0, 50},
+		{OffsetTarget{Kind: OffsetTargetOffset, Offset: 500}, 0, high},
+		{OffsetTarget{Kind: OffsetTargetTimestamp}, 42, 42},
+		// 해당 시각 이후 메시지가 없으면 끝으로 옮긴다.
+		{OffsetTarget{Kind: OffsetTargetTimestamp}, -1, high},
+	}
+	for _, c := range cases {
+		if got := c.target.resolve(low, high, c.timeOffset); got != c.want {
+			t.Fatalf("%s.resolve(timeOffset=%d) = %d, want %d", c.target, c.timeOffset, got, c.want)
+		}
+	}
+}
+
+func TestPartitionOffsetPlanCounts(t *testing.T) {
+	rewind := PartitionOffsetPlan{Low: 10, High: 100, Current: 80, Target: 30}
+	if rewind.Reprocess() != 50 || rewind.Skip() != 0 {
+		t.Fatalf("unexpected rewind counts: reprocess=%d skip=%d", rewind.Reprocess(), rewind.Skip())
+	}
+	forward := PartitionOffsetPlan{Low: 10, High: 100, Current: 80, Target: 100}
+	if forward.Reprocess() != 0 || forward.Skip() != 20 {
+		t.Fatalf("unexpected forward counts: reprocess=%d skip=%d", forward.Reprocess(), forward.Skip())
+	}
+	// 커밋 기록이 없는 그룹은 가장 앞(low)부터 읽으므로 latest로 옮기면 전체를 건너뛴다.
+	fresh := PartitionOffsetPlan{Low: 10, High: 100, Current: -1, Target: 100}
+	if fresh.Reprocess() != 0 || fresh.Skip() != 90 {
+		t.Fatalf("unexpected fresh counts: reprocess=%d skip=%d", fresh.Reprocess(), fresh.Skip())
+	}
+	if got := (PartitionRange{Start: 30, End: 45}).Count(); got != 15 {
+		t.Fatalf("expected range count 15, got %d", got)
+	}
+}
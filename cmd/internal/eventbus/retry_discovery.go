@@ -0,0 +1,227 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// defaultDiscoveryInterval은 RetryReinjectorSupervisor가 토픽 목록을 다시 조회하는 기본 주기입니다.
+const defaultDiscoveryInterval = 30 * time.Second
+
+// TopicFilter는 재주입할 기본 토픽을 고르는 허용/차단 목록입니다. 패턴은 path.Match 형식입니다 (예: "tech-letter.post.*").
+// Allow가 비어 있으면 모든 토픽을 허용하며, Deny에 걸리면 Allow와 관계없이 제외합니다.
+type TopicFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+// ParseTopicFilter는 쉼표로 구분한 허용/차단 패턴 목록으로 TopicFilter를 만듭니다. 잘못된 패턴이면 오류를 반환합니다.
+func ParseTopicFilter(allow, deny string) (TopicFilter, error) {
+	f := TopicFilter{Allow: splitPatterns(allow), Deny: splitPatterns(deny)}
+	for _, p := range append(slices.Clone(f.Allow), f.Deny...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return TopicFilter{}, fmt.Errorf("토픽 패턴 %q 파싱 실패: %w", p, err)
+		}
+	}
+	return f, nil
+}
+
+func splitPatterns(s string) []string {
+	var patterns []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// Match는 기본 토픽 base가 필터를 통과하면 true를 반환합니다.
+func (f TopicFilter) Match(base string) bool {
+	if matchAny(f.Deny, base) {
+		return false
+	}
+	return len(f.Allow) == 0 || matchAny(f.Allow, base)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscoverRetryTopics는 브로커의 토픽 이름 목록에서 "<base>.retry.<n>" 형식의 재시도 토픽을 찾아 기본 토픽별로 묶습니다.
+//
+// known에 있는 토픽(카탈로그/코드에 선언된 토픽)은 그 재시도 정책을 그대로 씁니다.
+// 그 밖의 토픽은 기본 정책(DefaultRetryPolicy)을 쓰되, 최대 재시도 횟수를 브로커에 있는 가장 큰 n으로 맞춥니다.
+// 결과는 기본 토픽 이름순입니다.
+func DiscoverRetryTopics(names []string, known []Topic) []Topic {
+	maxRetry := make(map[string]int)
+	for _, name := range names {
+		if base, n, ok := ParseRetryTopicName(name); ok && n > maxRetry[base] {
+			maxRetry[base] = n
+		}
+	}
+
+	declared := make(map[string]Topic, len(known))
+	for _, t := range known {
+		declared[t.Base()] = t
+	}
+
+	topics := make([]Topic, 0, len(maxRetry))
+	for base, n := range maxRetry {
+		if t, ok := declared[base]; ok {
+			topics = append(topics, t)
+			continue
+		}
+		policy := DefaultRetryPolicy()
+		policy.MaxAttempts = n
+		topics = append(topics, NewTopic(base).WithRetryPolicy(policy))
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Base() < topics[j].Base() })
+	return topics
+}
+
+// RetryReinjectorSupervisor는 브로커의 재시도 토픽을 주기적으로 찾아 기본 토픽별 재주입기를 시작/중지합니다.
+// Go 코드에 선언되지 않은 토픽(예: Python 서비스만 쓰는 토픽)의 재시도 토픽도 비워지도록 합니다.
+//
+// 재시도 토픽이 하나라도 있는 기본 토픽 중 Filter를 통과한 것만 재주입합니다. 재시도 토픽이 모두 사라지거나
+// 재시도 토픽 구성이 바뀌면 재주입기를 멈추고(필요하면 다시 시작하고), 실패로 끝난 재주입기는 다음 조회 때 다시 시작합니다.
+type RetryReinjectorSupervisor struct {
+	Bus *KafkaEventBus
+	// GroupID는 재주입 컨슈머 그룹의 접두사입니다. 토픽별 그룹은 "<GroupID>-<base의 .을 -로 바꾼 이름>"입니다.
+	GroupID string
+	// Topics는 재시도 정책을 알고 있는 토픽(카탈로그)입니다. 발견된 토픽 중 여기 있는 것은 이 정책을 씁니다.
+	Topics []Topic
+	Filter TopicFilter
+	// Interval은 토픽 목록 조회 주기입니다. 0 이하이면 defaultDiscoveryInterval을 사용합니다.
+	Interval time.Duration
+}
+
+type supervisedReinjector struct {
+	topic  Topic
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Run은 ctx가 취소될 때까지 재주입기를 관리합니다. 종료 시 모든 재주입기를 멈추고 기다립니다.
+func (s *RetryReinjectorSupervisor) Run(ctx context.Context) error {
+	admin, err := kafka.NewAdminClientFromProducer(s.Bus.Producer)
+	if err != nil {
+		return fmt.Errorf("AdminClient 생성 실패: %w", err)
+	}
+	defer admin.Close()
+
+	interval := s.Interval
+	if interval <= 0 {
+		interval = defaultDiscoveryInterval
+	}
+
+	running := make(map[string]*supervisedReinjector)
+	defer func() {
+		for base, r := range running {
+			r.cancel()
+			<-r.done
+			delete(running, base)
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if names, err := listTopicNames(admin); err != nil {
+			// 조회에 실패하면 토픽이 사라진 것으로 오인하지 않도록 현재 재주입기를 그대로 둔다.
+			logger.Log.Errorf("재시도 토픽 조회 실패: %v", err)
+		} else {
+			s.reconcile(ctx, running, names)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile은 발견된 재시도 토픽에 맞춰 재주입기를 시작하거나 멈춥니다.
+func (s *RetryReinjectorSupervisor) reconcile(ctx context.Context, running map[string]*supervisedReinjector, names []string) {
+	desired := make(map[string]Topic)
+	for _, t := range DiscoverRetryTopics(names, s.Topics) {
+		if s.Filter.Match(t.Base()) {
+			desired[t.Base()] = t
+		}
+	}
+
+	for base, r := range running {
+		t, ok := desired[base]
+		if ok && slices.Equal(t.GetRetryTopics(), r.topic.GetRetryTopics()) && !isDone(r.done) {
+			continue
+		}
+		r.cancel()
+		<-r.done
+		delete(running, base)
+		if !ok {
+			s.Bus.forgetReinjector(base)
+			logger.Log.Infof("토픽 %s의 재시도 토픽이 없거나 필터에서 제외되어 재주입기를 중지했습니다.", base)
+		}
+	}
+
+	for base, t := range desired {
+		if _, ok := running[base]; ok {
+			continue
+		}
+		running[base] = s.start(ctx, t)
+		logger.Log.Infof("토픽 %s의 재주입기를 시작합니다. 재시도 토픽: %s", base, strings.Join(t.GetRetryTopics(), ", "))
+	}
+}
+
+func (s *RetryReinjectorSupervisor) start(ctx context.Context, topic Topic) *supervisedReinjector {
+	rctx, cancel := context.WithCancel(ctx)
+	r := &supervisedReinjector{topic: topic, cancel: cancel, done: make(chan struct{})}
+	groupID := s.GroupID + "-" + strings.ReplaceAll(topic.Base(), ".", "-")
+	go func() {
+		defer close(r.done)
+		if err := s.Bus.StartRetryReinjector(rctx, groupID, topic); err != nil && rctx.Err() == nil {
+			logger.Log.Errorf("eventbus retry reinjector error for %s: %v", topic.Base(), err)
+		}
+	}()
+	return r
+}
+
+func isDone(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// listTopicNames는 클러스터의 모든 토픽 이름을 조회합니다.
+func listTopicNames(admin *kafka.AdminClient) ([]string, error) {
+	md, err := admin.GetMetadata(nil, true, 10000)
+	if err != nil {
+		return nil, fmt.Errorf("토픽 메타데이터 조회 실패: %w", err)
+	}
+	names := make([]string, 0, len(md.Topics))
+	for name, tm := range md.Topics {
+		if tm.Error.Code() == kafka.ErrUnknownTopicOrPart {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -0,0 +1,60 @@
+package eventbus
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiscoverRetryTopics(t *testing.T) {
+	known := NewTopic("tech-letter.post.summary").WithRetryPolicy(RetryPolicy{Delays: []time.Duration{time.Second}})
+	names := []string{
+		"tech-letter.post.summary",
+		"tech-letter.post.summary.retry.1",
+		"tech-letter.post.summary.dlq",
+		"python.only.topic",
+		"python.only.topic.retry.1",
+		"python.only.topic.retry.2",
+		"orphan.retry.x",
+		"__consumer_offsets",
+	}
+
+	topics := DiscoverRetryTopics(names, []Topic{known})
+	if len(topics) != 2 {
+		t.Fatalf("expected 2 discovered topics, got %+v", topics)
+	}
+	if topics[0].Base() != "python.only.topic" || !slices.Equal(topics[0].GetRetryTopics(), []string{"python.only.topic.retry.1", "python.only.topic.retry.2"}) {
+		t.Fatalf("unexpected discovered topic: %v", topics[0].GetRetryTopics())
+	}
+	if d, ok := topics[0].RetryDelayForTopic("python.only.topic.retry.2", nil); !ok || d != RetryDelays[1] {
+		t.Fatalf("expected default retry delay for discovered topic, got %v %v", d, ok)
+	}
+	if topics[1].Base() != known.Base() || len(topics[1].GetRetryTopics()) != 1 {
+		t.Fatalf("expected declared topic to keep its policy, got %v", topics[1].GetRetryTopics())
+	}
+}
+
+func TestTopicFilter(t *testing.T) {
+	f, err := ParseTopicFilter("tech-letter.post.*, python.*", "tech-letter.post.embedding")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	cases := map[string]bool{
+		"tech-letter.post.summary":   true,
+		"tech-letter.post.embedding": false,
+		"python.only":                true,
+		"tech-letter.chat.requested": false,
+	}
+	for base, want := range cases {
+		if got := f.Match(base); got != want {
+			t.Fatalf("Match(%q) = %v, want %v", base, got, want)
+		}
+	}
+
+	if !(TopicFilter{}).Match("anything") {
+		t.Fatalf("empty filter should allow all topics")
+	}
+	if _, err := ParseTopicFilter("[", ""); err == nil {
+		t.Fatalf("expected error for malformed pattern")
+	}
+}
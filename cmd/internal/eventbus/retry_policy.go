@@ -0,0 +1,62 @@
+package eventbus
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy는 토픽별 재시도 정책입니다.
+// 재시도 토픽은 "<base>.retry.1" ~ "<base>.retry.<MaxAttempts>" 로 만들어지며,
+// n번째 재시도 토픽의 메시지는 Delays[n-1] 만큼 지연된 뒤 기본 토픽으로 재주입됩니다.
+type RetryPolicy struct {
+	// Delays는 재시도 횟수(1-based)별 지연 시간입니다.
+	// MaxAttempts가 Delays 길이보다 크면 남은 재시도에는 마지막 지연 시간을 반복 사용합니다.
+	Delays []time.Duration
+	// MaxAttempts는 DLQ로 보내기 전 최대 재시도 횟수입니다. 0 이하이면 len(Delays)를 사용합니다.
+	MaxAttempts int
+	// Jitter는 지연 시간에 더할 무작위 비율(0~1)입니다. 0.2이면 지연 시간의 최대 20%를 추가로 기다립니다.
+	// 같은 메시지는 항상 같은 값을 얻도록 메시지 키로부터 결정적으로 계산합니다.
+	Jitter float64
+}
+
+// DefaultRetryPolicy는 별도 정책을 지정하지 않은 토픽이 사용하는 기본 정책(RetryDelays)입니다.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{Delays: RetryDelays}
+}
+
+// Attempts는 정책이 허용하는 최대 재시도 횟수를 반환합니다.
+func (p RetryPolicy) Attempts() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+	return len(p.Delays)
+}
+
+// Delay는 n번째(1-based) 재시도의 지연 시간을 반환합니다. 범위를 벗어나면 false를 반환합니다.
+func (p RetryPolicy) Delay(n int) (time.Duration, bool) {
+	if n <= 0 || n > p.Attempts() || len(p.Delays) == 0 {
+		return 0, false
+	}
+	if n > len(p.Delays) {
+		return p.Delays[len(p.Delays)-1], true
+	}
+	return p.Delays[n-1], true
+}
+
+// delayWithJitter는 n번째 재시도 지연 시간에 key로부터 결정된 지터를 더해 반환합니다.
+func (p RetryPolicy) delayWithJitter(n int, key []byte) (time.Duration, bool) {
+	delay, ok := p.Delay(n)
+	if !ok || p.Jitter <= 0 {
+		return delay, ok
+	}
+	jitter := p.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	h := fnv.New64a()
+	h.Write(key)
+	h.Write([]byte(strconv.Itoa(n)))
+	fraction := float64(h.Sum64()%10000) / 10000
+	return delay + time.Duration(float64(delay)*jitter*fraction), true
+}
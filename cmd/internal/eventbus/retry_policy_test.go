@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTopicRetryTopicsFollowPolicyAttempts(t *testing.T) {
+	topic := NewTopic("test.policy").WithRetryPolicy(RetryPolicy{
+		Delays:      []time.Duration{time.Second, 2 * time.Second},
+		MaxAttempts: 3,
+	})
+
+	if got := len(topic.GetRetryTopics()); got != 3 {
+		t.Fatalf("expected 3 retry topics, got %d", got)
+	}
+	if _, err := topic.GetRetryTopic(4); !errors.Is(err, ErrMaxRetryExceeded) {
+		t.Fatalf("expected ErrMaxRetryExceeded, got %v", err)
+	}
+
+	// MaxAttempts가 Delays보다 길면 마지막 지연 시간을 반복한다.
+	delay, ok := topic.RetryDelayForTopic("test.policy.retry.3", nil)
+	if !ok || delay != 2*time.Second {
+		t.Fatalf("expected 2s for retry.3, got %s (ok=%v)", delay, ok)
+	}
+	if _, ok := topic.RetryDelayForTopic("other.retry.1", nil); ok {
+		t.Fatalf("expected retry topic of another base to be rejected")
+	}
+}
+
+func TestRetryPolicyJitterIsBoundedAndDeterministic(t *testing.T) {
+	policy := RetryPolicy{Delays: []time.Duration{time.Minute}, Jitter: 0.5}
+
+	first, _ := policy.delayWithJitter(1, []byte("evt-1"))
+	again, _ := policy.delayWithJitter(1, []byte("evt-1"))
+	if first != again {
+		t.Fatalf("expected deterministic jitter, got %s and %s", first, again)
+	}
+	if first < time.Minute || first >= 90*time.Second {
+		t.Fatalf("expected delay within [1m, 1m30s), got %s", first)
+	}
+}
+
+func TestNewTopicJSONEventClampsMaxRetryToTopicPolicy(t *testing.T) {
+	topic := NewTopic("test.policy.clamp").WithRetryPolicy(RetryPolicy{
+		Delays: []time.Duration{time.Second, time.Second},
+	})
+
+	evt, err := NewTopicJSONEvent(topic, "", map[string]string{"k": "v"}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evt.MaxRetry != 2 {
+		t.Fatalf("expected max retry clamped to 2, got %d", evt.MaxRetry)
+	}
+	if evt.ID == "" {
+		t.Fatalf("expected generated id")
+	}
+
+	evt, err = NewJSONEvent("", map[string]string{"k": "v"}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evt.MaxRetry != len(RetryDelays) {
+		t.Fatalf("expected max retry clamped to the default policy, got %d", evt.MaxRetry)
+	}
+}
+
+func TestRouteFailureHonorsEventMaxRetry(t *testing.T) {
+	topic := NewTopic("test.policy.route")
+
+	route := routeFailure(topic, Event{ID: "evt-1", Retry: 1, MaxRetry: 2}, errors.New("boom"), failureSource{})
+	if route.DLQ || route.Topic != "test.policy.route.retry.2" {
+		t.Fatalf("expected retry.2, got %+v", route)
+	}
+
+	route = routeFailure(topic, Event{ID: "evt-1", Retry: 2, MaxRetry: 2}, errors.New("boom"), failureSource{})
+	if !route.DLQ || route.Topic != topic.DLQ() {
+		t.Fatalf("expected DLQ route, got %+v", route)
+	}
+}
@@ -0,0 +1,466 @@
+package eventbus
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// SchedulerTopic은 PublishAt/PublishAfter로 예약한 이벤트를 보관하는 토픽입니다.
+// 이벤트 ID를 키로 하는 compact 토픽이며, 예약 취소와 발송 완료는 같은 키의 톰스톤으로 기록합니다.
+// retryworker가 StartScheduler로 이 토픽을 읽어 예약 시각에 대상 토픽으로 발행합니다.
+// Python common/eventbus/topics.py 의 SCHEDULER_TOPIC 과 같은 이름을 유지해야 합니다.
+const SchedulerTopic = "tech-letter.eventbus.scheduled"
+
+const (
+	// headerScheduleTopic은 예약 이벤트를 발행할 대상 토픽입니다.
+	headerScheduleTopic = "X-Schedule-Topic"
+	// headerScheduleAt은 예약 시각(Unix 밀리초)입니다.
+	headerScheduleAt = "X-Schedule-At"
+	// headerScheduleFired는 발송 완료 톰스톤에 붙이는, 발송한 예약 레코드의 오프셋입니다.
+	// 사용자 취소 톰스톤에는 붙지 않습니다.
+	headerScheduleFired = "X-Schedule-Fired"
+)
+
+// ErrScheduleIDRequired는 ID 없는 이벤트를 예약하려 할 때 반환됩니다. 예약은 이벤트 ID로 취소합니다.
+var ErrScheduleIDRequired = errors.New("예약 이벤트에는 ID가 필요합니다")
+
+// schedulerFireRetryBackoff는 예약 이벤트 발행에 실패했을 때 다시 시도하기까지의 대기 시간입니다.
+const schedulerFireRetryBackoff = time.Second
+
+// schedulerPollInterval은 발송할 예약이 없을 때 스케줄러 컨슈머의 최대 Poll 대기 시간입니다.
+const schedulerPollInterval = 100 * time.Millisecond
+
+// scheduleHeaders는 예약 레코드에 붙일 헤더를 만듭니다.
+func scheduleHeaders(headers []kafka.Header, topic string, when time.Time) []kafka.Header {
+	return append(headers,
+		kafka.Header{Key: headerScheduleTopic, Value: []byte(topic)},
+		kafka.Header{Key: headerScheduleAt, Value: []byte(strconv.FormatInt(when.UnixMilli(), 10))},
+	)
+}
+
+// scheduledEntry는 발송을 기다리는 예약 하나입니다.
+type scheduledEntry struct {
+	id        string
+	topic     string
+	at        time.Time
+	value     []byte
+	headers   []kafka.Header
+	partition int32
+	offset    int64
+	index     int
+}
+
+// parseScheduledRecord는 스케줄러 토픽 레코드를 예약으로 해석합니다.
+func parseScheduledRecord(key string, value []byte, headers []kafka.Header, partition int32, offset int64) (*scheduledEntry, error) {
+	e := &scheduledEntry{id: key, value: value, headers: headers, partition: partition, offset: offset}
+	var at string
+	for _, h := range headers {
+		switch h.Key {
+		case headerScheduleTopic:
+			e.topic = string(h.Value)
+		case headerScheduleAt:
+			at = string(h.Value)
+		}
+	}
+	ms, err := strconv.ParseInt(at, 10, 64)
+	if e.topic == "" || err != nil {
+		return nil, fmt.Errorf("예약 %s 헤더 오류: topic=%q at=%q", key, e.topic, at)
+	}
+	e.at = time.UnixMilli(ms)
+	return e, nil
+}
+
+// firedOffset은 발송 완료 톰스톤이면 발송한 레코드의 오프셋을 반환합니다.
+func firedOffset(headers []kafka.Header) (int64, bool) {
+	for _, h := range headers {
+		if h.Key == headerScheduleFired {
+			offset, err := strconv.ParseInt(string(h.Value), 10, 64)
+			return offset, err == nil
+		}
+	}
+	return 0, false
+}
+
+// scheduleBook은 아직 발송하지 않은 예약을 ID별로 보관하는 타이머 힙입니다.
+// 같은 ID로 다시 예약하면 이전 예약을 대체합니다.
+type scheduleBook struct {
+	byID  map[string]*scheduledEntry
+	queue scheduleQueue
+}
+
+func newScheduleBook() *scheduleBook {
+	return &scheduleBook{byID: make(map[string]*scheduledEntry)}
+}
+
+// Apply는 스케줄러 토픽에서 읽은 레코드를 반영합니다. value가 nil이면 톰스톤입니다.
+// 발송 완료 톰스톤은 그 사이 같은 ID로 새로 예약된 항목을 지우지 않습니다.
+func (b *scheduleBook) Apply(key string, value []byte, headers []kafka.Header, partition int32, offset int64) error {
+	if value == nil {
+		if fired, ok := firedOffset(headers); ok {
+			if e, exists := b.byID[key]; exists && e.offset != fired {
+				return nil
+			}
+		}
+		b.Cancel(key)
+		return nil
+	}
+	e, err := parseScheduledRecord(key, value, headers, partition, offset)
+	if err != nil {
+		return err
+	}
+	b.Put(e)
+	return nil
+}
+
+// Put은 예약을 추가하거나 같은 ID의 예약을 대체합니다.
+func (b *scheduleBook) Put(e *scheduledEntry) {
+	b.Cancel(e.id)
+	b.byID[e.id] = e
+	heap.Push(&b.queue, e)
+}
+
+// Cancel은 id의 예약을 지웁니다. 예약이 없으면 false를 반환합니다.
+func (b *scheduleBook) Cancel(id string) bool {
+	e, ok := b.byID[id]
+	if !ok {
+		return false
+	}
+	heap.Remove(&b.queue, e.index)
+	delete(b.byID, id)
+	return true
+}
+
+// Due는 now 기준으로 예약 시각이 지난 항목을 시각 순서로 꺼냅니다.
+func (b *scheduleBook) Due(now time.Time) []*scheduledEntry {
+	var due []*scheduledEntry
+	for b.queue.Len() > 0 && !b.queue[0].at.After(now) {
+		e := heap.Pop(&b.queue).(*scheduledEntry)
+		delete(b.byID, e.id)
+		due = append(due, e)
+	}
+	return due
+}
+
+// Next는 가장 이른 예약 시각을 반환합니다.
+func (b *scheduleBook) Next() (time.Time, bool) {
+	if b.queue.Len() == 0 {
+		return time.Time{}, false
+	}
+	return b.queue[0].at, true
+}
+
+// RemovePartition은 리밸런스로 회수된 파티션의 예약을 버립니다. 새 소유자가 토픽을 처음부터 다시 읽습니다.
+func (b *scheduleBook) RemovePartition(partition int32) {
+	for id, e := range b.byID {
+		if e.partition == partition {
+			b.Cancel(id)
+		}
+	}
+}
+
+// Len은 대기 중인 예약 수를 반환합니다.
+func (b *scheduleBook) Len() int {
+	return b.queue.Len()
+}
+
+// scheduleQueue는 예약 시각 기준 최소 힙입니다.
+type scheduleQueue []*scheduledEntry
+
+func (q scheduleQueue) Len() int { return len(q) }
+
+func (q scheduleQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+
+func (q scheduleQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *scheduleQueue) Push(x any) {
+	e := x.(*scheduledEntry)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *scheduleQueue) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*q = old[:n-1]
+	return e
+}
+
+// PublishAt은 event를 when 시각에 topic으로 발행하도록 SchedulerTopic에 예약합니다.
+// 페이로드 스키마 검사와 클레임 체크는 예약할 때 적용하며, 트레이싱 정보도 예약 시점의 것을 이어갑니다.
+// 예약은 이벤트 ID로 식별하므로 같은 ID로 다시 예약하면 이전 예약을 대체하고, CancelScheduled로 취소합니다.
+// when이 이미 지났으면 스케줄러가 읽는 즉시 발행합니다.
+func (k *KafkaEventBus) PublishAt(ctx context.Context, topic string, event Event, when time.Time) error {
+	if event.ID == "" {
+		return ErrScheduleIDRequired
+	}
+	event, err := k.Schemas.Prepare(topic, event)
+	if err == nil {
+		event, err = k.ClaimCheck.offload(ctx, topic, event)
+	}
+	if err != nil {
+		return err
+	}
+	data, headers, err := encodeEnvelope(k.Envelope, event, traceHeadersFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	_, err = k.produceAsync(ctx, SchedulerTopic, event.ID, data, scheduleHeaders(headers, topic, when)).Wait(ctx)
+	return err
+}
+
+// PublishAfter는 지금부터 delay 뒤에 발행하도록 예약합니다.
+func (k *KafkaEventBus) PublishAfter(ctx context.Context, topic string, event Event, delay time.Duration) error {
+	return k.PublishAt(ctx, topic, event, k.Clock.Now().Add(delay))
+}
+
+// CancelScheduled는 id로 예약한 이벤트를 취소합니다. 이미 발행된 예약이나 없는 ID에 대해서도 오류를 반환하지 않습니다.
+func (k *KafkaEventBus) CancelScheduled(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrScheduleIDRequired
+	}
+	_, err := k.produceAsync(ctx, SchedulerTopic, id, nil, nil).Wait(ctx)
+	return err
+}
+
+// StartScheduler는 SchedulerTopic을 구독해 예약 시각이 된 이벤트를 대상 토픽으로 발행합니다.
+//
+// 예약 시각이 레코드 순서와 무관하므로 오프셋을 커밋하지 않고, 파티션을 할당받을 때마다 처음부터 읽어
+// 남은 예약을 메모리에 다시 쌓습니다. 할당 시점의 파티션 끝(high watermark)까지 모두 읽기 전에는 발행하지 않으므로,
+// 뒤에 기록된 취소/발송 완료 톰스톤을 읽기 전에 지난 예약을 다시 발행하지 않습니다.
+// 발행한 예약은 톰스톤으로 지워 재시작 후 다시 발행하지 않으며,
+// 발행 뒤 톰스톤을 남기기 전에 종료되면 재시작 후 한 번 더 발행될 수 있습니다(최소 한 번 전달).
+func (k *KafkaEventBus) StartScheduler(ctx context.Context, groupID string) error {
+	consumerCfg := &kafka.ConfigMap{
+		"bootstrap.servers":             k.Brokers,
+		"group.id":                      groupID,
+		"auto.offset.reset":             "earliest",
+		"enable.auto.commit":            false,
+		"enable.partition.eof":          true,
+		"partition.assignment.strategy": "range",
+	}
+	c, err := kafka.NewConsumer(consumerCfg)
+	if err != nil {
+		return fmt.Errorf("kafka 스케줄러 컨슈머 생성 실패: %w", err)
+	}
+	defer c.Close()
+
+	book := newScheduleBook()
+	// loading은 아직 할당 시점의 끝까지 읽지 못한 파티션별 high watermark입니다. 비어 있어야 예약을 발행합니다.
+	loading := make(map[int32]int64)
+	loaded := func(partition int32, offset int64) {
+		if high, ok := loading[partition]; ok && offset+1 >= high {
+			delete(loading, partition)
+			if len(loading) == 0 {
+				logger.Log.Infof("예약 이벤트 스케줄러: 예약 %d건을 모두 읽었습니다.", book.Len())
+			}
+		}
+	}
+	rebalanceCb := func(c *kafka.Consumer, ev kafka.Event) error {
+		switch e := ev.(type) {
+		case kafka.AssignedPartitions:
+			partitions := make([]kafka.TopicPartition, len(e.Partitions))
+			for i, tp := range e.Partitions {
+				partitions[i] = kafka.TopicPartition{Topic: tp.Topic, Partition: tp.Partition, Offset: kafka.OffsetBeginning}
+				low, high, err := c.QueryWatermarkOffsets(SchedulerTopic, tp.Partition, 10000)
+				switch {
+				case err != nil:
+					// 끝 위치를 모르면 파티션 끝(PartitionEOF)에 닿을 때까지 기다린다.
+					logger.Log.Warnf("스케줄러 토픽 %s[%d] 워터마크 조회 실패: %v", SchedulerTopic, tp.Partition, err)
+					loading[tp.Partition] = math.MaxInt64
+				case high > low:
+					loading[tp.Partition] = high
+				}
+			}
+			return c.Assign(partitions)
+		case kafka.RevokedPartitions:
+			for _, tp := range e.Partitions {
+				book.RemovePartition(tp.Partition)
+				delete(loading, tp.Partition)
+			}
+			return c.Unassign()
+		}
+		return nil
+	}
+	if err := c.SubscribeTopics([]string{SchedulerTopic}, rebalanceCb); err != nil {
+		return fmt.Errorf("스케줄러 토픽 구독 실패 %s: %w", SchedulerTopic, err)
+	}
+
+	logger.Log.Infof("예약 이벤트 스케줄러 (%s) 시작됨. 구독 토픽: %s", groupID, SchedulerTopic)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Log.Info("예약 이벤트 스케줄러 종료 중.")
+			return ctx.Err()
+		default:
+		}
+
+		wait := schedulerPollInterval
+		if len(loading) == 0 {
+			for _, e := range book.Due(k.Clock.Now()) {
+				if !k.fireScheduled(ctx, e) {
+					e.at = k.Clock.Now().Add(schedulerFireRetryBackoff)
+					book.Put(e)
+				}
+			}
+			if next, ok := book.Next(); ok {
+				wait = min(wait, max(next.Sub(k.Clock.Now()), time.Millisecond))
+			}
+		}
+		k.Metrics.observeScheduled(book.Len())
+
+		switch ev := c.Poll(int(wait / time.Millisecond)).(type) {
+		case *kafka.Message:
+			if ev.TopicPartition.Error != nil {
+				logger.Log.Errorf("스케줄러 컨슈머 메시지 오류: %v", ev.TopicPartition.Error)
+				continue
+			}
+			if err := book.Apply(string(ev.Key), ev.Value, ev.Headers, ev.TopicPartition.Partition, int64(ev.TopicPartition.Offset)); err != nil {
+				logger.Log.Errorf("%v. 예약을 건너뜁니다.", err)
+			}
+			loaded(ev.TopicPartition.Partition, int64(ev.TopicPartition.Offset))
+		case kafka.PartitionEOF:
+			// 끝 오프셋이 컴팩션이나 트랜잭션 마커라 읽히지 않아도 파티션 끝에 닿았으면 다 읽은 것이다.
+			loaded(ev.Partition, math.MaxInt64-1)
+		case kafka.Error:
+			if ev.IsFatal() {
+				return fmt.Errorf("스케줄러 컨슈머 치명적 오류: %w", ev)
+			}
+			logger.Log.Errorf("스케줄러 컨슈머 오류: %v", ev)
+		}
+	}
+}
+
+// fireScheduled는 예약 이벤트를 대상 토픽으로 발행하고 발송 완료 톰스톤을 남깁니다.
+// 발행에 실패하면 false를 반환합니다. 형식이 잘못된 예약은 버리고 true를 반환합니다.
+func (k *KafkaEventBus) fireScheduled(ctx context.Context, e *scheduledEntry) bool {
+	evt, err := DecodeEnvelope(e.value, e.headers)
+	if err != nil {
+		logger.Log.Errorf("예약 %s의 이벤트 페이로드 오류: %v. 예약을 버립니다.", e.id, err)
+	} else {
+		logger.InfoWithFields(fmt.Sprintf("예약 이벤트 %s를 %s로 발행. (예약 시각: %s)", evt.ID, e.topic, e.at.Format(time.RFC3339)),
+			traceLogFields(contextWithTraceHeaders(ctx, e.headers), logger.Fields{"event_id": evt.ID, "topic": e.topic}))
+		if err := k.publish(ctx, e.topic, evt, traceHeaders(e.headers)); err != nil {
+			logger.Log.Errorf("예약 이벤트 %s 발행 실패: %v", evt.ID, err)
+			return false
+		}
+		k.Metrics.observeScheduledFired(e.topic)
+	}
+
+	fired := []kafka.Header{{Key: headerScheduleFired, Value: []byte(strconv.FormatInt(e.offset, 10))}}
+	if _, err := k.produceAsync(ctx, SchedulerTopic, e.id, nil, fired).Wait(ctx); err != nil {
+		// 톰스톤이 없으면 재시작 후 한 번 더 발행될 수 있지만, 이미 발행했으므로 다시 시도하지 않는다.
+		logger.Log.Errorf("예약 %s 발송 완료 기록 실패: %v", e.id, err)
+	}
+	return true
+}
+
+// PublishAt은 event를 when 시각(Clock 기준)에 topic으로 발행하도록 예약합니다. StartScheduler가 실행 중이어야 발행됩니다.
+func (m *MemoryEventBus) PublishAt(ctx context.Context, topic string, event Event, when time.Time) error {
+	if event.ID == "" {
+		return ErrScheduleIDRequired
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	event, err := m.Schemas.Prepare(topic, event)
+	if err == nil {
+		event, err = m.ClaimCheck.offload(ctx, topic, event)
+	}
+	if err != nil {
+		return err
+	}
+	data, headers, err := encodeEnvelope(m.Envelope, event, traceHeadersFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	return m.appendRaw(SchedulerTopic, event.ID, data, scheduleHeaders(headers, topic, when)).Err
+}
+
+// PublishAfter는 Clock 기준 지금부터 delay 뒤에 발행하도록 예약합니다.
+func (m *MemoryEventBus) PublishAfter(ctx context.Context, topic string, event Event, delay time.Duration) error {
+	return m.PublishAt(ctx, topic, event, m.clock.Now().Add(delay))
+}
+
+// CancelScheduled는 id로 예약한 이벤트를 취소합니다.
+func (m *MemoryEventBus) CancelScheduled(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrScheduleIDRequired
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.appendRaw(SchedulerTopic, id, nil, nil).Err
+}
+
+// StartScheduler는 SchedulerTopic을 읽어 예약 시각이 된 이벤트를 대상 토픽으로 발행합니다.
+func (m *MemoryEventBus) StartScheduler(ctx context.Context, groupID string) error {
+	book := newScheduleBook()
+	for {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return ErrBusClosed
+		}
+		key := groupID + "/" + SchedulerTopic
+		msgs := m.topics[SchedulerTopic]
+		for _, msg := range msgs[m.offsets[key]:] {
+			if err := book.Apply(msg.key, msg.value, msg.headers, 0, msg.offset); err != nil {
+				logger.Log.Errorf("%v. 예약을 건너뜁니다.", err)
+			}
+		}
+		m.offsets[key] = len(msgs)
+		notify := m.notify
+		m.mu.Unlock()
+
+		for _, e := range book.Due(m.clock.Now()) {
+			evt, err := DecodeEnvelope(e.value, e.headers)
+			if err != nil {
+				logger.Log.Errorf("예약 %s의 이벤트 페이로드 오류: %v. 예약을 버립니다.", e.id, err)
+				continue
+			}
+			if err := m.publish(ctx, e.topic, evt, traceHeaders(e.headers)); err != nil {
+				return fmt.Errorf("예약 이벤트 %s 발행 실패: %w", evt.ID, err)
+			}
+			fired := []kafka.Header{{Key: headerScheduleFired, Value: []byte(strconv.FormatInt(e.offset, 10))}}
+			m.appendRaw(SchedulerTopic, e.id, nil, fired)
+		}
+
+		var timer Timer
+		var timerC <-chan time.Time
+		if next, ok := book.Next(); ok {
+			if !next.After(m.clock.Now()) {
+				continue
+			}
+			timer = m.clock.NewTimer(next)
+			timerC = timer.C()
+		}
+		select {
+		case <-ctx.Done():
+		case <-notify:
+		case <-timerC:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestMemoryEventBusPublishAt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Unix(0, 0)
+	clock := NewManualClock(start)
+	bus := NewMemoryEventBus(clock)
+	defer bus.Close()
+	topic := NewTopic("test.memory.scheduled")
+
+	schedule := func(id, postID string, when time.Time) {
+		t.Helper()
+		evt, _ := NewTopicJSONEvent(topic, id, testPayload{PostID: postID}, 0)
+		if err := bus.PublishAt(ctx, topic.Base(), evt, when); err != nil {
+			t.Fatalf("unexpected schedule error: %v", err)
+		}
+	}
+	schedule("evt-1", "post-1", start.Add(time.Hour))
+	schedule("evt-2", "post-2", start.Add(3*time.Hour))
+	schedule("evt-3", "post-3", start.Add(30*time.Minute))
+	// 같은 ID로 다시 예약하면 이전 예약을 대체한다.
+	schedule("evt-2", "post-2b", start.Add(90*time.Minute))
+	if err := bus.CancelScheduled(ctx, "evt-3"); err != nil {
+		t.Fatalf("unexpected cancel error: %v", err)
+	}
+	if err := bus.PublishAfter(ctx, topic.Base(), Event{}, time.Minute); !errors.Is(err, ErrScheduleIDRequired) {
+		t.Fatalf("expected ErrScheduleIDRequired, got %v", err)
+	}
+
+	go bus.StartScheduler(ctx, "scheduler")
+
+	clock.Advance(time.Hour)
+	waitFor(t, func() bool { return len(bus.Events(topic.Base())) == 1 })
+	if got := bus.Events(topic.Base())[0]; got.ID != "evt-1" {
+		t.Fatalf("expected evt-1 first, got %+v", got)
+	}
+
+	clock.Advance(3 * time.Hour)
+	waitFor(t, func() bool { return len(bus.Events(topic.Base())) == 2 })
+	time.Sleep(20 * time.Millisecond)
+	events := bus.Events(topic.Base())
+	if len(events) != 2 {
+		t.Fatalf("expected cancelled and replaced schedules not to fire, got %+v", events)
+	}
+	payload, err := DecodeJSON[testPayload](events[1])
+	if err != nil || events[1].ID != "evt-2" || payload.PostID != "post-2b" {
+		t.Fatalf("expected rescheduled evt-2, got %+v (%v)", events[1], err)
+	}
+}
+
+func TestScheduleBookFiredTombstoneKeepsNewerSchedule(t *testing.T) {
+	record := func(when time.Time) ([]byte, []kafka.Header) {
+		return []byte(`{"id":"evt-1"}`), scheduleHeaders(nil, "t", when)
+	}
+	fired := func(offset int64) []kafka.Header {
+		return []kafka.Header{{Key: headerScheduleFired, Value: []byte(strconv.FormatInt(offset, 10))}}
+	}
+	now := time.Unix(1000, 0)
+
+	book := newScheduleBook()
+	value, headers := record(now)
+	if err := book.Apply("evt-1", value, headers, 0, 1); err != nil {
+		t.Fatalf("unexpected apply error: %v", err)
+	}
+	value, headers = record(now.Add(time.Hour))
+	book.Apply("evt-1", value, headers, 0, 2)
+	book.Apply("evt-1", nil, fired(1), 0, 3)
+	if book.Len() != 1 {
+		t.Fatalf("fired tombstone of an older record should keep the newer schedule")
+	}
+	book.Apply("evt-1", nil, nil, 0, 4)
+	if book.Len() != 0 {
+		t.Fatalf("cancel tombstone should remove the schedule")
+	}
+
+	if err := book.Apply("evt-2", []byte(`{}`), nil, 0, 5); err == nil {
+		t.Fatalf("expected error for record without schedule headers")
+	}
+
+	value, headers = record(now.Add(2 * time.Hour))
+	book.Apply("evt-3", value, headers, 1, 6)
+	value, headers = record(now.Add(time.Hour))
+	book.Apply("evt-4", value, headers, 0, 7)
+	if next, _ := book.Next(); !next.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected next schedule: %v", next)
+	}
+	book.RemovePartition(0)
+	due := book.Due(now.Add(3 * time.Hour))
+	if len(due) != 1 || due[0].id != "evt-3" || book.Len() != 0 {
+		t.Fatalf("unexpected due entries: %+v", due)
+	}
+}
+
+func TestKafkaSchedulerWaitsForTombstonesBeforeFiring(t *testing.T) {
+	topic := NewTopic("tech-letter.test.scheduled")
+	bus := newMockKafkaBus(t, topic.Base(), SchedulerTopic)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 지난 예약과 그 취소 톰스톤, 그 뒤의 다른 예약을 순서대로 기록한다.
+	past := time.Now().Add(-time.Minute)
+	for _, id := range []string{"cancelled", "kept"} {
+		if err := bus.PublishAt(ctx, topic.Base(), Event{ID: id, Payload: []byte(`{}`)}, past); err != nil {
+			t.Fatalf("publish at: %v", err)
+		}
+		if id == "cancelled" {
+			if err := bus.CancelScheduled(ctx, id); err != nil {
+				t.Fatalf("cancel: %v", err)
+			}
+		}
+	}
+
+	fired := make(chan string, 2)
+	go bus.StartScheduler(ctx, "scheduler-test")
+	go bus.Subscribe(ctx, "scheduler-test-target", topic, func(ctx context.Context, evt Event) error {
+		fired <- evt.ID
+		return nil
+	})
+
+	select {
+	case id := <-fired:
+		if id != "kept" {
+			t.Fatalf("cancelled schedule was fired before its tombstone was read: %s", id)
+		}
+	case <-ctx.Done():
+		t.Fatalf("scheduled event was not fired")
+	}
+	select {
+	case id := <-fired:
+		t.Fatalf("unexpected fired event %s", id)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
@@ -0,0 +1,279 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrSchemaViolation은 페이로드가 토픽에 등록된 스키마와 맞지 않을 때 반환되는 오류입니다.
+// 컨슈머 쪽에서 발생하면 재시도해도 결과가 같으므로 영구 오류(Permanent)로 DLQ에 보냅니다.
+var ErrSchemaViolation = errors.New("이벤트 스키마 위반")
+
+// SchemaField는 페이로드 필드 하나의 계약입니다.
+type SchemaField struct {
+	Name string `json:"name"`
+	// Kind는 JSON 값 종류입니다: string, integer, number, boolean, array, object
+	Kind string `json:"kind"`
+	// Optional이면 필드가 없거나 null이어도 됩니다.
+	Optional bool `json:"optional"`
+}
+
+// PayloadSchema는 토픽에 실리는 페이로드 타입 하나의 버전별 스키마입니다.
+type PayloadSchema struct {
+	Topic   string        `json:"-"`
+	Type    string        `json:"type"`
+	Version int           `json:"version"`
+	Fields  []SchemaField `json:"fields"`
+	goType  reflect.Type
+}
+
+// SchemaCatalog는 토픽별로 허용되는 페이로드 타입과 버전을 관리합니다.
+// 카탈로그에 없는 토픽은 검증하지 않습니다.
+type SchemaCatalog struct {
+	mu     sync.RWMutex
+	topics map[string]map[string][]*PayloadSchema // topic -> payload type -> 버전 오름차순
+}
+
+// NewSchemaCatalog는 빈 SchemaCatalog를 생성합니다.
+func NewSchemaCatalog() *SchemaCatalog {
+	return &SchemaCatalog{topics: make(map[string]map[string][]*PayloadSchema)}
+}
+
+// RegisterPayload는 topic에 eventType 페이로드의 version 스키마로 T를 등록합니다.
+// T의 json 태그가 필드 이름이 되며, 포인터 또는 omitempty 필드는 선택 필드로 취급합니다.
+func RegisterPayload[T any](c *SchemaCatalog, topic Topic, eventType string, version int) error {
+	if version <= 0 {
+		return fmt.Errorf("스키마 버전은 1 이상이어야 합니다: %s v%d", eventType, version)
+	}
+	goType := reflect.TypeOf((*T)(nil)).Elem()
+	if goType.Kind() != reflect.Struct {
+		return fmt.Errorf("페이로드 타입은 구조체여야 합니다: %s", goType)
+	}
+	schema := &PayloadSchema{
+		Topic:   topic.Base(),
+		Type:    eventType,
+		Version: version,
+		Fields:  schemaFields(goType),
+		goType:  goType,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	byType, ok := c.topics[schema.Topic]
+	if !ok {
+		byType = make(map[string][]*PayloadSchema)
+		c.topics[schema.Topic] = byType
+	}
+	for _, existing := range byType[eventType] {
+		if existing.Version == version {
+			return fmt.Errorf("이미 등록된 스키마입니다: %s %s v%d", schema.Topic, eventType, version)
+		}
+	}
+	versions := append(byType[eventType], schema)
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	byType[eventType] = versions
+	return nil
+}
+
+// Lookup은 topic의 eventType 스키마를 찾습니다. version이 0이면 최신 버전을 반환합니다.
+func (c *SchemaCatalog) Lookup(topic, eventType string, version int) (*PayloadSchema, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	versions := c.topics[topic][eventType]
+	if len(versions) == 0 {
+		return nil, false
+	}
+	if version == 0 {
+		return versions[len(versions)-1], true
+	}
+	for _, s := range versions {
+		if s.Version == version {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// Has는 topic이 카탈로그에 등록되어 있는지 확인합니다.
+func (c *SchemaCatalog) Has(topic string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.topics[topic]
+	return ok
+}
+
+// Validate는 evt의 페이로드가 topic에 등록된 스키마를 따르는지 검사합니다.
+// SchemaVersion이 0이면 페이로드 타입의 최신 버전으로 검사합니다.
+func (c *SchemaCatalog) Validate(topic string, evt Event) error {
+	_, err := c.resolve(topic, evt)
+	return err
+}
+
+// Prepare는 발행 전에 페이로드를 검사하고, SchemaVersion이 비어 있으면 검사에 사용한 버전을 기록해 반환합니다.
+func (c *SchemaCatalog) Prepare(topic string, evt Event) (Event, error) {
+	schema, err := c.resolve(topic, evt)
+	if err != nil {
+		return evt, err
+	}
+	if schema != nil && evt.SchemaVersion == 0 {
+		evt.SchemaVersion = schema.Version
+	}
+	return evt, nil
+}
+
+func (c *SchemaCatalog) resolve(topic string, evt Event) (*PayloadSchema, error) {
+	if !c.Has(topic) {
+		return nil, nil
+	}
+	// 클레임 체크로 분리된 페이로드는 최초 발행 때 이미 검사했다(DLQ 재발행 등).
+	if evt.offloaded() {
+		return nil, nil
+	}
+
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(evt.Payload, &head); err != nil {
+		return nil, fmt.Errorf("%w: 토픽 %s 이벤트 %s 페이로드가 JSON 객체가 아닙니다: %v", ErrSchemaViolation, topic, evt.ID, err)
+	}
+	if head.Type == "" {
+		return nil, fmt.Errorf("%w: 토픽 %s 이벤트 %s 페이로드에 type 필드가 없습니다", ErrSchemaViolation, topic, evt.ID)
+	}
+	schema, ok := c.Lookup(topic, head.Type, evt.SchemaVersion)
+	if !ok {
+		return nil, fmt.Errorf("%w: 토픽 %s에 등록되지 않은 페이로드 %s v%d (이벤트 %s)", ErrSchemaViolation, topic, head.Type, evt.SchemaVersion, evt.ID)
+	}
+	if err := schema.validate(evt.Payload); err != nil {
+		return nil, fmt.Errorf("%w: 토픽 %s 페이로드 %s v%d (이벤트 %s): %v", ErrSchemaViolation, topic, schema.Type, schema.Version, evt.ID, err)
+	}
+	return schema, nil
+}
+
+// validate는 필수 필드가 모두 있고 null이 아닌지 확인한 뒤, 등록된 Go 타입으로 디코딩해 값의 종류를 검사합니다.
+func (s *PayloadSchema) validate(payload json.RawMessage) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return err
+	}
+	var missing []string
+	for _, f := range s.Fields {
+		if f.Optional {
+			continue
+		}
+		raw, ok := fields[f.Name]
+		if !ok || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			missing = append(missing, f.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("필수 필드 누락: %s", strings.Join(missing, ", "))
+	}
+	if err := json.Unmarshal(payload, reflect.New(s.goType).Interface()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// schemaHandler는 핸들러 실행 전에 페이로드를 검사하고, 위반 시 영구 오류로 반환해 바로 DLQ로 보냅니다.
+func schemaHandler(catalog *SchemaCatalog, topic string, handler EventHandler) EventHandler {
+	if catalog == nil {
+		return handler
+	}
+	return func(ctx context.Context, evt Event) error {
+		if err := catalog.Validate(topic, evt); err != nil {
+			return Permanent(err)
+		}
+		return handler(ctx, evt)
+	}
+}
+
+// TopicContract는 토픽 하나의 페이로드 계약입니다. 언어 간 계약 테스트의 골든 파일 형식입니다.
+type TopicContract struct {
+	Topic    string           `json:"topic"`
+	Payloads []*PayloadSchema `json:"payloads"`
+}
+
+// Contracts는 카탈로그 전체를 토픽/타입/버전 순으로 정렬해 반환합니다.
+func (c *SchemaCatalog) Contracts() []TopicContract {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]TopicContract, 0, len(c.topics))
+	for topic, byType := range c.topics {
+		tc := TopicContract{Topic: topic}
+		for _, versions := range byType {
+			tc.Payloads = append(tc.Payloads, versions...)
+		}
+		sort.Slice(tc.Payloads, func(i, j int) bool {
+			if tc.Payloads[i].Type != tc.Payloads[j].Type {
+				return tc.Payloads[i].Type < tc.Payloads[j].Type
+			}
+			return tc.Payloads[i].Version < tc.Payloads[j].Version
+		})
+		out = append(out, tc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Topic < out[j].Topic })
+	return out
+}
+
+// schemaFields는 구조체의 json 태그로부터 필드 계약을 이름순으로 만듭니다. 임베디드 구조체 필드는 펼칩니다.
+func schemaFields(t reflect.Type) []SchemaField {
+	var fields []SchemaField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, schemaFields(sf.Type)...)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		ft := sf.Type
+		optional := strings.Contains(opts, "omitempty")
+		if ft.Kind() == reflect.Pointer {
+			optional = true
+			ft = ft.Elem()
+		}
+		fields = append(fields, SchemaField{Name: name, Kind: jsonKind(ft), Optional: optional})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields
+}
+
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
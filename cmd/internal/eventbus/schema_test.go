@@ -0,0 +1,142 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "testdata 골든 파일을 현재 정의로 갱신")
+
+// eventContractsGolden은 Go/Python 양쪽 계약 테스트가 공유하는 골든 파일입니다.
+// Python 쪽은 common/tests/test_event_contracts.py 가 같은 파일을 읽어 비교합니다.
+var eventContractsGolden = filepath.Join("testdata", "event_contracts.golden.json")
+
+type eventContracts struct {
+	Topics []TopicContract `json:"topics"`
+}
+
+func TestEventContractsMatchGolden(t *testing.T) {
+	got, err := json.MarshalIndent(eventContracts{Topics: DefaultSchemas.Contracts()}, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal contracts: %v", err)
+	}
+	got = append(got, '\n')
+
+	if *updateGolden {
+		if err := os.WriteFile(eventContractsGolden, got, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(eventContractsGolden)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("Go 스키마 카탈로그가 %s 와 다릅니다. 의도한 변경이면 Python common/eventbus/schemas.py 도 맞춘 뒤 `go test -run TestEventContractsMatchGolden -update` 로 갱신하세요.\n%s", eventContractsGolden, got)
+	}
+}
+
+func TestDefaultSchemasCoverAllTopics(t *testing.T) {
+	for _, topic := range AllTopics {
+		if !DefaultSchemas.Has(topic.Base()) {
+			t.Fatalf("topic %s has no registered payload schema", topic.Base())
+		}
+	}
+}
+
+func validSummaryRequest() map[string]any {
+	return map[string]any{
+		"id": "evt-1", "type": EventTypePostSummaryRequested, "timestamp": "2025-01-01T00:00:00Z",
+		"source": "content_service", "version": "1.0",
+		"post_id": "p1", "title": "t", "blog_name": "b", "link": "https://example.com", "published_at": "2025-01-01",
+	}
+}
+
+func TestSchemaCatalogValidate(t *testing.T) {
+	topic := TopicPostSummary
+
+	valid, _ := NewTopicJSONEvent(topic, "", validSummaryRequest(), 0)
+	prepared, err := DefaultSchemas.Prepare(topic.Base(), valid)
+	if err != nil {
+		t.Fatalf("expected valid payload, got %v", err)
+	}
+	if prepared.SchemaVersion != 1 {
+		t.Fatalf("expected schema_version stamped as 1, got %d", prepared.SchemaVersion)
+	}
+
+	missing := validSummaryRequest()
+	delete(missing, "post_id")
+	evt, _ := NewTopicJSONEvent(topic, "", missing, 0)
+	if err := DefaultSchemas.Validate(topic.Base(), evt); !errors.Is(err, ErrSchemaViolation) {
+		t.Fatalf("expected violation for missing post_id, got %v", err)
+	}
+
+	wrongKind := validSummaryRequest()
+	wrongKind["title"] = 42
+	evt, _ = NewTopicJSONEvent(topic, "", wrongKind, 0)
+	if err := DefaultSchemas.Validate(topic.Base(), evt); !errors.Is(err, ErrSchemaViolation) {
+		t.Fatalf("expected violation for numeric title, got %v", err)
+	}
+
+	unknownType := validSummaryRequest()
+	unknownType["type"] = EventTypeCreditConsumed
+	evt, _ = NewTopicJSONEvent(topic, "", unknownType, 0)
+	if err := DefaultSchemas.Validate(topic.Base(), evt); !errors.Is(err, ErrSchemaViolation) {
+		t.Fatalf("expected violation for payload type of another topic, got %v", err)
+	}
+
+	evt, _ = NewTopicJSONEvent(topic, "", validSummaryRequest(), 0)
+	evt.SchemaVersion = 2
+	if err := DefaultSchemas.Validate(topic.Base(), evt); !errors.Is(err, ErrSchemaViolation) {
+		t.Fatalf("expected violation for unknown schema version, got %v", err)
+	}
+
+	// 카탈로그에 없는 토픽은 검사하지 않는다.
+	if err := DefaultSchemas.Validate("test.unregistered", Event{ID: "x"}); err != nil {
+		t.Fatalf("expected unregistered topic to pass, got %v", err)
+	}
+}
+
+func TestMemoryBusRejectsInvalidPayloads(t *testing.T) {
+	ctx := context.Background()
+	bus := NewMemoryEventBus(nil)
+	defer bus.Close()
+
+	bad := validSummaryRequest()
+	delete(bad, "link")
+	evt, _ := NewTopicJSONEvent(TopicPostSummary, "", bad, 0)
+	if err := bus.Publish(ctx, TopicPostSummary.Base(), evt); !errors.Is(err, ErrSchemaViolation) {
+		t.Fatalf("expected publish to reject invalid payload, got %v", err)
+	}
+	if got := len(bus.Events(TopicPostSummary.Base())); got != 0 {
+		t.Fatalf("expected nothing published, got %d", got)
+	}
+
+	// 다른 언어에서 발행된 잘못된 페이로드는 구독 시점에 걸러져 재시도 없이 DLQ로 간다.
+	bus.Schemas = nil
+	if err := bus.Publish(ctx, TopicPostSummary.Base(), evt); err != nil {
+		t.Fatalf("publish without catalog: %v", err)
+	}
+	bus.Schemas = DefaultSchemas
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	called := false
+	go bus.Subscribe(subCtx, "group", TopicPostSummary, func(ctx context.Context, evt Event) error {
+		called = true
+		return nil
+	})
+	waitFor(t, func() bool { return len(bus.Events(TopicPostSummary.DLQ())) == 1 })
+	if called {
+		t.Fatalf("handler must not run for invalid payload")
+	}
+	if reason := bus.Events(TopicPostSummary.DLQ())[0].DLQ.Reason; reason != DLQReasonPermanentError {
+		t.Fatalf("expected permanent error DLQ reason, got %s", reason)
+	}
+}
@@ -0,0 +1,63 @@
+package eventbus
+
+import "time"
+
+// SubscribeOption은 Subscribe 호출 단위의 동작을 조정하는 옵션입니다.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	concurrency  int
+	dedup        DedupStore
+	drainTimeout time.Duration
+	middlewares  []Middleware
+	breaker      *CircuitBreakerConfig
+	rateLimit    *RateLimit
+}
+
+// WithConcurrency는 메시지를 병렬로 처리할 워커 수를 지정합니다.
+// 동일한 메시지 키는 항상 같은 워커에 배정되므로 키 단위 처리 순서는 유지됩니다.
+// 0 이하이면 KAFKA_CONSUMER_CONCURRENCY 환경변수 또는 기본값(1)을 사용합니다.
+func WithConcurrency(n int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithDrainTimeout은 종료(ctx 취소)나 파티션 회수 시 처리 중인 핸들러를 기다릴 최대 시간을 지정합니다.
+// 시간이 지나면 남은 핸들러의 컨텍스트를 취소하고 완료된 오프셋까지만 커밋합니다.
+// 0 이하이면 KAFKA_CONSUMER_DRAIN_TIMEOUT_MS 환경변수 또는 기본값(30초)을 사용합니다.
+func WithDrainTimeout(d time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.drainTimeout = d
+	}
+}
+
+func newSubscribeOptions(opts []SubscribeOption) subscribeOptions {
+	o := subscribeOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.concurrency <= 0 {
+		o.concurrency = getKafkaConsumerConcurrencyFromEnv()
+	}
+	if o.drainTimeout <= 0 {
+		o.drainTimeout = getKafkaConsumerDrainTimeoutFromEnv()
+	}
+	if o.breaker == nil {
+		o.breaker = getCircuitBreakerFromEnv()
+	}
+	return o
+}
+
+// wrapHandler는 옵션으로 지정된 처리 레이어(중복 제거 등)를 핸들러에 덧씌웁니다.
+func (o subscribeOptions) wrapHandler(groupID string, handler EventHandler) EventHandler {
+	if o.dedup != nil {
+		handler = dedupHandler(o.dedup, groupID, handler)
+	}
+	return handler
+}
+
+// wrapMiddlewares는 버스 전역 미들웨어를 가장 바깥에, 구독별 미들웨어를 그 안쪽에 적용합니다.
+func (o subscribeOptions) wrapMiddlewares(global []Middleware, handler EventHandler) EventHandler {
+	return Chain(Chain(handler, o.middlewares...), global...)
+}
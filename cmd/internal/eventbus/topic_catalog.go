@@ -0,0 +1,359 @@
+package eventbus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"tech-letter/cmd/internal/logger"
+)
+
+// TopicSettings는 토픽 하나의 생성/설정 값입니다. 0 값인 필드는 상위 설정(defaults, 기본 토픽)을 물려받습니다.
+type TopicSettings struct {
+	Partitions        int `yaml:"partitions"`
+	ReplicationFactor int `yaml:"replication_factor"`
+	// Retention은 retention.ms 로 적용됩니다. 음수이면 무기한(-1) 보관입니다.
+	Retention time.Duration `yaml:"retention"`
+	// CleanupPolicy는 cleanup.policy 로 적용됩니다 (delete, compact, compact,delete).
+	CleanupPolicy string `yaml:"cleanup_policy"`
+	// Configs는 그 밖의 토픽 설정입니다 (예: max.message.bytes). 키 단위로 상위 설정과 병합됩니다.
+	Configs map[string]string `yaml:"configs"`
+}
+
+// RetryTopicSettings는 재시도 토픽 설정과 재시도 정책입니다.
+// 토픽 설정을 비워 두면 기본 토픽과 같은 값을, Delays를 비워 두면 코드에 선언된 토픽 정책(또는 RetryDelays)을 사용합니다.
+// Python 컨슈머는 항상 RetryDelays 길이만큼 재시도 토픽으로 발행하므로, 재시도 횟수는 코드 정책과 같아야 합니다.
+type RetryTopicSettings struct {
+	TopicSettings `yaml:",inline"`
+	Delays        []time.Duration `yaml:"delays"`
+	MaxAttempts   int             `yaml:"max_attempts"`
+	Jitter        float64         `yaml:"jitter"`
+}
+
+// TopicCatalogEntry는 카탈로그의 기본 토픽 하나와 그 재시도/DLQ 토픽 설정입니다.
+type TopicCatalogEntry struct {
+	Name          string `yaml:"name"`
+	TopicSettings `yaml:",inline"`
+	Retry         RetryTopicSettings `yaml:"retry"`
+	DLQ           TopicSettings      `yaml:"dlq"`
+	// RateLimit은 이 토픽 구독의 처리 속도 제한입니다 (예: "30/m", "5/s:10"). KAFKA_TOPIC_RATE_LIMIT 환경변수가 우선합니다.
+	RateLimit RateLimit `yaml:"rate_limit"`
+}
+
+// TopicCatalog는 EnsureTopics가 클러스터와 맞출 토픽 선언입니다.
+// 운영/개발 환경은 같은 코드를 쓰고 YAML 카탈로그(EVENTBUS_TOPIC_CATALOG)만 다르게 둡니다.
+//
+//	defaults:
+//	  partitions: 3
+//	  replication_factor: 1
+//	  retention: 168h
+//	  dlq:
+//	    partitions: 1
+//	    retention: 720h
+//	topics:
+//	  - name: tech-letter.post.summary
+//	    rate_limit: 30/m
+//	    retry:
+//	      delays: [5m, 15m, 30m, 1h, 2h]
+//	      jitter: 0.2
+type TopicCatalog struct {
+	// Defaults는 모든 토픽에 먼저 적용되는 설정입니다. Name은 사용하지 않습니다.
+	Defaults TopicCatalogEntry   `yaml:"defaults"`
+	Entries  []TopicCatalogEntry `yaml:"topics"`
+	// Scheduler는 예약 이벤트 토픽(SchedulerTopic) 설정입니다. defaults를 물려받되 cleanup_policy 기본값은 compact입니다.
+	Scheduler TopicSettings `yaml:"scheduler"`
+}
+
+// TopicDefinition은 카탈로그를 펼친 실제 Kafka 토픽 하나의 목표 상태입니다.
+type TopicDefinition struct {
+	Name              string
+	Partitions        int
+	ReplicationFactor int
+	Configs           map[string]string
+}
+
+// DefaultTopicCatalog는 카탈로그 파일이 없을 때 사용하는 카탈로그입니다.
+// AllTopics를 기본 토픽·재시도 토픽 3 파티션, DLQ 1 파티션, 복제 계수 1로 선언합니다.
+func DefaultTopicCatalog() *TopicCatalog {
+	c := &TopicCatalog{
+		Defaults: TopicCatalogEntry{
+			TopicSettings: TopicSettings{Partitions: 3, ReplicationFactor: 1},
+			DLQ:           TopicSettings{Partitions: 1},
+		},
+	}
+	for _, t := range AllTopics {
+		c.Entries = append(c.Entries, TopicCatalogEntry{Name: t.Base()})
+	}
+	return c
+}
+
+// LoadTopicCatalog는 YAML 카탈로그 파일을 읽어 검증합니다.
+func LoadTopicCatalog(path string) (*TopicCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("토픽 카탈로그 %s 읽기 실패: %w", path, err)
+	}
+	c, err := ParseTopicCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("토픽 카탈로그 %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// ParseTopicCatalog는 YAML 카탈로그를 파싱해 검증합니다. 오타를 잡기 위해 알 수 없는 키는 오류로 처리합니다.
+func ParseTopicCatalog(data []byte) (*TopicCatalog, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var c TopicCatalog
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("YAML 파싱 실패: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// LoadTopicCatalogFromEnv는 EVENTBUS_TOPIC_CATALOG 경로의 카탈로그를 읽습니다.
+// 환경변수가 비어 있으면 DefaultTopicCatalog를 사용합니다.
+func LoadTopicCatalogFromEnv() (*TopicCatalog, error) {
+	path := strings.TrimSpace(os.Getenv("EVENTBUS_TOPIC_CATALOG"))
+	if path == "" {
+		logger.Log.Infof("EVENTBUS_TOPIC_CATALOG is not set, using default topic catalog")
+		return DefaultTopicCatalog(), nil
+	}
+	return LoadTopicCatalog(path)
+}
+
+// getTopicRetryPoliciesFromEnv 는 토픽 카탈로그(EVENTBUS_TOPIC_CATALOG)에 지정된 기본 토픽별 재시도 정책을 읽어온다.
+// 카탈로그가 없거나 읽지 못하면 코드에 선언된 정책을 그대로 쓴다.
+func getTopicRetryPoliciesFromEnv() map[string]RetryPolicy {
+	path := strings.TrimSpace(os.Getenv("EVENTBUS_TOPIC_CATALOG"))
+	if path == "" {
+		return nil
+	}
+	catalog, err := LoadTopicCatalog(path)
+	if err != nil {
+		logger.Log.Warnf("토픽 카탈로그의 재시도 정책을 읽지 못했습니다: %v", err)
+		return nil
+	}
+	return catalog.RetryPolicies()
+}
+
+// Validate는 토픽 이름 중복과 펼친 뒤의 파티션/복제 계수/정책 값을 검사합니다.
+func (c *TopicCatalog) Validate() error {
+	if len(c.Entries) == 0 {
+		return errors.New("토픽이 하나도 선언되지 않았습니다")
+	}
+	seen := make(map[string]bool, len(c.Entries))
+	for _, e := range c.Entries {
+		if e.Name == "" {
+			return errors.New("이름이 비어 있는 토픽이 있습니다")
+		}
+		if seen[e.Name] {
+			return fmt.Errorf("토픽 %s 가 중복 선언되었습니다", e.Name)
+		}
+		seen[e.Name] = true
+		for _, s := range []TopicSettings{e.TopicSettings, e.Retry.TopicSettings, e.DLQ} {
+			if s.Partitions < 0 || s.ReplicationFactor < 0 {
+				return fmt.Errorf("토픽 %s: 파티션 수와 복제 계수는 음수일 수 없습니다", e.Name)
+			}
+		}
+		if e.Retry.Jitter < 0 || e.Retry.Jitter > 1 || e.Retry.MaxAttempts < 0 {
+			return fmt.Errorf("토픽 %s: jitter 는 0~1, max_attempts 는 0 이상이어야 합니다", e.Name)
+		}
+		for _, d := range e.Retry.Delays {
+			if d <= 0 {
+				return fmt.Errorf("토픽 %s: 재시도 지연 시간은 0보다 커야 합니다", e.Name)
+			}
+		}
+	}
+	declared := declaredTopics()
+	for _, t := range c.Topics() {
+		code, ok := declared[t.Base()]
+		if !ok {
+			code = NewTopic(t.Base())
+		}
+		if got, want := t.RetryPolicy().Attempts(), code.RetryPolicy().Attempts(); got != want {
+			return fmt.Errorf("토픽 %s: 재시도 횟수 %d 는 코드 정책의 %d 와 같아야 합니다 (지연 시간과 jitter 만 바꿀 수 있습니다)", t.Base(), got, want)
+		}
+	}
+	for _, def := range c.Definitions() {
+		if def.Partitions <= 0 || def.ReplicationFactor <= 0 {
+			return fmt.Errorf("토픽 %s: partitions 와 replication_factor 를 defaults 또는 토픽에 지정해야 합니다", def.Name)
+		}
+		switch def.Configs["cleanup.policy"] {
+		case "", "delete", "compact", "compact,delete", "delete,compact":
+		default:
+			return fmt.Errorf("토픽 %s: 알 수 없는 cleanup_policy %q", def.Name, def.Configs["cleanup.policy"])
+		}
+	}
+	return nil
+}
+
+// Topics는 카탈로그의 기본 토픽을 재시도 정책과 함께 Topic으로 반환합니다.
+// 코드에 선언된 토픽(AllTopics)은 그 정책을 기본값으로 쓰고, 카탈로그의 retry 설정이 있으면 덮어씁니다.
+func (c *TopicCatalog) Topics() []Topic {
+	declared := declaredTopics()
+	topics := make([]Topic, 0, len(c.Entries))
+	for _, e := range c.Entries {
+		t, ok := declared[e.Name]
+		if !ok {
+			t = NewTopic(e.Name)
+		}
+		policy := t.RetryPolicy()
+		if delays := firstNonEmpty(e.Retry.Delays, c.Defaults.Retry.Delays); len(delays) > 0 {
+			policy = RetryPolicy{Delays: delays, Jitter: policy.Jitter}
+		}
+		if n := firstPositive(e.Retry.MaxAttempts, c.Defaults.Retry.MaxAttempts); n > 0 {
+			policy.MaxAttempts = n
+		}
+		if j := firstPositive(e.Retry.Jitter, c.Defaults.Retry.Jitter); j > 0 {
+			policy.Jitter = j
+		}
+		topics = append(topics, t.WithRetryPolicy(policy))
+	}
+	return topics
+}
+
+// RetryPolicies는 카탈로그에 retry 정책(delays, max_attempts, jitter)을 지정한 기본 토픽별 재시도 정책을 반환합니다.
+// 지정하지 않은 토픽은 구독 코드가 넘긴 Topic의 정책을 그대로 쓰도록 결과에 넣지 않습니다.
+func (c *TopicCatalog) RetryPolicies() map[string]RetryPolicy {
+	out := make(map[string]RetryPolicy)
+	topics := c.Topics()
+	for i, e := range c.Entries {
+		if e.Retry.hasPolicy() || c.Defaults.Retry.hasPolicy() {
+			out[e.Name] = topics[i].RetryPolicy()
+		}
+	}
+	return out
+}
+
+func (r RetryTopicSettings) hasPolicy() bool {
+	return len(r.Delays) > 0 || r.MaxAttempts > 0 || r.Jitter > 0
+}
+
+func declaredTopics() map[string]Topic {
+	declared := make(map[string]Topic, len(AllTopics))
+	for _, t := range AllTopics {
+		declared[t.Base()] = t
+	}
+	return declared
+}
+
+// RateLimits는 속도 제한이 있는 기본 토픽별 RateLimit을 반환합니다. 토픽에 없으면 defaults의 rate_limit을 씁니다.
+func (c *TopicCatalog) RateLimits() map[string]RateLimit {
+	out := make(map[string]RateLimit)
+	for _, e := range c.Entries {
+		limit := e.RateLimit
+		if !limit.Enabled() {
+			limit = c.Defaults.RateLimit
+		}
+		if limit.Enabled() {
+			out[e.Name] = limit
+		}
+	}
+	return out
+}
+
+// Definitions는 카탈로그를 기본/재시도/DLQ 토픽과 예약 토픽별 목표 상태로 펼칩니다.
+//
+// 기본 토픽은 defaults 위에 토픽 설정을, 재시도 토픽은 기본 토픽 위에 retry 설정을 덮어씁니다.
+// DLQ 토픽은 기본 토픽의 복제 계수와 설정을 물려받지만 파티션 수는 dlq 설정(없으면 1)을 따릅니다.
+func (c *TopicCatalog) Definitions() []TopicDefinition {
+	topics := c.Topics()
+	defs := make([]TopicDefinition, 0, len(topics)*3)
+	for i, e := range c.Entries {
+		base := mergeTopicSettings(c.Defaults.TopicSettings, e.TopicSettings)
+		defs = append(defs, base.definition(e.Name))
+
+		retry := mergeTopicSettings(mergeTopicSettings(base, c.Defaults.Retry.TopicSettings), e.Retry.TopicSettings)
+		for _, name := range topics[i].GetRetryTopics() {
+			defs = append(defs, retry.definition(name))
+		}
+
+		dlqBase := base
+		dlqBase.Partitions = 1
+		dlq := mergeTopicSettings(mergeTopicSettings(dlqBase, c.Defaults.DLQ), e.DLQ)
+		defs = append(defs, dlq.definition(topics[i].DLQ()))
+	}
+
+	scheduler := c.Defaults.TopicSettings
+	scheduler.CleanupPolicy = "compact"
+	defs = append(defs, mergeTopicSettings(scheduler, c.Scheduler).definition(SchedulerTopic))
+	return defs
+}
+
+func (s TopicSettings) definition(name string) TopicDefinition {
+	configs := make(map[string]string, len(s.Configs)+2)
+	for k, v := range s.Configs {
+		configs[k] = v
+	}
+	switch {
+	case s.Retention < 0:
+		configs["retention.ms"] = "-1"
+	case s.Retention > 0:
+		configs["retention.ms"] = strconv.FormatInt(s.Retention.Milliseconds(), 10)
+	}
+	if s.CleanupPolicy != "" {
+		configs["cleanup.policy"] = s.CleanupPolicy
+	}
+	return TopicDefinition{
+		Name:              name,
+		Partitions:        s.Partitions,
+		ReplicationFactor: s.ReplicationFactor,
+		Configs:           configs,
+	}
+}
+
+// mergeTopicSettings는 override의 0이 아닌 값으로 base를 덮어쓴 새 설정을 반환합니다.
+func mergeTopicSettings(base, override TopicSettings) TopicSettings {
+	merged := base
+	if override.Partitions > 0 {
+		merged.Partitions = override.Partitions
+	}
+	if override.ReplicationFactor > 0 {
+		merged.ReplicationFactor = override.ReplicationFactor
+	}
+	if override.Retention != 0 {
+		merged.Retention = override.Retention
+	}
+	if override.CleanupPolicy != "" {
+		merged.CleanupPolicy = override.CleanupPolicy
+	}
+	if len(override.Configs) > 0 {
+		merged.Configs = make(map[string]string, len(base.Configs)+len(override.Configs))
+		for k, v := range base.Configs {
+			merged.Configs[k] = v
+		}
+		for k, v := range override.Configs {
+			merged.Configs[k] = v
+		}
+	}
+	return merged
+}
+
+func firstNonEmpty(values ...[]time.Duration) []time.Duration {
+	for _, v := range values {
+		if len(v) > 0 {
+			return v
+		}
+	}
+	return nil
+}
+
+func firstPositive[T int | float64](values ...T) T {
+	for _, v := range values {
+		if v > 0 {
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,166 @@
+package eventbus
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testCatalogYAML = `
+defaults:
+  partitions: 3
+  replication_factor: 2
+  retention: 168h
+  configs:
+    min.insync.replicas: "1"
+  dlq:
+    retention: 720h
+topics:
+  - name: tech-letter.post.summary
+    partitions: 6
+    configs:
+      max.message.bytes: "10485760"
+    retry:
+      partitions: 2
+      delays: [1m, 5m, 10m, 30m, 1h]
+      jitter: 0.1
+    dlq:
+      cleanup_policy: compact
+  - name: test.catalog.custom
+    retention: -1s
+`
+
+func TestParseTopicCatalogDefinitions(t *testing.T) {
+	c, err := ParseTopicCatalog([]byte(testCatalogYAML))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	defs := map[string]TopicDefinition{}
+	var names []string
+	for _, def := range c.Definitions() {
+		defs[def.Name] = def
+		names = append(names, def.Name)
+	}
+	wantNames := []string{
+		"tech-letter.post.summary", "tech-letter.post.summary.retry.1", "tech-letter.post.summary.retry.2", "tech-letter.post.summary.retry.3",
+		"tech-letter.post.summary.retry.4", "tech-letter.post.summary.retry.5", "tech-letter.post.summary.dlq",
+		"test.catalog.custom", "test.catalog.custom.retry.1", "test.catalog.custom.retry.2", "test.catalog.custom.retry.3",
+		"test.catalog.custom.retry.4", "test.catalog.custom.retry.5", "test.catalog.custom.dlq",
+		SchedulerTopic,
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("unexpected topics: %v", names)
+	}
+
+	base := defs["tech-letter.post.summary"]
+	if base.Partitions != 6 || base.ReplicationFactor != 2 {
+		t.Fatalf("unexpected base definition: %+v", base)
+	}
+	wantConfigs := map[string]string{"min.insync.replicas": "1", "max.message.bytes": "10485760", "retention.ms": "604800000"}
+	if !reflect.DeepEqual(base.Configs, wantConfigs) {
+		t.Fatalf("unexpected base configs: %v", base.Configs)
+	}
+	if retry := defs["tech-letter.post.summary.retry.2"]; retry.Partitions != 2 || !reflect.DeepEqual(retry.Configs, wantConfigs) {
+		t.Fatalf("retry topic should inherit base settings, got %+v", retry)
+	}
+	dlq := defs["tech-letter.post.summary.dlq"]
+	if dlq.Partitions != 1 || dlq.ReplicationFactor != 2 || dlq.Configs["retention.ms"] != "2592000000" || dlq.Configs["cleanup.policy"] != "compact" {
+		t.Fatalf("unexpected dlq definition: %+v", dlq)
+	}
+	if custom := defs["test.catalog.custom"]; custom.Configs["retention.ms"] != "-1" {
+		t.Fatalf("negative retention should mean infinite, got %+v", custom)
+	}
+	if scheduler := defs[SchedulerTopic]; scheduler.Partitions != 3 || scheduler.Configs["cleanup.policy"] != "compact" {
+		t.Fatalf("scheduler topic should be compacted, got %+v", scheduler)
+	}
+}
+
+func TestTopicCatalogTopicsRetryPolicy(t *testing.T) {
+	c, err := ParseTopicCatalog([]byte(testCatalogYAML))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	topics := c.Topics()
+	summary := topics[0].RetryPolicy()
+	if !reflect.DeepEqual(summary.Delays, []time.Duration{time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute, time.Hour}) || summary.Jitter != 0.1 {
+		t.Fatalf("catalog retry policy should override the declared one, got %+v", summary)
+	}
+	if custom := topics[1].RetryPolicy(); !reflect.DeepEqual(custom.Delays, RetryDelays) {
+		t.Fatalf("undeclared topic should use default policy, got %+v", custom)
+	}
+
+	declared := DefaultTopicCatalog().Topics()
+	if !reflect.DeepEqual(declared[0].RetryPolicy(), TopicPostSummary.RetryPolicy()) {
+		t.Fatalf("default catalog should keep declared policies, got %+v", declared[0].RetryPolicy())
+	}
+
+	// 카탈로그에 재시도 정책을 지정한 토픽만 버스가 구독/재주입에 덮어쓴다.
+	policies := c.RetryPolicies()
+	if _, ok := policies["test.catalog.custom"]; ok || !reflect.DeepEqual(policies["tech-letter.post.summary"], summary) {
+		t.Fatalf("unexpected catalog retry policies: %+v", policies)
+	}
+	bus := &KafkaEventBus{topicRetryPolicies: policies}
+	if got := bus.withCatalogRetryPolicy(TopicPostSummary).RetryPolicy(); !reflect.DeepEqual(got, summary) {
+		t.Fatalf("bus should use the catalog retry policy, got %+v", got)
+	}
+	if got := bus.withCatalogRetryPolicy(TopicCredit).RetryPolicy(); !reflect.DeepEqual(got, CreditRetryPolicy) {
+		t.Fatalf("topics without catalog policy should keep the declared one, got %+v", got)
+	}
+}
+
+func TestParseTopicCatalogRejectsInvalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown key":      "topics:\n  - name: a\n    partitons: 3\n    replication_factor: 1\n",
+		"duplicate":        "defaults: {partitions: 1, replication_factor: 1}\ntopics:\n  - name: a\n  - name: a\n",
+		"missing replicas": "topics:\n  - name: a\n    partitions: 1\n",
+		"cleanup policy":   "defaults: {partitions: 1, replication_factor: 1, cleanup_policy: forever}\ntopics:\n  - name: a\n",
+		"retry attempts":   "defaults: {partitions: 1, replication_factor: 1}\ntopics:\n  - name: a\n    retry: {max_attempts: 3}\n",
+		"retry delays":     "defaults: {partitions: 1, replication_factor: 1}\ntopics:\n  - name: a\n    retry: {delays: [1m, 5m]}\n",
+		"empty":            "",
+	}
+	for name, data := range cases {
+		if _, err := ParseTopicCatalog([]byte(data)); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestRepositoryTopicCatalogs(t *testing.T) {
+	for _, name := range []string{"topics.dev.yaml", "topics.prod.yaml"} {
+		c, err := LoadTopicCatalog(filepath.Join("..", "..", "..", "config", name))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(c.Entries) != len(AllTopics) {
+			t.Fatalf("%s: expected %d topics, got %d", name, len(AllTopics), len(c.Entries))
+		}
+	}
+}
+
+func TestPlanTopicChanges(t *testing.T) {
+	defs := []TopicDefinition{
+		{Name: "a", Partitions: 3, ReplicationFactor: 1, Configs: map[string]string{"retention.ms": "100", "cleanup.policy": "delete"}},
+		{Name: "b", Partitions: 3, ReplicationFactor: 1},
+		{Name: "c", Partitions: 1, ReplicationFactor: 3},
+		{Name: "d", Partitions: 1, ReplicationFactor: 1},
+	}
+	current := map[string]topicState{
+		"a": {Partitions: 1, ReplicationFactor: 1, Configs: map[string]string{"retention.ms": "50", "cleanup.policy": "delete", "segment.ms": "1"}},
+		"c": {Partitions: 2, ReplicationFactor: 1},
+		"d": {Partitions: 1, ReplicationFactor: 1},
+	}
+
+	got := planTopicChanges(defs, current)
+	want := []TopicChange{
+		{Topic: "a", Kind: TopicChangeAddPartitions, Key: "partitions", From: "1", To: "3"},
+		{Topic: "a", Kind: TopicChangeAlterConfig, Key: "retention.ms", From: "50", To: "100"},
+		{Topic: "b", Kind: TopicChangeCreate, To: "partitions=3, replication_factor=1"},
+		{Topic: "c", Kind: TopicChangeManual, Key: "partitions", From: "2", To: "1"},
+		{Topic: "c", Kind: TopicChangeManual, Key: "replication_factor", From: "1", To: "3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected plan:\n got %+v\nwant %+v", got, want)
+	}
+}
@@ -1,26 +1,42 @@
 package eventbus
 
 import (
-    "strconv"
-    "strings"
-    "time"
+	"strconv"
+	"strings"
+	"time"
 )
 
-// ParseRetryDelayFromTopicName는 토픽 이름에서 재시도 지연 시간을 추출합니다.
+// ParseRetryTopicName은 "<base>.retry.<n>" 형식의 토픽 이름을 (base, n)으로 분해합니다.
+// n은 1부터 시작하며, 형식이 맞지 않으면 ok=false를 반환합니다.
+func ParseRetryTopicName(name string) (base string, n int, ok bool) {
+	idx := strings.LastIndex(name, ".retry.")
+	if idx <= 0 || idx+7 >= len(name) {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(name[idx+7:])
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+	return name[:idx], n, true
+}
+
+// baseTopicName은 재시도 토픽(<base>.retry.<n>)과 DLQ 토픽(<base>.dlq) 이름을 기본 토픽 이름으로 바꿉니다.
+// 기본 토픽 이름은 그대로 반환합니다.
+func baseTopicName(topic string) string {
+	if base, _, ok := ParseRetryTopicName(topic); ok {
+		return base
+	}
+	return strings.TrimSuffix(topic, ".dlq")
+}
+
+// ParseRetryDelayFromTopicName는 토픽 이름에서 기본 정책(RetryDelays) 기준 재시도 지연 시간을 추출합니다.
 // 지원 형식(단일): "<base>.retry.<n>"  (n은 1부터 시작) => RetryDelays[n-1]
+// 토픽별 정책을 반영하려면 Topic.RetryDelayForTopic을 사용합니다.
 // 반환: (delay, ok)
 func ParseRetryDelayFromTopicName(name string) (time.Duration, bool) {
-    idx := strings.LastIndex(name, ".retry.")
-    if idx == -1 || idx+7 >= len(name) {
-        return 0, false
-    }
-    suffix := name[idx+7:]
-    n, err := strconv.Atoi(suffix)
-    if err != nil {
-        return 0, false
-    }
-    if n <= 0 || n > len(RetryDelays) {
-        return 0, false
-    }
-    return RetryDelays[n-1], true
+	_, n, ok := ParseRetryTopicName(name)
+	if !ok {
+		return 0, false
+	}
+	return DefaultRetryPolicy().Delay(n)
 }
@@ -1,17 +1,73 @@
 package eventbus
 
+import "time"
+
 // 전역 토픽 선언: 기능별 기본 토픽 이름을 관리합니다.
 // 필요시 환경설정으로 교체할 수 있도록 한 곳에서 관리합니다.
+//
+// 재시도 정책의 재시도 횟수는 Python eventbus(RetryDelays)와 같은 5회로 유지합니다.
+// Python 컨슈머도 "<base>.retry.1~5" 토픽으로 재시도를 발행하고, 지연은 retryworker가 이 정책으로 적용합니다.
+
+var (
+	// SummaryRetryPolicy는 LLM 요청 한도(rate limit) 회복을 기다릴 수 있도록 긴 지연과 지터를 사용합니다.
+	SummaryRetryPolicy = RetryPolicy{
+		Delays: []time.Duration{
+			5 * time.Minute,
+			15 * time.Minute,
+			30 * time.Minute,
+			1 * time.Hour,
+			2 * time.Hour,
+		},
+		Jitter: 0.2,
+	}
+
+	// CreditRetryPolicy는 사용자 응답에 직접 영향을 주는 크레딧 이벤트를 위해 짧은 지연을 사용합니다.
+	CreditRetryPolicy = RetryPolicy{
+		Delays: []time.Duration{
+			5 * time.Second,
+			15 * time.Second,
+			30 * time.Second,
+			1 * time.Minute,
+			5 * time.Minute,
+		},
+	}
+)
 
 var (
-	TopicPostSummary                  = NewTopic("tech-letter.post.summary")
+	TopicPostSummary                  = NewTopic("tech-letter.post.summary").WithRetryPolicy(SummaryRetryPolicy)
 	TopicPostEmbedding                = NewTopic("tech-letter.post.embedding")
 	TopicPostEmbeddingDeleteRequested = NewTopic("tech-letter.post.embedding_delete_requested")
-	TopicCredit                       = NewTopic("tech-letter.credit")
+	TopicCredit                       = NewTopic("tech-letter.credit").WithRetryPolicy(CreditRetryPolicy)
 	TopicChat                         = NewTopic("tech-letter.chat")
 	TopicChatContextCompression       = NewTopic("tech-letter.chat.context_compression")
 )
 
+// DefaultSchemas는 AllTopics에 실리는 페이로드 타입과 버전을 등록한 카탈로그입니다.
+// KafkaEventBus/MemoryEventBus는 기본으로 이 카탈로그로 발행/구독 페이로드를 검사합니다.
+// Python common/eventbus/schemas.py 와 같은 내용을 유지해야 하며, testdata/event_contracts.golden.json 으로 검증합니다.
+var DefaultSchemas = newDefaultSchemaCatalog()
+
+func newDefaultSchemaCatalog() *SchemaCatalog {
+	c := NewSchemaCatalog()
+	must := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+	must(RegisterPayload[PostSummaryRequestedPayload](c, TopicPostSummary, EventTypePostSummaryRequested, 1))
+	must(RegisterPayload[PostSummaryResponsePayload](c, TopicPostSummary, EventTypePostSummaryResponse, 1))
+	must(RegisterPayload[PostEmbeddingRequestedPayload](c, TopicPostEmbedding, EventTypePostEmbeddingRequested, 1))
+	must(RegisterPayload[PostEmbeddingResponsePayload](c, TopicPostEmbedding, EventTypePostEmbeddingResponse, 1))
+	must(RegisterPayload[PostEmbeddingAppliedPayload](c, TopicPostEmbedding, EventTypePostEmbeddingApplied, 1))
+	must(RegisterPayload[PostEmbeddingDeleteRequestedPayload](c, TopicPostEmbeddingDeleteRequested, EventTypePostEmbeddingDeleteRequested, 1))
+	must(RegisterPayload[CreditConsumedPayload](c, TopicCredit, EventTypeCreditConsumed, 1))
+	must(RegisterPayload[CreditGrantedPayload](c, TopicCredit, EventTypeCreditGranted, 1))
+	must(RegisterPayload[ChatCompletedPayload](c, TopicChat, EventTypeChatCompleted, 1))
+	must(RegisterPayload[ChatFailedPayload](c, TopicChat, EventTypeChatFailed, 1))
+	must(RegisterPayload[ChatContextCompressionRequestedPayload](c, TopicChatContextCompression, EventTypeChatContextCompressionRequest, 1))
+	return c
+}
+
 var AllTopics = []Topic{
 	TopicPostSummary,
 	TopicPostEmbedding,
@@ -0,0 +1,70 @@
+package eventbus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"tech-letter/cmd/internal/logger"
+	"tech-letter/cmd/internal/trace"
+)
+
+// Kafka 메시지 헤더에 기록하는 트레이싱 키. HTTP 헤더(X-Request-Id/X-Span-Id)와 동일한 이름을 사용한다.
+const (
+	headerRequestID = "X-Request-Id"
+	headerSpanID    = "X-Span-Id"
+)
+
+// traceHeadersFromContext는 발행 시점의 컨텍스트에서 Request ID와 다음 Span ID를 꺼내 헤더로 만든다.
+// HTTP 아웃바운드 호출과 마찬가지로 발행 1회를 하나의 span으로 보고 span 시퀀스를 증가시킨다.
+// 컨텍스트에 트레이스가 없으면 새 Request ID를 생성해 이벤트 흐름 단위로라도 추적할 수 있게 한다.
+func traceHeadersFromContext(ctx context.Context) []kafka.Header {
+	requestID, spanID := trace.NextSpanID(ctx)
+	return []kafka.Header{
+		{Key: headerRequestID, Value: []byte(requestID)},
+		{Key: headerSpanID, Value: []byte(spanID)},
+	}
+}
+
+// traceHeaders는 메시지 헤더 중 트레이싱 헤더만 골라 반환한다.
+// 재시도/DLQ/재주입 시 원본 트레이스를 그대로 이어가기 위해 사용한다.
+func traceHeaders(headers []kafka.Header) []kafka.Header {
+	var out []kafka.Header
+	for _, h := range headers {
+		if h.Key == headerRequestID || h.Key == headerSpanID {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// contextWithTraceHeaders는 메시지 헤더의 트레이싱 정보를 핸들러 컨텍스트로 복원한다.
+// 헤더가 없으면 ctx를 그대로 반환한다.
+func contextWithTraceHeaders(ctx context.Context, headers []kafka.Header) context.Context {
+	var requestID string
+	var span int64
+	for _, h := range headers {
+		switch h.Key {
+		case headerRequestID:
+			requestID = string(h.Value)
+		case headerSpanID:
+			if v, err := strconv.ParseInt(string(h.Value), 10, 64); err == nil {
+				span = v
+			}
+		}
+	}
+	if requestID == "" {
+		return ctx
+	}
+	return trace.WithRequestAndSpan(ctx, requestID, span)
+}
+
+// traceLogFields는 컨텍스트의 트레이싱 정보를 구조화 로그 필드로 변환한다.
+func traceLogFields(ctx context.Context, fields logger.Fields) logger.Fields {
+	if requestID := trace.RequestIDFromContext(ctx); requestID != "" {
+		fields["request_id"] = requestID
+		fields["span_id"] = trace.CurrentSpanID(ctx)
+	}
+	return fields
+}
@@ -14,7 +14,7 @@ type ctxKey string
 
 const ctxKeyTrace ctxKey = "trace_info"
 
-// Info는 하나의 HTTP 요청에 대한 트레이싱 정보를 담는다.
+// Info는 하나의 HTTP 요청(및 그로부터 이어지는 이벤트 처리)에 대한 트레이싱 정보를 담는다.
 // - RequestID: 요청 단위로 고유
 // - spanSeq: 동일 RequestID 내에서 각 outbound 호출마다 1,2,3,... 순차 증가
 type Info struct {
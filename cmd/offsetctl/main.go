@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"tech-letter/cmd/internal/eventbus"
+	"tech-letter/cmd/internal/logger"
+)
+
+const usage = `offsetctl: 컨슈머 그룹 오프셋 초기화/기간 재생 도구
+
+사용법:
+  offsetctl reset   -group <group_id> -topic <topic> -to <earliest|latest|RFC3339|offset> [-dry-run]
+  offsetctl replay  -topic <base> -since <RFC3339> -to-group <new_group_id> [-dry-run]
+  offsetctl replay  -topic <base> -since <RFC3339> [-until <RFC3339>] -to-topic <topic> [-dry-run]
+
+reset 은 그룹에 활성 멤버가 없어야 하므로 해당 서비스를 먼저 중지한 뒤 실행한다.
+replay -to-group 은 커밋 기록이 없는 새 그룹의 시작 위치를 -since 로 지정하며, 이후 서비스를 그 그룹 ID 로 띄우면 된다.
+replay -to-topic 은 기간 안의 메시지를 다른 토픽으로 그대로 복사한다.
+-dry-run 은 아무것도 바꾸지 않고 파티션별로 영향을 받는 메시지 수만 출력한다.
+`
+
+func main() {
+	logger.InitFromEnv("LOG_LEVEL")
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "reset":
+		err = runReset(ctx, args)
+	case "replay":
+		err = runReplay(ctx, args)
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령: %s\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "offsetctl %s 실패: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func runReset(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("reset", flag.ExitOnError)
+	group := fs.String("group", "", "컨슈머 그룹 ID")
+	topic := fs.String("topic", "", "토픽 이름 (기본/재시도 토픽 모두 가능)")
+	to := fs.String("to", "", "earliest, latest, RFC3339 시각 또는 오프셋")
+	dryRun := fs.Bool("dry-run", false, "변경하지 않고 파티션별 영향만 출력")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *group == "" || *topic == "" || *to == "" {
+		return errors.New("-group, -topic, -to 가 필요합니다")
+	}
+	target, err := eventbus.ParseOffsetTarget(*to)
+	if err != nil {
+		return err
+	}
+
+	brokers := eventbus.GetBrokers()
+	var plans []eventbus.PartitionOffsetPlan
+	if *dryRun {
+		plans, err = eventbus.PlanOffsetReset(ctx, brokers, *group, *topic, target)
+	} else {
+		plans, err = eventbus.ResetOffsets(ctx, brokers, *group, *topic, target)
+	}
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TOPIC\tPARTITION\tLOW\tHIGH\tCURRENT\tTARGET\tREPROCESS\tSKIP")
+	var reprocess, skip int64
+	for _, p := range plans {
+		current := "-"
+		if p.Current >= 0 {
+			current = fmt.Sprint(p.Current)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%d\t%d\t%d\n", p.Topic, p.Partition, p.Low, p.High, current, p.Target, p.Reprocess(), p.Skip())
+		reprocess += p.Reprocess()
+		skip += p.Skip()
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if *dryRun {
+		fmt.Printf("그룹 %s: %s 기준 재처리 %d건, 건너뜀 %d건 (dry-run)\n", *group, target, reprocess, skip)
+		return nil
+	}
+	fmt.Printf("그룹 %s 오프셋을 %s 기준으로 변경했습니다: 재처리 %d건, 건너뜀 %d건\n", *group, target, reprocess, skip)
+	return nil
+}
+
+func runReplay(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	topicName := fs.String("topic", "", "기본 토픽 이름 (예: tech-letter.post.summary)")
+	sinceStr := fs.String("since", "", "재생 시작 시각 (RFC3339, 포함)")
+	untilStr := fs.String("until", "", "재생 종료 시각 (RFC3339, 제외, -to-topic 에서만 사용)")
+	toGroup := fs.String("to-group", "", "재생할 새 컨슈머 그룹 ID")
+	toTopic := fs.String("to-topic", "", "메시지를 복사할 토픽")
+	dryRun := fs.Bool("dry-run", false, "변경하지 않고 파티션별 대상 메시지 수만 출력")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *topicName == "" || *sinceStr == "" {
+		return errors.New("-topic, -since 가 필요합니다")
+	}
+	if (*toGroup == "") == (*toTopic == "") {
+		return errors.New("-to-group 과 -to-topic 중 하나만 지정해야 합니다")
+	}
+
+	var r eventbus.ReplayRange
+	var err error
+	if r.Since, err = time.Parse(time.RFC3339, *sinceStr); err != nil {
+		return fmt.Errorf("-since 파싱 실패: %w", err)
+	}
+	if *untilStr != "" {
+		if *toGroup != "" {
+			return errors.New("-until 은 -to-topic 과 함께만 사용할 수 있습니다")
+		}
+		if r.Until, err = time.Parse(time.RFC3339, *untilStr); err != nil {
+			return fmt.Errorf("-until 파싱 실패: %w", err)
+		}
+	}
+
+	topic := eventbus.NewTopic(*topicName)
+
+	brokers := eventbus.GetBrokers()
+	ranges, err := eventbus.PlanReplay(ctx, brokers, topic, r)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TOPIC\tPARTITION\tSTART\tEND\tMESSAGES")
+	var total int64
+	for _, pr := range ranges {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", pr.Topic, pr.Partition, pr.Start, pr.End, pr.Count())
+		total += pr.Count()
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if *dryRun {
+		fmt.Printf("대상 %d건 (dry-run)\n", total)
+		return nil
+	}
+
+	if *toGroup != "" {
+		if _, err := eventbus.ReplayToGroup(ctx, brokers, topic, *toGroup, r); err != nil {
+			return err
+		}
+		fmt.Printf("그룹 %s 의 시작 위치를 %s 로 지정했습니다. 이 그룹 ID 로 컨슈머를 실행하세요.\n", *toGroup, r.Since.Format(time.RFC3339))
+		return nil
+	}
+	copied, err := eventbus.ReplayToTopic(ctx, brokers, topic, *toTopic, r)
+	if err != nil {
+		return fmt.Errorf("%d건 복사 후 실패: %w", copied, err)
+	}
+	fmt.Printf("%s -> %s %d건 복사\n", topic.Base(), *toTopic, copied)
+	return nil
+}
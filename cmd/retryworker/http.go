@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"tech-letter/cmd/internal/eventbus"
+	"tech-letter/cmd/internal/logger"
+)
+
+// statusQueryTimeout은 /status에서 재시도 토픽의 대기 메시지 수를 브로커에 조회할 때의 제한 시간이다.
+const statusQueryTimeout = 5 * time.Second
+
+// reinjectorController 는 HTTP 핸들러가 사용하는 재주입기 조회/제어 기능이다. *eventbus.KafkaEventBus 가 구현한다.
+type reinjectorController interface {
+	Reinjectors() []eventbus.ReinjectorStatus
+	ReinjectorsWithPending(ctx context.Context) ([]eventbus.ReinjectorStatus, error)
+	PauseReinjection(topic string) error
+	ResumeReinjection(topic string) error
+}
+
+// lagReporter 는 마지막 컨슈머 지연 검사 결과를 제공한다. *eventbus.LagMonitor 가 구현한다.
+type lagReporter interface {
+	Report() eventbus.LagReport
+}
+
+// newServeMux 는 /metrics, 상태 확인, 재주입 제어 엔드포인트를 등록한 핸들러를 만든다.
+//   - GET /healthz: 실패(failed)한 재주입기가 있으면 503
+//   - GET /readyz: 모든 재주입기가 running 이 아니면 503 (일시 정지는 준비된 것으로 본다)
+//   - GET /status: 재주입기 상태와 재시도 토픽별 대기 메시지 수
+//   - GET /lag: 컨슈머 그룹별 기본/재시도 토픽 지연, DLQ 크기와 현재 알림
+//   - POST /admin/reinjectors/{topic}/pause|resume: 토픽별 재주입 일시 정지/재개
+//
+// /admin 요청은 "Authorization: Bearer <adminToken>" 헤더가 있어야 하며, adminToken 이 비어 있으면 모두 403 으로 거부한다.
+func newServeMux(ctrl reinjectorController, lag lagReporter, adminToken string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		statuses := ctrl.Reinjectors()
+		code := http.StatusOK
+		for _, s := range statuses {
+			if s.State == eventbus.ReinjectorFailed {
+				code = http.StatusServiceUnavailable
+				break
+			}
+		}
+		writeJSON(w, code, map[string]any{"reinjectors": statuses})
+	})
+
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		statuses := ctrl.Reinjectors()
+		code := http.StatusOK
+		if len(statuses) == 0 {
+			code = http.StatusServiceUnavailable
+		}
+		for _, s := range statuses {
+			if s.State != eventbus.ReinjectorRunning {
+				code = http.StatusServiceUnavailable
+				break
+			}
+		}
+		writeJSON(w, code, map[string]any{"reinjectors": statuses})
+	})
+
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), statusQueryTimeout)
+		defer cancel()
+		statuses, err := ctrl.ReinjectorsWithPending(ctx)
+		if err != nil {
+			logger.Log.Errorf("failed to query retry topic backlog: %v", err)
+			writeJSON(w, http.StatusBadGateway, map[string]any{"error": err.Error(), "reinjectors": ctrl.Reinjectors()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"reinjectors": statuses})
+	})
+
+	mux.HandleFunc("GET /lag", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, lag.Report())
+	})
+
+	admin := func(action string, fn func(topic string) error) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if adminToken == "" {
+				writeJSON(w, http.StatusForbidden, map[string]any{"error": "admin endpoints are disabled: RETRY_WORKER_ADMIN_TOKEN is not set"})
+				return
+			}
+			if !authorized(r, adminToken) {
+				writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "unauthorized"})
+				return
+			}
+			topic := r.PathValue("topic")
+			if err := fn(topic); err != nil {
+				code := http.StatusInternalServerError
+				if errors.Is(err, eventbus.ErrUnknownReinjector) {
+					code = http.StatusNotFound
+				}
+				writeJSON(w, code, map[string]any{"error": err.Error()})
+				return
+			}
+			logger.InfoWithFields("retry reinjection "+action, logger.Fields{"topic": topic, "remote_addr": r.RemoteAddr})
+			writeJSON(w, http.StatusOK, map[string]any{"topic": topic, "action": action})
+		}
+	}
+	mux.HandleFunc("POST /admin/reinjectors/{topic}/pause", admin("paused", ctrl.PauseReinjection))
+	mux.HandleFunc("POST /admin/reinjectors/{topic}/resume", admin("resumed", ctrl.ResumeReinjection))
+
+	return mux
+}
+
+func authorized(r *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Log.Errorf("failed to write http response: %v", err)
+	}
+}
+
+// startHTTPServer 는 newServeMux 핸들러를 제공하는 HTTP 서버를 백그라운드로 시작한다.
+func startHTTPServer(addr string, handler http.Handler) *http.Server {
+	srv := &http.Server{Addr: addr, Handler: handler}
+	go func() {
+		logger.Log.Infof("http server listening on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Log.Errorf("http server error: %v", err)
+		}
+	}()
+	return srv
+}
+
+// getMetricsAddrFromEnv 는 RETRY_WORKER_METRICS_ADDR 환경변수에서 HTTP 서버 주소를 읽어온다.
+// 비어 있으면 기본값 ":9100" 을 사용한다.
+func getMetricsAddrFromEnv() string {
+	if addr := strings.TrimSpace(os.Getenv("RETRY_WORKER_METRICS_ADDR")); addr != "" {
+		return addr
+	}
+	return ":9100"
+}
+
+// getAdminTokenFromEnv 는 RETRY_WORKER_ADMIN_TOKEN 환경변수에서 /admin 엔드포인트의 Bearer 토큰을 읽어온다.
+// 비어 있으면 /admin 엔드포인트를 사용할 수 없다.
+func getAdminTokenFromEnv() string {
+	return strings.TrimSpace(os.Getenv("RETRY_WORKER_ADMIN_TOKEN"))
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tech-letter/cmd/internal/eventbus"
+)
+
+type fakeController struct {
+	statuses []eventbus.ReinjectorStatus
+	paused   map[string]bool
+}
+
+func (f *fakeController) Reinjectors() []eventbus.ReinjectorStatus { return f.statuses }
+
+func (f *fakeController) ReinjectorsWithPending(context.Context) ([]eventbus.ReinjectorStatus, error) {
+	return f.statuses, nil
+}
+
+func (f *fakeController) PauseReinjection(topic string) error { return f.setPaused(topic, true) }
+
+func (f *fakeController) ResumeReinjection(topic string) error { return f.setPaused(topic, false) }
+
+func (f *fakeController) setPaused(topic string, paused bool) error {
+	for _, s := range f.statuses {
+		if s.Topic == topic {
+			f.paused[topic] = paused
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", eventbus.ErrUnknownReinjector, topic)
+}
+
+type fakeLag struct{}
+
+func (fakeLag) Report() eventbus.LagReport { return eventbus.LagReport{} }
+
+func TestHealthAndReadiness(t *testing.T) {
+	ctrl := &fakeController{paused: map[string]bool{}}
+	mux := newServeMux(ctrl, fakeLag{}, "")
+	get := func(path string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	if code := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected not ready without reinjectors, got %d", code)
+	}
+
+	ctrl.statuses = []eventbus.ReinjectorStatus{
+		{Topic: "a", State: eventbus.ReinjectorRunning, Paused: true},
+		{Topic: "b", State: eventbus.ReinjectorStarting},
+	}
+	if code := get("/healthz"); code != http.StatusOK {
+		t.Fatalf("expected healthy, got %d", code)
+	}
+	if code := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected not ready while starting, got %d", code)
+	}
+
+	ctrl.statuses[1].State = eventbus.ReinjectorRunning
+	if code := get("/readyz"); code != http.StatusOK {
+		t.Fatalf("expected ready, got %d", code)
+	}
+
+	ctrl.statuses[1].State = eventbus.ReinjectorFailed
+	if code := get("/healthz"); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected unhealthy with failed reinjector, got %d", code)
+	}
+	if code := get("/status"); code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+	if code := get("/lag"); code != http.StatusOK {
+		t.Fatalf("expected lag 200, got %d", code)
+	}
+}
+
+func TestAdminPauseResume(t *testing.T) {
+	ctrl := &fakeController{
+		statuses: []eventbus.ReinjectorStatus{{Topic: "tech-letter.post.created", State: eventbus.ReinjectorRunning}},
+		paused:   map[string]bool{},
+	}
+	mux := newServeMux(ctrl, fakeLag{}, "secret")
+	post := func(path, token string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := post("/admin/reinjectors/tech-letter.post.created/pause", ""); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", code)
+	}
+	if code := post("/admin/reinjectors/tech-letter.post.created/pause", "secret"); code != http.StatusOK || !ctrl.paused["tech-letter.post.created"] {
+		t.Fatalf("expected topic to be paused, got %d", code)
+	}
+	if code := post("/admin/reinjectors/tech-letter.post.created/resume", "secret"); code != http.StatusOK || ctrl.paused["tech-letter.post.created"] {
+		t.Fatalf("expected topic to be resumed, got %d", code)
+	}
+	if code := post("/admin/reinjectors/unknown/pause", "secret"); code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown topic, got %d", code)
+	}
+	if !errors.Is(ctrl.PauseReinjection("unknown"), eventbus.ErrUnknownReinjector) {
+		t.Fatalf("expected ErrUnknownReinjector")
+	}
+
+	// 토큰을 설정하지 않으면 /admin 은 열리지 않는다.
+	rec := httptest.NewRecorder()
+	newServeMux(ctrl, fakeLag{}, "").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/reinjectors/tech-letter.post.created/pause", nil))
+	if rec.Code != http.StatusForbidden || ctrl.paused["tech-letter.post.created"] {
+		t.Fatalf("expected 403 without admin token, got %d", rec.Code)
+	}
+}
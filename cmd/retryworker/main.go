@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"tech-letter/cmd/internal/eventbus"
 	"tech-letter/cmd/internal/logger"
@@ -19,10 +20,29 @@ func main() {
 	defer cancel()
 
 	brokers := eventbus.GetBrokers()
-	for _, t := range eventbus.AllTopics {
-		if err := eventbus.EnsureTopics(brokers, t, 3); err != nil {
-			logger.Log.Errorf("failed to ensure eventbus topics for %s: %v", t.Base(), err)
+	catalog, err := eventbus.LoadTopicCatalogFromEnv()
+	if err != nil {
+		logger.Log.Errorf("failed to load topic catalog: %v", err)
+		os.Exit(1)
+	}
+	ensureCtx, ensureCancel := context.WithTimeout(ctx, 30*time.Second)
+	changes, err := eventbus.EnsureTopics(ensureCtx, brokers, catalog, false)
+	ensureCancel()
+	if err != nil {
+		logger.Log.Errorf("failed to ensure eventbus topics: %v", err)
+	}
+	for _, c := range changes {
+		if c.Kind == eventbus.TopicChangeManual {
+			logger.Log.Warnf("topic catalog drift needs manual action: %s", c)
+			continue
 		}
+		logger.Log.Infof("topic catalog applied: %s", c)
+	}
+
+	filter, err := eventbus.ParseTopicFilter(os.Getenv("RETRY_WORKER_TOPIC_ALLOW"), os.Getenv("RETRY_WORKER_TOPIC_DENY"))
+	if err != nil {
+		logger.Log.Errorf("failed to parse retry topic filter: %v", err)
+		os.Exit(1)
 	}
 
 	bus, err := eventbus.NewKafkaEventBus(brokers)
@@ -34,25 +54,78 @@ func main() {
 
 	groupID := eventbus.GetGroupID() + "-retry-worker"
 
+	if err := eventbus.RegisterMetrics(nil); err != nil {
+		logger.Log.Errorf("failed to register eventbus metrics: %v", err)
+		os.Exit(1)
+	}
+	adminToken := getAdminTokenFromEnv()
+	if adminToken == "" {
+		logger.Log.Warn("RETRY_WORKER_ADMIN_TOKEN is not set; /admin endpoints are disabled")
+	}
+	lagMonitor := &eventbus.LagMonitor{
+		Brokers:    brokers,
+		Thresholds: eventbus.LagThresholdsFromEnv(),
+		Interval:   getDurationFromEnv("EVENTBUS_LAG_CHECK_INTERVAL"),
+		Metrics:    eventbus.DefaultMetrics,
+	}
+	srv := startHTTPServer(getMetricsAddrFromEnv(), newServeMux(bus, lagMonitor, adminToken))
+
 	logger.Log.Info("starting retry worker service with eventbus...")
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	for _, t := range eventbus.AllTopics {
-		topic := t
-		go func() {
-			topicGroupID := groupID + "-" + strings.ReplaceAll(topic.Base(), ".", "-")
-			if err := bus.StartRetryReinjector(ctx, topicGroupID, topic); err != nil && err != context.Canceled {
-				logger.Log.Errorf("eventbus retry reinjector error for %s: %v", topic.Base(), err)
-			}
-		}()
+	// 카탈로그 토픽뿐 아니라 브로커에 있는 모든 "<base>.retry.<n>" 토픽을 찾아 재주입한다.
+	supervisor := &eventbus.RetryReinjectorSupervisor{
+		Bus:      bus,
+		GroupID:  groupID,
+		Topics:   catalog.Topics(),
+		Filter:   filter,
+		Interval: getDurationFromEnv("RETRY_WORKER_DISCOVERY_INTERVAL"),
 	}
+	go func() {
+		if err := supervisor.Run(ctx); err != nil && err != context.Canceled {
+			logger.Log.Errorf("eventbus retry reinjector supervisor error: %v", err)
+		}
+	}()
+
+	go func() {
+		if err := lagMonitor.Run(ctx); err != nil && err != context.Canceled {
+			logger.Log.Errorf("eventbus lag monitor error: %v", err)
+		}
+	}()
+
+	go func() {
+		if err := bus.StartScheduler(ctx, groupID+"-scheduler"); err != nil && err != context.Canceled {
+			logger.Log.Errorf("eventbus scheduler error: %v", err)
+		}
+	}()
 
 	<-sigChan
 	logger.Log.Info("received shutdown signal, shutting down retry worker service...")
 
 	cancel()
 
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Log.Errorf("http server shutdown error: %v", err)
+	}
+
 	logger.Log.Info("retry worker service stopped")
 }
+
+// getDurationFromEnv 는 key 환경변수(예: "30s")에서 주기를 읽어온다.
+// 비어 있거나 잘못된 값이면 0 을 반환해 eventbus 기본값을 사용한다.
+func getDurationFromEnv(key string) time.Duration {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		logger.Log.Warnf("%s 환경변수 파싱 실패: %q. 기본값 사용.", key, v)
+		return 0
+	}
+	return d
+}
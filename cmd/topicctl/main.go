@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"tech-letter/cmd/internal/eventbus"
+	"tech-letter/cmd/internal/logger"
+)
+
+const usage = `topicctl: eventbus 토픽 카탈로그 적용 도구
+
+사용법:
+  topicctl diff   [-catalog <path>]
+  topicctl apply  [-catalog <path>] [-dry-run]
+
+-catalog 를 생략하면 EVENTBUS_TOPIC_CATALOG 환경변수의 카탈로그(없으면 기본 카탈로그)를 사용한다.
+diff 는 apply -dry-run 과 같으며 클러스터를 바꾸지 않고 차이만 출력한다.
+apply 는 없는 토픽 생성, 파티션 추가, 설정 변경을 적용한다. 파티션 축소와 복제 계수 변경은 MANUAL 로만 표시된다.
+`
+
+func main() {
+	logger.InitFromEnv("LOG_LEVEL")
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "diff":
+		err = runApply(ctx, "diff", args, true)
+	case "apply":
+		err = runApply(ctx, "apply", args, false)
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령: %s\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "topicctl %s 실패: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func runApply(ctx context.Context, name string, args []string, dryRun bool) error {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	path := fs.String("catalog", "", "YAML 토픽 카탈로그 경로")
+	if !dryRun {
+		fs.BoolVar(&dryRun, "dry-run", false, "변경하지 않고 차이만 출력")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		catalog *eventbus.TopicCatalog
+		err     error
+	)
+	if *path != "" {
+		catalog, err = eventbus.LoadTopicCatalog(*path)
+	} else {
+		catalog, err = eventbus.LoadTopicCatalogFromEnv()
+	}
+	if err != nil {
+		return err
+	}
+
+	changes, err := eventbus.EnsureTopics(ctx, eventbus.GetBrokers(), catalog, dryRun)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Println("카탈로그와 클러스터가 일치합니다")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TOPIC\tCHANGE\tKEY\tCURRENT\tTARGET")
+	manual := 0
+	for _, c := range changes {
+		if c.Kind == eventbus.TopicChangeManual {
+			manual++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Topic, c.Kind, dash(c.Key), dash(c.From), dash(c.To))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("변경 %d건, 수동 조치 %d건 (dry-run)\n", len(changes)-manual, manual)
+		return nil
+	}
+	fmt.Printf("변경 %d건 적용, 수동 조치 %d건\n", len(changes)-manual, manual)
+	return nil
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
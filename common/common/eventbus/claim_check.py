@@ -0,0 +1,236 @@
+"""큰 페이로드를 블롭 저장소로 분리하는 클레임 체크.
+
+Go eventbus(claim_check.go, blob_store.go)와 같은 환경변수·키 형식·참조(payload_ref)를 사용하므로
+Go 서비스가 분리한 페이로드를 Python 워커가 읽을 수 있고, 그 반대도 가능하다.
+
+- EVENTBUS_BLOB_STORE: "/path", "file:///path" 또는 "s3://bucket/prefix" (비어 있으면 사용 안 함)
+- EVENTBUS_CLAIM_CHECK_THRESHOLD_BYTES: 분리 기준 크기 (기본 512KiB)
+- EVENTBUS_CLAIM_CHECK_GC_GROUPS: 처리 후 블롭을 삭제할 컨슈머 그룹 (쉼표 구분, 비어 있으면 삭제하지 않고 저장소 TTL에 맡김)
+  DLQ로 보낸 이벤트의 블롭은 dlqctl show/replay를 위해 남겨 두고, 재처리한 이벤트가 성공하면 삭제한다.
+- EVENTBUS_S3_ENDPOINT / EVENTBUS_S3_REGION / EVENTBUS_S3_ACCESS_KEY / EVENTBUS_S3_SECRET_KEY
+"""
+
+from __future__ import annotations
+
+import dataclasses
+import hashlib
+import json
+import logging
+import os
+import tempfile
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Protocol
+from urllib.parse import quote, urlparse
+
+from .core import Event
+
+logger = logging.getLogger(__name__)
+
+DEFAULT_CLAIM_CHECK_THRESHOLD = 512 * 1024
+
+
+class BlobNotFoundError(Exception):
+    """블롭 저장소에 키가 없는 경우."""
+
+
+class BlobIntegrityError(Exception):
+    """블롭 내용이 이벤트에 기록된 크기/해시와 다른 경우."""
+
+
+class BlobStore(Protocol):
+    def put(self, key: str, data: bytes) -> None: ...
+
+    def get(self, key: str) -> bytes: ...
+
+    def delete(self, key: str) -> None: ...
+
+
+def _validate_key(key: str) -> None:
+    if not key or key.startswith("/") or ".." in key.split("/"):
+        raise ValueError(f"invalid blob key: {key!r}")
+
+
+class FileBlobStore:
+    """로컬 디렉터리에 블롭을 저장한다. 여러 서비스가 같은 볼륨을 공유할 때 사용한다."""
+
+    def __init__(self, root: str) -> None:
+        if not root:
+            raise ValueError("blob store directory is required")
+        self._root = Path(root)
+        self._root.mkdir(parents=True, exist_ok=True)
+
+    def put(self, key: str, data: bytes) -> None:
+        _validate_key(key)
+        path = self._root / key
+        path.parent.mkdir(parents=True, exist_ok=True)
+        # 읽는 쪽이 쓰다 만 파일을 보지 않도록 임시 파일에 쓴 뒤 이름을 바꾼다.
+        fd, tmp = tempfile.mkstemp(dir=path.parent, prefix=".blob-")
+        try:
+            with os.fdopen(fd, "wb") as f:
+                f.write(data)
+            os.replace(tmp, path)
+        except BaseException:
+            Path(tmp).unlink(missing_ok=True)
+            raise
+
+    def get(self, key: str) -> bytes:
+        _validate_key(key)
+        try:
+            return (self._root / key).read_bytes()
+        except FileNotFoundError as exc:
+            raise BlobNotFoundError(key) from exc
+
+    def delete(self, key: str) -> None:
+        _validate_key(key)
+        (self._root / key).unlink(missing_ok=True)
+
+
+class S3BlobStore:
+    """S3 호환 저장소(AWS S3, MinIO 등)에 블롭을 저장한다. boto3가 필요하다."""
+
+    def __init__(
+        self,
+        bucket: str,
+        prefix: str = "",
+        *,
+        endpoint: str | None = None,
+        region: str | None = None,
+        access_key: str | None = None,
+        secret_key: str | None = None,
+    ) -> None:
+        import boto3
+        from botocore.config import Config
+
+        if not bucket:
+            raise ValueError("S3 bucket is required")
+        self._bucket = bucket
+        self._prefix = prefix.strip("/")
+        # MinIO는 가상 호스트 방식을 지원하지 않는 경우가 많아 경로 방식을 사용한다(Go 구현과 동일).
+        self._client = boto3.client(
+            "s3",
+            endpoint_url=endpoint or None,
+            region_name=region or "us-east-1",
+            aws_access_key_id=access_key or None,
+            aws_secret_access_key=secret_key or None,
+            config=Config(s3={"addressing_style": "path"}),
+        )
+
+    def _object_key(self, key: str) -> str:
+        _validate_key(key)
+        return f"{self._prefix}/{key}" if self._prefix else key
+
+    def put(self, key: str, data: bytes) -> None:
+        self._client.put_object(
+            Bucket=self._bucket,
+            Key=self._object_key(key),
+            Body=data,
+            ContentType="application/json",
+        )
+
+    def get(self, key: str) -> bytes:
+        try:
+            resp = self._client.get_object(Bucket=self._bucket, Key=self._object_key(key))
+        except self._client.exceptions.NoSuchKey as exc:
+            raise BlobNotFoundError(key) from exc
+        return resp["Body"].read()
+
+    def delete(self, key: str) -> None:
+        self._client.delete_object(Bucket=self._bucket, Key=self._object_key(key))
+
+
+@dataclass(slots=True)
+class ClaimCheck:
+    """큰 페이로드를 블롭으로 분리하고, 구독 시 다시 채워 넣는다."""
+
+    store: BlobStore
+    threshold: int = DEFAULT_CLAIM_CHECK_THRESHOLD
+    gc_groups: list[str] = field(default_factory=list)
+
+    def offload(self, topic: str, event: Event) -> Event:
+        """인코딩한 페이로드가 임계값보다 크면 블롭으로 저장하고 참조만 남긴 이벤트를 반환한다."""
+        if event.payload_ref is not None or event.payload is None:
+            return event
+        data = json.dumps(event.payload, ensure_ascii=False).encode("utf-8")
+        if len(data) <= self.threshold:
+            return event
+
+        digest = hashlib.sha256(data).hexdigest()
+        ref = {
+            "key": f"{_base_topic(topic)}/{quote(event.id, safe='')}-{digest[:16]}.json",
+            "size": len(data),
+            "sha256": digest,
+        }
+        self.store.put(ref["key"], data)
+        logger.debug("offloaded payload of event %s (%d bytes) to %s", event.id, len(data), ref["key"])
+        return dataclasses.replace(event, payload=None, payload_ref=ref)
+
+    def resolve(self, event: Event) -> Event:
+        """블롭 참조만 가진 이벤트의 페이로드를 채운 사본을 반환한다. payload_ref는 GC를 위해 남긴다."""
+        ref = event.payload_ref
+        if ref is None or event.payload is not None:
+            return event
+        data = self.store.get(ref["key"])
+        if len(data) != ref.get("size") or hashlib.sha256(data).hexdigest() != ref.get("sha256"):
+            raise BlobIntegrityError(f"event {event.id} blob {ref['key']}")
+        return dataclasses.replace(event, payload=json.loads(data))
+
+    def release(self, group_id: str, event: Event) -> None:
+        """처리가 끝난 이벤트의 블롭을 삭제한다. 실패해도 처리 결과에는 영향을 주지 않는다."""
+        if event.payload_ref is None:
+            return
+        if group_id not in self.gc_groups:
+            return
+        try:
+            self.store.delete(event.payload_ref["key"])
+        except Exception as exc:  # noqa: BLE001
+            logger.warning("failed to delete blob %s of event %s: %s", event.payload_ref["key"], event.id, exc)
+
+
+def _base_topic(topic: str) -> str:
+    base, sep, suffix = topic.rpartition(".retry.")
+    if sep and base and suffix.isdigit():
+        return base
+    return topic.removesuffix(".dlq")
+
+
+def new_blob_store(raw: str) -> BlobStore:
+    """URL 형식의 설정으로 블롭 저장소를 만든다 (Go NewBlobStoreFromURL과 동일한 형식)."""
+    if raw.startswith("/"):
+        return FileBlobStore(raw)
+    url = urlparse(raw)
+    if url.scheme == "file":
+        return FileBlobStore(url.path)
+    if url.scheme == "s3":
+        return S3BlobStore(
+            url.netloc,
+            url.path,
+            endpoint=os.getenv("EVENTBUS_S3_ENDPOINT"),
+            region=os.getenv("EVENTBUS_S3_REGION"),
+            access_key=os.getenv("EVENTBUS_S3_ACCESS_KEY"),
+            secret_key=os.getenv("EVENTBUS_S3_SECRET_KEY"),
+        )
+    raise RuntimeError(f"unsupported EVENTBUS_BLOB_STORE: {raw!r}")
+
+
+def get_claim_check() -> ClaimCheck | None:
+    """EVENTBUS_BLOB_STORE 환경변수로 클레임 체크 설정을 만든다. 비어 있으면 None 을 반환한다."""
+    raw = os.getenv("EVENTBUS_BLOB_STORE", "").strip()
+    if not raw:
+        return None
+
+    threshold = DEFAULT_CLAIM_CHECK_THRESHOLD
+    raw_threshold = os.getenv("EVENTBUS_CLAIM_CHECK_THRESHOLD_BYTES", "").strip()
+    if raw_threshold:
+        try:
+            threshold = int(raw_threshold)
+        except ValueError as exc:  # noqa: TRY003
+            raise RuntimeError(
+                "EVENTBUS_CLAIM_CHECK_THRESHOLD_BYTES must be an integer value, got: "
+                f"{raw_threshold!r}"
+            ) from exc
+        if threshold <= 0:
+            threshold = DEFAULT_CLAIM_CHECK_THRESHOLD
+
+    groups = [g.strip() for g in os.getenv("EVENTBUS_CLAIM_CHECK_GC_GROUPS", "").split(",") if g.strip()]
+    return ClaimCheck(store=new_blob_store(raw), threshold=threshold, gc_groups=groups)
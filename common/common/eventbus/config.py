@@ -66,3 +66,48 @@ def get_max_poll_interval_ms() -> int | None:
         return None
 
     return value
+
+
+COMPRESSION_TYPES = ("none", "gzip", "snappy", "lz4", "zstd")
+
+
+def get_compression_type() -> str | None:
+    """Kafka producer 기본 압축 방식(compression.type)을 반환한다.
+
+    - 환경 변수 KAFKA_COMPRESSION_TYPE 이 비어있으면 None 을 반환한다(라이브러리 기본값 none).
+    - 지원하지 않는 값이 들어오면 명시적인 에러를 발생시킨다.
+    """
+
+    raw_value = os.getenv("KAFKA_COMPRESSION_TYPE", "").strip().lower()
+    if not raw_value:
+        return None
+    if raw_value not in COMPRESSION_TYPES:
+        raise RuntimeError(
+            f"KAFKA_COMPRESSION_TYPE must be one of {COMPRESSION_TYPES}, got: "
+            f"{raw_value!r}"
+        )
+    return raw_value
+
+
+def get_topic_compression() -> dict[str, str]:
+    """토픽별 압축 방식을 반환한다 (Go eventbus 와 같은 KAFKA_TOPIC_COMPRESSION 형식).
+
+    형식: "tech-letter.post.summary=zstd,tech-letter.post.embedding=lz4"
+    키는 기본 토픽 이름이며 재시도/DLQ 토픽에도 같은 압축을 사용한다.
+    """
+
+    raw_value = os.getenv("KAFKA_TOPIC_COMPRESSION", "").strip()
+    result: dict[str, str] = {}
+    for item in raw_value.split(","):
+        item = item.strip()
+        if not item:
+            continue
+        topic, sep, compression = item.partition("=")
+        topic = topic.strip()
+        compression = compression.strip().lower()
+        if not sep or not topic or compression not in COMPRESSION_TYPES:
+            raise RuntimeError(
+                f"invalid KAFKA_TOPIC_COMPRESSION entry: {item!r}",
+            )
+        result[topic] = compression
+    return result
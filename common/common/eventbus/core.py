@@ -31,6 +31,11 @@ class Event:
     retry: int = 0
     max_retry: int = 0
     last_error: str | None = None
+    # payload가 따르는 스키마 버전 (Go Event.SchemaVersion). 0이면 최신 버전으로 취급한다.
+    schema_version: int = 0
+    # 클레임 체크로 분리된 페이로드의 블롭 참조 {"key", "size", "sha256"} (Go Event.PayloadRef).
+    # 값이 있으면 payload는 None 이며, 구독 시 블롭을 읽어 payload를 채운다.
+    payload_ref: dict | None = None
 
     def __post_init__(self) -> None:
         if self.max_retry <= 0 or self.max_retry > len(RetryDelays):
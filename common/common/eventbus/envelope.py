@@ -0,0 +1,101 @@
+"""Kafka 메시지 봉투(envelope) 디코딩.
+
+Go eventbus(envelope.go, codec.go)는 기본 JSON 봉투 외에 MessagePack, Protobuf,
+CloudEvents 1.0 바이너리/구조화 모드로도 발행할 수 있다. Python 컨슈머는 헤더로 형식을
+판별해 모두 같은 Event로 복원한다(Protobuf 제외). 발행은 항상 기본 JSON 봉투를 사용한다.
+"""
+
+from __future__ import annotations
+
+import base64
+import json
+from typing import Any
+
+from .core import Event
+
+# Go eventbus(codec.go)가 바이너리 코덱 이름을 기록하는 헤더. 없으면 JSON 봉투다.
+EVENT_CODEC_HEADER = "X-Event-Codec"
+CE_HEADER_PREFIX = "ce_"
+CONTENT_TYPE_HEADER = "content-type"
+CLOUDEVENTS_JSON = "application/cloudevents+json"
+
+
+def decode_message(value: bytes | None, headers: list[tuple[str, bytes]] | None) -> Event:
+    """메시지 값과 헤더로 Event를 복원한다. 해석할 수 없으면 ValueError를 던진다."""
+    header_map = {k.lower(): v for k, v in (headers or [])}
+
+    codec = _to_str(header_map.get(EVENT_CODEC_HEADER.lower()))
+    if codec == "msgpack":
+        import msgpack
+
+        raw = msgpack.unpackb(value or b"", raw=False, timestamp=3)
+        if not isinstance(raw, dict):
+            raise ValueError("msgpack event must be a map")
+        return decode_event(raw)
+    if codec and codec != "json":
+        # protobuf 봉투는 Go 전용 토픽에서만 사용한다.
+        raise ValueError(f"unsupported event codec {codec!r}")
+
+    if "ce_specversion" in header_map:
+        attrs = {
+            k[len(CE_HEADER_PREFIX) :]: _to_str(v)
+            for k, v in header_map.items()
+            if k.startswith(CE_HEADER_PREFIX)
+        }
+        if CONTENT_TYPE_HEADER in header_map:
+            attrs["datacontenttype"] = _to_str(header_map[CONTENT_TYPE_HEADER])
+        data = json.loads(value) if value else None
+        return _from_cloudevent(attrs, data)
+
+    content_type = _to_str(header_map.get(CONTENT_TYPE_HEADER)).strip().lower()
+    if content_type.startswith(CLOUDEVENTS_JSON):
+        doc = json.loads(value or b"")
+        if not isinstance(doc, dict):
+            raise ValueError("structured CloudEvent must be a JSON object")
+        data = doc.pop("data", None)
+        if "data_base64" in doc:
+            data = json.loads(base64.b64decode(doc.pop("data_base64")))
+        attrs = {k: v if isinstance(v, str) else json.dumps(v) for k, v in doc.items()}
+        return _from_cloudevent(attrs, data)
+
+    return decode_event(json.loads(value or b""))
+
+
+def decode_event(raw: dict) -> Event:
+    """기본 JSON 봉투를 Event로 변환한다."""
+    return Event(
+        id=str(raw.get("id", "")),
+        payload=raw.get("payload"),
+        retry=int(raw.get("retry", 0)),
+        max_retry=int(raw.get("max_retry", 0)),
+        last_error=raw.get("last_error"),
+        schema_version=int(raw.get("schema_version") or 0),
+        payload_ref=raw.get("payload_ref"),
+    )
+
+
+def _from_cloudevent(attrs: dict[str, str], data: Any) -> Event:
+    if not attrs.get("specversion", "").startswith("1"):
+        raise ValueError(f"unsupported CloudEvents specversion {attrs.get('specversion')!r}")
+    for required in ("id", "source", "type"):
+        if not attrs.get(required):
+            raise ValueError(f"CloudEvent missing required attribute {required}")
+
+    # 재시도 메타데이터는 Go 코덱과 같은 확장 속성(retry, maxretry, lasterror, schemaversion, payloadref)에 담긴다.
+    return Event(
+        id=attrs["id"],
+        payload=data,
+        retry=int(attrs.get("retry") or 0),
+        max_retry=int(attrs.get("maxretry") or 0),
+        last_error=attrs.get("lasterror"),
+        schema_version=int(attrs.get("schemaversion") or 0),
+        payload_ref=json.loads(attrs["payloadref"]) if attrs.get("payloadref") else None,
+    )
+
+
+def _to_str(value: bytes | str | None) -> str:
+    if value is None:
+        return ""
+    if isinstance(value, bytes):
+        return value.decode("utf-8")
+    return value
@@ -0,0 +1,42 @@
+"""UUIDv7(RFC 9562) 이벤트 ID 생성.
+
+Go eventbus.NewEventID 와 같은 방식이다. 상위 48비트는 밀리초 타임스탬프라 시간순 정렬이 가능하고,
+같은 밀리초 안에서는 12비트 시퀀스를 증가시켜 프로세스 내 충돌과 역전을 막는다.
+"""
+
+from __future__ import annotations
+
+import os
+import threading
+import time
+import uuid
+
+_lock = threading.Lock()
+_last_ms = 0
+_seq = 0
+
+
+def new_event_id() -> str:
+    """UUIDv7 형식의 이벤트 ID 문자열을 만든다."""
+    global _last_ms, _seq
+
+    rand = bytearray(os.urandom(16))
+    with _lock:
+        ms = time.time_ns() // 1_000_000
+        if ms <= _last_ms:
+            _seq += 1
+            if _seq > 0x0FFF:
+                # 같은 밀리초에 4096개를 넘으면 다음 밀리초로 넘겨 단조 증가를 유지한다.
+                _last_ms += 1
+                _seq = 0
+            ms = _last_ms
+        else:
+            _last_ms = ms
+            _seq = int.from_bytes(rand[6:8], "big") & 0x07FF  # 시퀀스 시작값은 무작위(절반 범위)로 둔다.
+        seq = _seq
+
+    rand[0:6] = ms.to_bytes(6, "big")
+    rand[6] = 0x70 | (seq >> 8)  # version 7
+    rand[7] = seq & 0xFF
+    rand[8] = (rand[8] & 0x3F) | 0x80  # variant 10
+    return str(uuid.UUID(bytes=bytes(rand)))
@@ -3,15 +3,33 @@ from __future__ import annotations
 import json
 import logging
 from dataclasses import asdict
+from datetime import datetime, timedelta, timezone
 from typing import Callable
 
 from confluent_kafka import Consumer, KafkaError, Producer
 
+from .claim_check import (
+    BlobIntegrityError,
+    BlobNotFoundError,
+    get_claim_check,
+)
 from .core import Event, MaxRetryExceededError, Topic, RetryDelays
-from .config import get_max_poll_interval_ms, get_message_max_bytes
+from .config import (
+    get_compression_type,
+    get_max_poll_interval_ms,
+    get_message_max_bytes,
+    get_topic_compression,
+)
+from .envelope import decode_message
+from .rate_limit import RateLimit, RateLimiter, get_topic_rate_limits
+from .scheduler import schedule_headers
+from .topics import SCHEDULER_TOPIC
 
 logger = logging.getLogger(__name__)
 
+# Go eventbus(trace_headers.go)와 동일한 트레이싱 헤더 키
+TRACE_HEADER_KEYS = ("X-Request-Id", "X-Span-Id")
+
 
 class KafkaEventBus:
     """Kafka 기반 EventBus 구현.
@@ -26,27 +44,117 @@ class KafkaEventBus:
         if message_max_bytes is not None:
             producer_conf["message.max.bytes"] = message_max_bytes
 
+        compression = get_compression_type()
+        if compression is not None:
+            producer_conf["compression.type"] = compression
+
+        self._producer_conf = producer_conf
         self._producer = Producer(producer_conf)
         self._brokers = brokers
 
+        # librdkafka 는 producer 단위로만 압축을 설정할 수 있으므로,
+        # 기본과 다른 압축이 필요한 토픽은 압축 방식별 producer 를 따로 둔다.
+        self._compression = compression or "none"
+        self._topic_compression = get_topic_compression()
+        self._producers: dict[str, Producer] = {}
+
+        # 큰 페이로드는 블롭 저장소로 분리한다 (EVENTBUS_BLOB_STORE 미설정 시 None).
+        self._claim_check = get_claim_check()
+
+        # 토픽별 처리 속도 제한 (KAFKA_TOPIC_RATE_LIMIT, 카탈로그 rate_limit). 같은 토픽 구독은 버킷 하나를 공유한다.
+        self._topic_rate_limits = get_topic_rate_limits()
+        self._rate_limiters: dict[str, RateLimiter] = {}
+
     def close(self) -> None:
+        for producer in self._producers.values():
+            producer.flush()
         self._producer.flush()
 
+    def _producer_for(self, topic: str) -> Producer:
+        compression = self._topic_compression.get(_compression_topic_key(topic))
+        if compression is None or compression == self._compression:
+            return self._producer
+        producer = self._producers.get(compression)
+        if producer is None:
+            producer = Producer({**self._producer_conf, "compression.type": compression})
+            self._producers[compression] = producer
+        return producer
+
     # 발행 -----------------------------------------------------------------
-    def publish(self, topic: str, event: Event) -> None:
+    def publish(
+        self,
+        topic: str,
+        event: Event,
+        *,
+        headers: list[tuple[str, bytes]] | None = None,
+    ) -> None:
+        """이벤트를 발행한다.
+
+        headers는 재시도/DLQ 발행 시 원본 메시지의 트레이싱 헤더(X-Request-Id, X-Span-Id)를
+        그대로 이어 붙이기 위해 사용한다.
+        """
+        if self._claim_check is not None:
+            event = self._claim_check.offload(topic, event)
         payload = json.dumps(asdict(event), ensure_ascii=False).encode("utf-8")
 
         def _delivery_callback(err, msg) -> None:  # type: ignore[no-untyped-def]
             if err is not None:
                 logger.error("failed to deliver message to %s: %s", msg.topic(), err)
 
-        self._producer.produce(
+        producer = self._producer_for(topic)
+        producer.produce(
             topic=topic,
             value=payload,
             key=event.id.encode("utf-8"),
+            headers=headers,
+            callback=_delivery_callback,
+        )
+        producer.poll(0)
+
+    # 예약 발행 -------------------------------------------------------------
+    def publish_at(self, topic: str, event: Event, when: datetime) -> None:
+        """event 를 when 시각에 topic 으로 발행하도록 예약 토픽에 기록한다.
+
+        Go retryworker 의 스케줄러가 예약 시각에 발행한다. 예약은 이벤트 ID 로 식별하므로
+        같은 ID 로 다시 예약하면 이전 예약을 대체하고, cancel_scheduled 로 취소한다.
+        """
+        if not event.id:
+            raise ValueError("예약 이벤트에는 ID가 필요합니다")
+        headers = schedule_headers(topic, when)
+        if self._claim_check is not None:
+            event = self._claim_check.offload(topic, event)
+        payload = json.dumps(asdict(event), ensure_ascii=False).encode("utf-8")
+        self._produce_scheduler_record(event.id, payload, headers)
+
+    def publish_after(self, topic: str, event: Event, delay: timedelta) -> None:
+        """지금부터 delay 뒤에 발행하도록 예약한다."""
+        self.publish_at(topic, event, datetime.now(timezone.utc) + delay)
+
+    def cancel_scheduled(self, event_id: str) -> None:
+        """아직 발행되지 않은 예약을 취소한다. 이미 발행되었거나 없는 ID 여도 오류가 아니다."""
+        if not event_id:
+            raise ValueError("예약 이벤트에는 ID가 필요합니다")
+        self._produce_scheduler_record(event_id, None, None)
+
+    def _produce_scheduler_record(
+        self,
+        key: str,
+        value: bytes | None,
+        headers: list[tuple[str, bytes]] | None,
+    ) -> None:
+        def _delivery_callback(err, msg) -> None:  # type: ignore[no-untyped-def]
+            if err is not None:
+                logger.error("failed to deliver schedule %s: %s", key, err)
+
+        producer = self._producer_for(SCHEDULER_TOPIC)
+        producer.produce(
+            topic=SCHEDULER_TOPIC,
+            value=value,
+            key=key.encode("utf-8"),
+            headers=headers,
             callback=_delivery_callback,
         )
-        self._producer.poll(0)
+        producer.poll(0)
 
     # 구독 -----------------------------------------------------------------
     def subscribe(
@@ -57,7 +165,13 @@ class KafkaEventBus:
         *,
         poll_timeout: float = 0.1,
         stop_flag: list[bool] | None = None,
+        rate_limit: RateLimit | None = None,
     ) -> None:
+        """topic 을 구독해 handler 로 처리한다.
+
+        rate_limit 을 주지 않으면 KAFKA_TOPIC_RATE_LIMIT 환경변수나 토픽 카탈로그의 rate_limit 으로 처리 속도를 제한한다.
+        """
+        limiter = self._rate_limiter_for(topic.base, rate_limit)
         consumer_conf: dict[str, object] = {
             "bootstrap.servers": self._brokers,
             "group.id": group_id,
@@ -89,8 +203,11 @@ class KafkaEventBus:
                     logger.error("consumer error: %s", msg.error())
                     continue
 
+                if limiter is not None:
+                    limiter.wait()
+
                 try:
-                    raw = json.loads(msg.value())
+                    evt = decode_message(msg.value(), msg.headers())
                 except Exception as exc:  # noqa: BLE001
                     logger.error(
                         "invalid event payload on topic %s: %s", msg.topic(), exc
@@ -98,21 +215,29 @@ class KafkaEventBus:
                     consumer.commit(message=msg, asynchronous=False)
                     continue
 
-                evt = self._decode_event(raw)
+                trace_headers = self._trace_headers(msg.headers())
+                release = False
 
                 # MaxRetry 보정 (Go와 동일한 기본 동작)
                 if evt.max_retry <= 0 or evt.max_retry > len(RetryDelays):
                     evt.max_retry = len(RetryDelays)
 
                 try:
-                    handler(evt)
+                    # 블롭 참조는 핸들러에 넘기는 사본에서만 풀고, 재시도/DLQ에는 참조만 전달한다.
+                    handler(self._resolve(evt))
                 except Exception as exc:  # noqa: BLE001
                     # 핸들러 실패: 재시도 또는 DLQ
                     evt.last_error = str(exc)
                     next_retry = evt.retry + 1
-                    try:
-                        next_topic = topic.get_retry_topic(next_retry)
-                    except MaxRetryExceededError:
+                    # 블롭이 없거나 손상되었으면 재시도해도 소용없으므로 바로 DLQ로 보낸다.
+                    permanent = isinstance(exc, (BlobNotFoundError, BlobIntegrityError))
+                    next_topic: str | None = None
+                    if not permanent:
+                        try:
+                            next_topic = topic.get_retry_topic(next_retry)
+                        except MaxRetryExceededError:
+                            pass
+                    if next_topic is None:
                         logger.error(
                             "event %s exceeded max retry, sending to DLQ %s: %s",
                             evt.id,
@@ -120,12 +245,13 @@ class KafkaEventBus:
                             exc,
                         )
                         try:
-                            self.publish(topic.dlq(), evt)
+                            self.publish(topic.dlq(), evt, headers=trace_headers)
                         except Exception as pub_exc:  # noqa: BLE001
                             logger.error(
                                 "failed to publish event %s to DLQ: %s", evt.id, pub_exc
                             )
                             continue  # 커밋하지 않음 -> 다시 처리 시도
+                        # DLQ 레코드도 참조만 가지므로 dlqctl show/replay를 위해 블롭을 남겨 둔다.
                     else:
                         evt.retry = next_retry
                         logger.warning(
@@ -136,7 +262,7 @@ class KafkaEventBus:
                             next_topic,
                         )
                         try:
-                            self.publish(next_topic, evt)
+                            self.publish(next_topic, evt, headers=trace_headers)
                         except Exception as pub_exc:  # noqa: BLE001
                             logger.error(
                                 "failed to publish retry event %s to %s: %s",
@@ -145,25 +271,70 @@ class KafkaEventBus:
                                 pub_exc,
                             )
                             continue  # 커밋하지 않음 -> 다시 처리 시도
+                else:
+                    release = True
 
                 # 성공 또는 재시도/DLQ 발행 성공 시 오프셋 커밋
                 try:
                     consumer.commit(message=msg, asynchronous=False)
                 except Exception as exc:  # noqa: BLE001
                     logger.error("offset commit error: %s", exc)
+                    continue
+                # 블롭은 커밋한 뒤에 지운다. 커밋 전에 지우면 다시 받은 메시지가 블롭을 찾지 못한다.
+                if release:
+                    self._release(group_id, evt)
         finally:
             consumer.close()
 
     # 내부 util -------------------------------------------------------------
+    def _rate_limiter_for(self, topic: str, limit: RateLimit | None) -> RateLimiter | None:
+        if limit is None:
+            limit = self._topic_rate_limits.get(topic)
+        if limit is None:
+            return None
+        limiter = self._rate_limiters.get(topic)
+        if limiter is not None:
+            if limiter.limit != limit:
+                logger.warning(
+                    "rate limit of topic %s is already %s, ignoring %s", topic, limiter.limit, limit
+                )
+            return limiter
+        limiter = RateLimiter(topic, limit)
+        self._rate_limiters[topic] = limiter
+        logger.info("rate limit on topic %s: %s", topic, limit)
+        return limiter
+
+    def _resolve(self, evt: Event) -> Event:
+        if evt.payload_ref is None:
+            return evt
+        if self._claim_check is None:
+            raise RuntimeError(
+                f"event {evt.id} references blob {evt.payload_ref.get('key')} "
+                "but EVENTBUS_BLOB_STORE is not configured"
+            )
+        return self._claim_check.resolve(evt)
+
+    def _release(self, group_id: str, evt: Event) -> None:
+        if self._claim_check is not None:
+            self._claim_check.release(group_id, evt)
+
     @staticmethod
-    def _decode_event(raw: dict) -> Event:
-        return Event(
-            id=str(raw.get("id", "")),
-            payload=raw.get("payload"),
-            retry=int(raw.get("retry", 0)),
-            max_retry=int(raw.get("max_retry", 0)),
-            last_error=raw.get("last_error"),
-        )
+    def _trace_headers(
+        headers: list[tuple[str, bytes]] | None,
+    ) -> list[tuple[str, bytes]] | None:
+        """Go eventbus가 기록한 트레이싱 헤더만 골라 재시도/DLQ 발행에 이어 붙인다."""
+        if not headers:
+            return None
+        picked = [(k, v) for k, v in headers if k in TRACE_HEADER_KEYS]
+        return picked or None
+
+
+def _compression_topic_key(topic: str) -> str:
+    """재시도(<base>.retry.<n>)/DLQ(<base>.dlq) 토픽을 기본 토픽 이름으로 바꾼다."""
+    base, sep, suffix = topic.rpartition(".retry.")
+    if sep and base and suffix.isdigit():
+        return base
+    return topic.removesuffix(".dlq")
 
 
 # FastAPI Dependency Injection을 위한 팩토리 함수
@@ -0,0 +1,177 @@
+"""구독 처리 속도 제한 (토큰 버킷).
+
+Go eventbus(rate_limit.go)와 같은 형식·환경변수를 사용하므로, 같은 설정으로 Go/Python 컨슈머를 함께 제한할 수 있다.
+
+- KAFKA_TOPIC_RATE_LIMIT: "tech-letter.post.summary=30/m,tech-letter.post.embedding=5/s:10"
+- EVENTBUS_TOPIC_CATALOG: 토픽 카탈로그 YAML 의 rate_limit (환경변수가 우선한다)
+
+제한은 프로세스 안에서 토픽마다 하나의 버킷을 공유한다. 기다리는 동안에는 poll 하지 않으므로,
+제한이 낮다면 KAFKA_MAX_POLL_INTERVAL_MS 를 메시지 간격보다 넉넉히 잡아야 한다.
+"""
+
+from __future__ import annotations
+
+import logging
+import os
+import threading
+import time
+from dataclasses import dataclass
+from typing import Callable
+
+logger = logging.getLogger(__name__)
+
+# 속도 제한으로 기다린 시간을 모아 로그로 남기는 주기(초)
+RATE_LIMIT_REPORT_INTERVAL = 60.0
+
+_UNITS = {"s": 1.0, "m": 60.0, "h": 3600.0}
+
+
+@dataclass(frozen=True, slots=True)
+class RateLimit:
+    """초당 처리 수(rate)와 한 번에 몰아서 처리할 수 있는 최대 수(burst)."""
+
+    rate: float
+    burst: int = 1
+
+    def __str__(self) -> str:
+        return f"{self.rate:g}/s:{self.burst}"
+
+
+def parse_rate_limit(raw: str) -> RateLimit | None:
+    """"<개수>/<s|m|h>[:<burst>]" 형식(예: "30/m", "5/s:10")을 RateLimit 으로 바꾼다. 빈 문자열은 None(제한 없음)."""
+    raw = raw.strip()
+    if not raw:
+        return None
+    spec, has_burst, burst_raw = raw.partition(":")
+    count_raw, sep, unit = spec.partition("/")
+    if not sep or unit.strip() not in _UNITS:
+        raise ValueError(f"invalid rate limit {raw!r}: expected <count>/<s|m|h>[:<burst>]")
+    try:
+        count = float(count_raw.strip())
+    except ValueError as exc:
+        raise ValueError(f"invalid rate limit {raw!r}: count must be a number") from exc
+    if count <= 0:
+        raise ValueError(f"invalid rate limit {raw!r}: count must be positive")
+    burst = 1
+    if has_burst:
+        try:
+            burst = int(burst_raw.strip())
+        except ValueError as exc:
+            raise ValueError(f"invalid rate limit {raw!r}: burst must be an integer") from exc
+        if burst < 1:
+            raise ValueError(f"invalid rate limit {raw!r}: burst must be at least 1")
+    return RateLimit(rate=count / _UNITS[unit.strip()], burst=burst)
+
+
+class RateLimiter:
+    """토픽 하나의 토큰 버킷. 토큰이 모자라면 미리 예약(음수 잔량)하고 그만큼 기다린다."""
+
+    def __init__(
+        self,
+        topic: str,
+        limit: RateLimit,
+        *,
+        clock: Callable[[], float] = time.monotonic,
+        sleep: Callable[[float], None] = time.sleep,
+    ) -> None:
+        self.topic = topic
+        self.limit = limit
+        self._clock = clock
+        self._sleep = sleep
+        self._lock = threading.Lock()
+        self._tokens = float(limit.burst)
+        self._last = clock()
+        self._throttled = 0
+        self._waited = 0.0
+        self._reported_at = self._last
+
+    def reserve(self) -> float:
+        """토큰 하나를 가져가고, 처리를 시작하기 전에 기다려야 하는 시간(초)을 반환한다."""
+        with self._lock:
+            now = self._clock()
+            elapsed = now - self._last
+            if elapsed > 0:
+                self._tokens = min(float(self.limit.burst), self._tokens + elapsed * self.limit.rate)
+                self._last = now
+            self._tokens -= 1
+            if self._tokens >= 0:
+                return 0.0
+            return -self._tokens / self.limit.rate
+
+    def wait(self) -> None:
+        """토큰을 얻을 때까지 기다린다. 기다린 시간은 주기적으로 로그로 남긴다."""
+        wait = self.reserve()
+        if wait <= 0:
+            return
+        self._sleep(wait)
+        self._record(wait)
+
+    def _record(self, wait: float) -> None:
+        with self._lock:
+            self._throttled += 1
+            self._waited += wait
+            now = self._clock()
+            if now - self._reported_at < RATE_LIMIT_REPORT_INTERVAL:
+                return
+            logger.info(
+                "rate limit on topic %s delayed %d messages for %.1fs in the last %.0fs (limit %s)",
+                self.topic,
+                self._throttled,
+                self._waited,
+                now - self._reported_at,
+                self.limit,
+            )
+            self._throttled = 0
+            self._waited = 0.0
+            self._reported_at = now
+
+
+def get_topic_rate_limits() -> dict[str, RateLimit]:
+    """토픽 카탈로그(EVENTBUS_TOPIC_CATALOG)의 rate_limit 위에 KAFKA_TOPIC_RATE_LIMIT 를 덮어써 읽는다.
+
+    Go getTopicRateLimitsFromEnv 와 같이 잘못된 항목은 경고 후 건너뛴다.
+    """
+    result: dict[str, RateLimit] = {}
+    path = os.getenv("EVENTBUS_TOPIC_CATALOG", "").strip()
+    if path:
+        try:
+            result.update(_catalog_rate_limits(path))
+        except Exception as exc:  # noqa: BLE001
+            logger.warning("failed to read rate limits from topic catalog %s: %s", path, exc)
+
+    for item in os.getenv("KAFKA_TOPIC_RATE_LIMIT", "").split(","):
+        item = item.strip()
+        if not item:
+            continue
+        topic, sep, value = item.partition("=")
+        topic = topic.strip()
+        try:
+            limit = parse_rate_limit(value) if sep and topic else None
+        except ValueError:
+            limit = None
+        if limit is None:
+            logger.warning("invalid KAFKA_TOPIC_RATE_LIMIT entry %r, skipping", item)
+            continue
+        result[_base_topic(topic)] = limit
+    return result
+
+
+def _catalog_rate_limits(path: str) -> dict[str, RateLimit]:
+    import yaml
+
+    with open(path, encoding="utf-8") as f:
+        catalog = yaml.safe_load(f) or {}
+    default = parse_rate_limit(str((catalog.get("defaults") or {}).get("rate_limit") or ""))
+    result: dict[str, RateLimit] = {}
+    for entry in catalog.get("topics") or []:
+        limit = parse_rate_limit(str(entry.get("rate_limit") or "")) or default
+        if limit is not None:
+            result[entry["name"]] = limit
+    return result
+
+
+def _base_topic(topic: str) -> str:
+    base, sep, suffix = topic.rpartition(".retry.")
+    if sep and base and suffix.isdigit():
+        return base
+    return topic.removesuffix(".dlq")
@@ -0,0 +1,21 @@
+from __future__ import annotations
+
+from datetime import datetime
+
+# Go eventbus(scheduler.go)와 동일한 예약 헤더 키
+SCHEDULE_TOPIC_HEADER = "X-Schedule-Topic"
+SCHEDULE_AT_HEADER = "X-Schedule-At"
+
+
+def schedule_headers(topic: str, when: datetime) -> list[tuple[str, bytes]]:
+    """예약 레코드에 붙일 헤더(대상 토픽, Unix 밀리초 예약 시각)를 만든다.
+
+    시간대가 없는 datetime 은 해석이 모호하므로 받지 않는다.
+    """
+    if when.tzinfo is None:
+        raise ValueError("when 에는 시간대가 있는 datetime 이 필요합니다")
+    at_ms = int(when.timestamp() * 1000)
+    return [
+        (SCHEDULE_TOPIC_HEADER, topic.encode("utf-8")),
+        (SCHEDULE_AT_HEADER, str(at_ms).encode("utf-8")),
+    ]
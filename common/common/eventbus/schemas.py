@@ -0,0 +1,136 @@
+"""토픽별 페이로드 스키마 카탈로그.
+
+Go cmd/internal/eventbus 의 DefaultSchemas 와 같은 내용을 유지해야 한다.
+두 정의는 cmd/internal/eventbus/testdata/event_contracts.golden.json 골든 파일로
+양쪽 계약 테스트에서 함께 검증한다.
+"""
+
+from __future__ import annotations
+
+import dataclasses
+from dataclasses import dataclass
+from typing import Any
+
+from common.events.chat import (
+    ChatCompletedEvent,
+    ChatContextCompressionRequestedEvent,
+    ChatEventType,
+    ChatFailedEvent,
+)
+from common.events.credit import (
+    CreditConsumedEvent,
+    CreditEventType,
+    CreditGrantedEvent,
+)
+from common.events.post import (
+    EventType,
+    PostEmbedResponseEvent,
+    PostEmbeddingAppliedEvent,
+    PostEmbeddingDeleteRequestedEvent,
+    PostEmbeddingRequestedEvent,
+    PostSummaryRequestedEvent,
+    PostSummaryResponseEvent,
+)
+
+from .topics import (
+    TOPIC_CHAT,
+    TOPIC_CHAT_CONTEXT_COMPRESSION,
+    TOPIC_CREDIT,
+    TOPIC_POST_EMBEDDING,
+    TOPIC_POST_EMBEDDING_DELETE_REQUESTED,
+    TOPIC_POST_SUMMARY,
+)
+
+
+@dataclass(frozen=True, slots=True)
+class PayloadSchema:
+    type: str
+    version: int
+    model: type
+
+
+SCHEMA_CATALOG: dict[str, list[PayloadSchema]] = {
+    TOPIC_POST_SUMMARY.base: [
+        PayloadSchema(EventType.POST_SUMMARY_REQUESTED, 1, PostSummaryRequestedEvent),
+        PayloadSchema(EventType.POST_SUMMARY_RESPONSE, 1, PostSummaryResponseEvent),
+    ],
+    TOPIC_POST_EMBEDDING.base: [
+        PayloadSchema(
+            EventType.POST_EMBEDDING_REQUESTED, 1, PostEmbeddingRequestedEvent
+        ),
+        PayloadSchema(EventType.POST_EMBEDDING_RESPONSE, 1, PostEmbedResponseEvent),
+        PayloadSchema(EventType.POST_EMBEDDING_APPLIED, 1, PostEmbeddingAppliedEvent),
+    ],
+    TOPIC_POST_EMBEDDING_DELETE_REQUESTED.base: [
+        PayloadSchema(
+            EventType.POST_EMBEDDING_DELETE_REQUESTED,
+            1,
+            PostEmbeddingDeleteRequestedEvent,
+        ),
+    ],
+    TOPIC_CREDIT.base: [
+        PayloadSchema(CreditEventType.CREDIT_CONSUMED, 1, CreditConsumedEvent),
+        PayloadSchema(CreditEventType.CREDIT_GRANTED, 1, CreditGrantedEvent),
+    ],
+    TOPIC_CHAT.base: [
+        PayloadSchema(ChatEventType.CHAT_COMPLETED, 1, ChatCompletedEvent),
+        PayloadSchema(ChatEventType.CHAT_FAILED, 1, ChatFailedEvent),
+    ],
+    TOPIC_CHAT_CONTEXT_COMPRESSION.base: [
+        PayloadSchema(
+            ChatEventType.CHAT_CONTEXT_COMPRESSION_REQUESTED,
+            1,
+            ChatContextCompressionRequestedEvent,
+        ),
+    ],
+}
+
+
+def latest_schema_version(event_type: str) -> int:
+    """payload type의 최신 스키마 버전을 반환한다. 카탈로그에 없으면 0."""
+    versions = [
+        schema.version
+        for schemas in SCHEMA_CATALOG.values()
+        for schema in schemas
+        if schema.type == event_type
+    ]
+    return max(versions, default=0)
+
+
+def _field_contract(field: dataclasses.Field[Any]) -> dict[str, Any]:
+    # from __future__ import annotations 때문에 field.type은 문자열이다.
+    annotation = str(field.type)
+    parts = [p.strip() for p in annotation.split("|")]
+    optional = "None" in parts
+    base = next((p for p in parts if p != "None"), "Any")
+
+    if base.startswith("list["):
+        kind = "array"
+    elif base == "str":
+        kind = "string"
+    elif base == "int":
+        kind = "integer"
+    elif base == "float":
+        kind = "number"
+    elif base == "bool":
+        kind = "boolean"
+    else:
+        kind = "object"
+    return {"name": field.name, "kind": kind, "optional": optional}
+
+
+def describe_catalog() -> dict[str, Any]:
+    """골든 파일과 같은 형식으로 카탈로그를 정규화한다 (토픽/타입/버전/필드 이름순)."""
+    topics = []
+    for topic in sorted(SCHEMA_CATALOG):
+        payloads = []
+        for schema in sorted(SCHEMA_CATALOG[topic], key=lambda s: (s.type, s.version)):
+            fields = sorted(
+                (_field_contract(f) for f in dataclasses.fields(schema.model)),
+                key=lambda f: f["name"],
+            )
+            payloads.append(
+                {"type": schema.type, "version": schema.version, "fields": fields}
+            )
+        topics.append({"topic": topic, "payloads": payloads})
+    return {"topics": topics}
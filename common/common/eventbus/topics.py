@@ -12,6 +12,9 @@ TOPIC_CREDIT = Topic("tech-letter.credit")
 TOPIC_CHAT = Topic("tech-letter.chat")
 TOPIC_CHAT_CONTEXT_COMPRESSION = Topic("tech-letter.chat.context_compression")
 
+# 예약 발행(publish_at) 레코드를 보관하는 compact 토픽. Go eventbus.SchedulerTopic 과 같은 이름을 유지한다.
+SCHEDULER_TOPIC = "tech-letter.eventbus.scheduled"
+
 ALL_TOPICS: list[Topic] = [
     TOPIC_POST_SUMMARY,
     TOPIC_POST_EMBEDDING,
@@ -0,0 +1,73 @@
+from __future__ import annotations
+
+import hashlib
+import json
+
+import pytest
+
+from common.eventbus.claim_check import (
+    BlobIntegrityError,
+    BlobNotFoundError,
+    ClaimCheck,
+    FileBlobStore,
+)
+from common.eventbus.core import Event
+from common.eventbus.envelope import decode_message
+
+
+def test_offload_and_resolve_large_payload(tmp_path) -> None:
+    cc = ClaimCheck(store=FileBlobStore(str(tmp_path)), threshold=16, gc_groups=["group"])
+    evt = Event(id="evt/1", payload={"type": "post.summary_requested", "html": "x" * 64})
+
+    stored = cc.offload("tech-letter.post.summary.retry.2", evt)
+    assert stored.payload is None
+    ref = stored.payload_ref
+    assert ref is not None
+    assert ref["key"].startswith("tech-letter.post.summary/evt%2F1-")
+    data = json.dumps(evt.payload, ensure_ascii=False).encode("utf-8")
+    assert ref["size"] == len(data)
+    assert ref["sha256"] == hashlib.sha256(data).hexdigest()
+
+    resolved = cc.resolve(stored)
+    assert resolved.payload == evt.payload
+    assert resolved.payload_ref == ref
+
+    cc.release("group", stored)
+    with pytest.raises(BlobNotFoundError):
+        cc.resolve(stored)
+
+
+def test_small_payload_is_not_offloaded(tmp_path) -> None:
+    cc = ClaimCheck(store=FileBlobStore(str(tmp_path)), threshold=1024)
+    evt = Event(id="evt-1", payload={"type": "x"})
+    assert cc.offload("t", evt) is evt
+
+
+def test_resolve_detects_tampered_blob(tmp_path) -> None:
+    store = FileBlobStore(str(tmp_path))
+    cc = ClaimCheck(store=store, threshold=1)
+    stored = cc.offload("t", Event(id="evt-1", payload={"type": "x"}))
+    store.put(stored.payload_ref["key"], b'{"type":"y"}')
+    with pytest.raises(BlobIntegrityError):
+        cc.resolve(stored)
+
+
+def test_release_respects_gc_groups(tmp_path) -> None:
+    cc = ClaimCheck(store=FileBlobStore(str(tmp_path)), threshold=1, gc_groups=["last"])
+    stored = cc.offload("t", Event(id="evt-1", payload={"type": "x"}))
+    cc.release("other", stored)
+    assert cc.resolve(stored).payload == {"type": "x"}
+    # gc_groups 가 비어 있으면 어느 그룹도 삭제하지 않는다.
+    ClaimCheck(store=cc.store).release("last", stored)
+    assert cc.resolve(stored).payload == {"type": "x"}
+    cc.release("last", stored)
+    with pytest.raises(BlobNotFoundError):
+        cc.resolve(stored)
+
+
+def test_decode_go_payload_ref() -> None:
+    ref = {"key": "t/evt-1-0123456789abcdef.json", "size": 12, "sha256": "ab"}
+    value = json.dumps({"id": "evt-1", "payload": None, "payload_ref": ref}).encode()
+    evt = decode_message(value, None)
+    assert evt.payload is None
+    assert evt.payload_ref == ref
@@ -0,0 +1,75 @@
+from __future__ import annotations
+
+import json
+
+import pytest
+
+from common.eventbus.envelope import decode_message
+
+
+def test_decode_default_json_envelope() -> None:
+    value = json.dumps(
+        {"id": "evt-1", "payload": {"type": "x"}, "retry": 1, "max_retry": 3}
+    ).encode()
+    evt = decode_message(value, [("X-Request-Id", b"req-1")])
+    assert evt.id == "evt-1"
+    assert evt.payload == {"type": "x"}
+    assert evt.retry == 1
+
+
+def test_decode_cloudevents_binary() -> None:
+    headers = [
+        ("ce_specversion", b"1.0"),
+        ("ce_id", b"evt-ce"),
+        ("ce_source", b"/tech-letter"),
+        ("ce_type", b"post.summary_requested"),
+        ("ce_retry", b"2"),
+        ("ce_maxretry", b"5"),
+        ("ce_lasterror", b"timeout"),
+        ("content-type", b"application/json"),
+    ]
+    evt = decode_message(b'{"post_id": "p1"}', headers)
+    assert evt.id == "evt-ce"
+    assert evt.payload == {"post_id": "p1"}
+    assert (evt.retry, evt.max_retry, evt.last_error) == (2, 5, "timeout")
+
+
+def test_decode_cloudevents_structured() -> None:
+    doc = {
+        "specversion": "1.0",
+        "id": "evt-ce",
+        "source": "/tech-letter",
+        "type": "post.summary_requested",
+        "datacontenttype": "application/json",
+        "retry": 1,
+        "maxretry": 5,
+        "schemaversion": 1,
+        "data": {"post_id": "p1"},
+    }
+    evt = decode_message(
+        json.dumps(doc).encode(),
+        [("content-type", b"application/cloudevents+json; charset=UTF-8")],
+    )
+    assert evt.payload == {"post_id": "p1"}
+    assert (evt.retry, evt.max_retry, evt.schema_version) == (1, 5, 1)
+
+
+def test_decode_cloudevents_requires_core_attributes() -> None:
+    with pytest.raises(ValueError):
+        decode_message(b"{}", [("ce_specversion", b"1.0"), ("ce_id", b"x")])
+
+
+def test_decode_msgpack_codec() -> None:
+    msgpack = pytest.importorskip("msgpack")
+    value = msgpack.packb(
+        {"id": "evt-mp", "payload": {"post_id": "p1"}, "retry": 1, "max_retry": 3}
+    )
+    evt = decode_message(value, [("X-Event-Codec", b"msgpack")])
+    assert evt.id == "evt-mp"
+    assert evt.payload == {"post_id": "p1"}
+    assert evt.retry == 1
+
+
+def test_decode_rejects_unsupported_codec() -> None:
+    with pytest.raises(ValueError):
+        decode_message(b"\x0a\x01x", [("X-Event-Codec", b"protobuf")])
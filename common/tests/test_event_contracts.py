@@ -0,0 +1,41 @@
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from common.eventbus.helpers import new_json_event
+from common.eventbus.schemas import describe_catalog
+from common.eventbus.topics import ALL_TOPICS
+from common.events.post import EventType
+
+# Go 계약 테스트(cmd/internal/eventbus/schema_test.go)와 같은 골든 파일을 사용한다.
+GOLDEN_PATH = (
+    Path(__file__).resolve().parents[2]
+    / "cmd"
+    / "internal"
+    / "eventbus"
+    / "testdata"
+    / "event_contracts.golden.json"
+)
+
+
+def test_schema_catalog_matches_golden() -> None:
+    golden = json.loads(GOLDEN_PATH.read_text(encoding="utf-8"))
+    assert describe_catalog() == golden, (
+        "Python 스키마 카탈로그가 Go 골든 파일과 다릅니다. "
+        "common/events 와 cmd/internal/eventbus/payloads.go 를 함께 수정하세요."
+    )
+
+
+def test_all_topics_have_schemas() -> None:
+    golden = json.loads(GOLDEN_PATH.read_text(encoding="utf-8"))
+    golden_topics = {t["topic"] for t in golden["topics"]}
+    assert {t.base for t in ALL_TOPICS} == golden_topics
+
+
+def test_new_json_event_stamps_schema_version() -> None:
+    evt = new_json_event({"type": EventType.POST_SUMMARY_REQUESTED})
+    assert evt.schema_version == 1
+
+    untyped = new_json_event({"foo": "bar"})
+    assert untyped.schema_version == 0
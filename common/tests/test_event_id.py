@@ -0,0 +1,32 @@
+from __future__ import annotations
+
+import re
+import time
+import uuid
+
+from common.eventbus.event_id import new_event_id
+from common.eventbus.helpers import new_json_event
+
+UUID7 = re.compile(r"^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$")
+
+
+def test_new_event_id_is_uuid7() -> None:
+    before = time.time_ns() // 1_000_000
+    event_id = new_event_id()
+    assert UUID7.match(event_id), event_id
+    parsed = uuid.UUID(event_id)
+    assert parsed.version == 7
+    assert parsed.variant == uuid.RFC_4122
+    assert int.from_bytes(parsed.bytes[:6], "big") >= before
+
+
+def test_new_event_id_is_monotonic() -> None:
+    ids = [new_event_id() for _ in range(5000)]
+    assert ids == sorted(ids)
+    assert len(set(ids)) == len(ids)
+
+
+def test_new_json_event_generates_uuid7_id() -> None:
+    evt = new_json_event({"type": "post.summary_requested"})
+    assert UUID7.match(evt.id), evt.id
+    assert new_json_event({"type": "x"}, event_id="evt-1").id == "evt-1"
@@ -0,0 +1,61 @@
+from __future__ import annotations
+
+import pytest
+
+from common.eventbus.rate_limit import (
+    RateLimit,
+    RateLimiter,
+    get_topic_rate_limits,
+    parse_rate_limit,
+)
+
+
+def test_parse_rate_limit_matches_go_format() -> None:
+    assert parse_rate_limit("") is None
+    assert parse_rate_limit("30/m") == RateLimit(rate=0.5, burst=1)
+    assert parse_rate_limit("5/s:10") == RateLimit(rate=5.0, burst=10)
+    assert parse_rate_limit("3600/h") == RateLimit(rate=1.0, burst=1)
+    for raw in ("30", "30/d", "0/s", "5/s:0", "x/s"):
+        with pytest.raises(ValueError):
+            parse_rate_limit(raw)
+
+
+def test_rate_limiter_spaces_out_messages() -> None:
+    now = [0.0]
+    slept: list[float] = []
+
+    def sleep(seconds: float) -> None:
+        slept.append(seconds)
+        now[0] += seconds
+
+    limiter = RateLimiter("t", RateLimit(rate=2.0, burst=2), clock=lambda: now[0], sleep=sleep)
+    for _ in range(4):
+        limiter.wait()
+    # burst 2건은 바로 처리하고, 그 뒤로는 0.5초 간격으로 처리한다.
+    assert slept == [0.5, 0.5]
+
+
+def test_topic_rate_limits_from_catalog_and_env(tmp_path, monkeypatch) -> None:
+    catalog = tmp_path / "topics.yaml"
+    catalog.write_text(
+        "defaults:\n"
+        "  rate_limit: 100/m\n"
+        "topics:\n"
+        "  - name: tech-letter.post.summary\n"
+        "    rate_limit: 30/m\n"
+        "  - name: tech-letter.post.embedding\n"
+        "  - name: tech-letter.credit\n",
+        encoding="utf-8",
+    )
+    monkeypatch.setenv("EVENTBUS_TOPIC_CATALOG", str(catalog))
+    monkeypatch.setenv(
+        "KAFKA_TOPIC_RATE_LIMIT",
+        "tech-letter.post.embedding.retry.1=5/s:10,broken,tech-letter.chat=1/d",
+    )
+
+    limits = get_topic_rate_limits()
+    assert limits == {
+        "tech-letter.post.summary": RateLimit(rate=0.5, burst=1),
+        "tech-letter.post.embedding": RateLimit(rate=5.0, burst=10),
+        "tech-letter.credit": RateLimit(rate=100 / 60, burst=1),
+    }
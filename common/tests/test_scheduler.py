@@ -0,0 +1,25 @@
+from __future__ import annotations
+
+from datetime import datetime, timedelta, timezone
+
+import pytest
+
+from common.eventbus.scheduler import schedule_headers
+from common.eventbus.topics import SCHEDULER_TOPIC
+
+
+def test_schedule_headers_use_unix_millis() -> None:
+    kst = timezone(timedelta(hours=9))
+    when = datetime(2025, 6, 10, 8, 0, 0, 250000, tzinfo=kst)
+    headers = dict(schedule_headers("tech-letter.newsletter.events", when))
+    assert headers["X-Schedule-Topic"] == b"tech-letter.newsletter.events"
+    assert headers["X-Schedule-At"] == b"1749510000250"
+
+
+def test_schedule_headers_reject_naive_datetime() -> None:
+    with pytest.raises(ValueError):
+        schedule_headers("t", datetime(2025, 6, 10, 8, 0, 0))
+
+
+def test_scheduler_topic_matches_go() -> None:
+    assert SCHEDULER_TOPIC == "tech-letter.eventbus.scheduled"
@@ -34,7 +34,7 @@ class ChatEventHandler:
 
     def handle_chat_completed(self, event_data: Any):
         """chat.completed 이벤트 핸들러."""
-        # event_data는 Event 객체일 것임 (KafkaEventBus._decode_event로 디코딩됨)
+        # event_data는 Event 객체일 것임 (eventbus.envelope.decode_message로 디코딩됨)
         # common/eventbus/kafka.py의 subscribe 메서드 확인:
         # handler(evt) 호출함. evt는 Event 객체.
 